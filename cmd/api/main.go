@@ -17,19 +17,35 @@ La aplicación utiliza Gin como framework web y Swagger para documentación auto
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"meli-products-api/domain"
+	analyticsCommands "meli-products-api/internal/application/commands/analytics"
+	productCommands "meli-products-api/internal/application/commands/product"
+	"meli-products-api/internal/application/controllers/analytics"
+	"meli-products-api/internal/application/controllers/catalog"
 	"meli-products-api/internal/application/controllers/product"
 	"meli-products-api/internal/application/mediator"
+	analyticsQueries "meli-products-api/internal/application/queries/analytics"
+	catalogQueries "meli-products-api/internal/application/queries/catalog"
 	productQueries "meli-products-api/internal/application/queries/product"
 	"meli-products-api/internal/delivery/rest/controllers"
 	"meli-products-api/internal/delivery/rest/middleware"
 	jsonRepo "meli-products-api/internal/repository/json"
+	"meli-products-api/internal/repository/logstore"
+	"meli-products-api/internal/repository/searchlog"
+	sqliteRepo "meli-products-api/internal/repository/sqlite"
 
 	// Import docs for swagger generation
 	_ "meli-products-api/docs"
@@ -53,24 +69,68 @@ import (
 // @externalDocs.description  OpenAPI
 // @externalDocs.url          https://swagger.io/resources/open-api/
 func main() {
-	// Inicializar repositorio con datos JSON
+	backend := flag.String("backend", backendJSON, "product repository backend: json, sqlite or log. "+
+		"sqlite and log apply the taxonomy and spec schemas but not search synonyms or hot reload; "+
+		"sqlite search also skips typo tolerance and does not rank prefix matches below whole words")
+	sqlitePath := flag.String("sqlite-path", filepath.Join("data", "products.db"), "SQLite database file, used with -backend sqlite")
+	logPath := flag.String("log-path", filepath.Join("data", "products.log"), "product log file, used with -backend log")
+	flag.Parse()
+
+	// Validar los productos: en modo estricto la API no inicia si alguno es inválido, en
+	// modo permisivo se descartan y se informan en /admin/catalog/validation
+	validationMode, err := domain.ParseCatalogValidationMode(envOrDefault(catalogValidationEnv, string(domain.ValidationStrict)))
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", catalogValidationEnv, err)
+	}
+
+	// Inicializar el repositorio de productos elegido
 	dataPath := filepath.Join("data", "products.json")
-	repo, err := jsonRepo.NewProductRepository(dataPath)
+	var repo productStore
+	switch *backend {
+	case backendJSON:
+		repo = setupJSONRepository(dataPath, validationMode)
+	case backendSQLite:
+		db := setupSQLiteRepository(*sqlitePath, dataPath, validationMode)
+		defer db.Close()
+		repo = db
+	case backendLog:
+		store := setupLogRepository(*logPath, dataPath, validationMode)
+		defer store.Close()
+		repo = store
+	default:
+		log.Fatalf("Invalid -backend %q, use %s, %s or %s", *backend, backendJSON, backendSQLite, backendLog)
+	}
+	if report := repo.GetValidationReport(); len(report.Rejected) > 0 {
+		log.Printf("Skipped %d invalid products, see /api/v1/admin/catalog/validation", len(report.Rejected))
+	}
+
+	// Cargar reglas de comparación ubicadas junto a los datos
+	rulesPath := filepath.Join("data", "comparison_rules.json")
+	rules, err := jsonRepo.LoadComparisonRules(rulesPath)
 	if err != nil {
-		log.Fatalf("Failed to initialize repository: %v", err)
+		log.Fatalf("Failed to load comparison rules: %v", err)
+	}
+
+	// Abrir el registro de búsquedas utilizado por los reportes de búsquedas
+	searchLog, err := searchlog.Open(searchLogDir, searchlog.DefaultMaxBytes, searchlog.DefaultMaxBackups)
+	if err != nil {
+		log.Fatalf("Failed to open search log: %v", err)
 	}
+	defer searchLog.Close()
 
 	// Inicializar mediator
 	mediatorInstance := mediator.NewMediator()
 
 	// Registrar handlers con el mediator
-	registerHandlers(mediatorInstance, repo)
+	registerHandlers(mediatorInstance, repo, rules, searchLog)
 
-	// Inicializar controlador
+	// Inicializar controladores
 	productController := controllers.NewProductController(mediatorInstance)
+	analyticsController := controllers.NewAnalyticsController(mediatorInstance)
+	catalogController := controllers.NewCatalogController(mediatorInstance)
 
 	// Configurar router de Gin
-	router := setupRouter(productController)
+	router := setupRouter(productController, analyticsController, catalogController)
 
 	// Iniciar servidor
 	log.Println("Starting Products Comparison API on port 8080...")
@@ -81,21 +141,244 @@ func main() {
 	}
 }
 
-// registerHandlers registra todos los handlers de queries con el mediator
-func registerHandlers(m mediator.Mediator, repo *jsonRepo.ProductRepository) {
+// Backends del repositorio de productos
+const (
+	backendJSON   = "json"
+	backendSQLite = "sqlite"
+	backendLog    = "log"
+)
+
+// productStore agrupa las operaciones del repositorio de productos que usan los handlers;
+// la implementan los repositorios JSON, SQLite y de registro append-only
+type productStore interface {
+	domain.WritableProductRepository
+	domain.ProductSearcher
+	domain.ProductAutocompleter
+	GetCategoryTree() []domain.CategoryTreeNode
+	GetBrands() []string
+	GetValidationReport() *domain.CatalogValidationReport
+}
+
+// setupJSONRepository carga el catálogo desde el archivo JSON con la taxonomía, los esquemas
+// de especificaciones y los sinónimos, y lo recarga cuando cambia alguno de los archivos o al
+// recibir SIGHUP
+func setupJSONRepository(dataPath string, validationMode domain.CatalogValidationMode) *jsonRepo.ProductRepository {
+	repo, err := jsonRepo.NewProductRepository(dataPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize repository: %v", err)
+	}
+	if err := repo.SetValidationMode(validationMode); err != nil {
+		log.Fatalf("Failed to validate products: %v", err)
+	}
+
+	applyCatalogRules(repo)
+
+	// Cargar sinónimos de búsqueda y recargarlos cuando cambie el archivo
+	synonymsPath := filepath.Join("data", "synonyms.json")
+	synonyms, err := jsonRepo.LoadSynonyms(synonymsPath)
+	if err != nil {
+		log.Fatalf("Failed to load synonyms: %v", err)
+	}
+	repo.SetSynonyms(synonyms)
+	go jsonRepo.WatchFile(context.Background(), synonymsPath, synonymsReloadInterval, func() {
+		reloadSynonyms(repo, synonymsPath)
+	})
+
+	// Recargar el catálogo cuando cambie el archivo de productos o al recibir SIGHUP
+	go jsonRepo.WatchFile(context.Background(), dataPath, catalogReloadInterval, func() {
+		reloadCatalog(repo, dataPath)
+	})
+	go reloadOnSignal(repo, dataPath)
+
+	return repo
+}
+
+// catalogRulesRepository es un repositorio que organiza las categorías según una taxonomía y
+// valida las especificaciones contra esquemas por categoría
+type catalogRulesRepository interface {
+	SetTaxonomy(taxonomy *domain.Taxonomy) error
+	SetSpecSchemas(schemas *domain.SpecSchemaSet) error
+}
+
+// applyCatalogRules carga la taxonomía y los esquemas de especificaciones ubicados junto a
+// los datos y los aplica al repositorio; la API no inicia si algún producto no los cumple
+func applyCatalogRules(repo catalogRulesRepository) {
+	// Organizar las categorías según la taxonomía
+	taxonomyPath := filepath.Join("data", "taxonomy.json")
+	taxonomy, err := jsonRepo.LoadTaxonomy(taxonomyPath)
+	if err != nil {
+		log.Fatalf("Failed to load taxonomy: %v", err)
+	}
+	if err := repo.SetTaxonomy(taxonomy); err != nil {
+		log.Fatalf("Failed to apply taxonomy: %v", err)
+	}
+
+	// Validar las especificaciones de los productos contra el esquema de su categoría
+	schemasPath := filepath.Join("data", "spec_schemas.json")
+	schemas, err := jsonRepo.LoadSpecSchemas(schemasPath)
+	if err != nil {
+		log.Fatalf("Failed to load spec schemas: %v", err)
+	}
+	if err := repo.SetSpecSchemas(schemas); err != nil {
+		log.Fatalf("Failed to apply spec schemas: %v", err)
+	}
+}
+
+// setupSQLiteRepository abre la base SQLite con la taxonomía y los esquemas de
+// especificaciones y, si no tiene productos, importa los del archivo JSON validados según el
+// modo indicado. A partir de entonces la base es la fuente de datos. Los sinónimos de
+// búsqueda y la recarga en caliente no se aplican.
+func setupSQLiteRepository(dbPath, dataPath string, validationMode domain.CatalogValidationMode) *sqliteRepo.ProductRepository {
+	repo, err := sqliteRepo.Open(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	applyCatalogRules(repo)
+	log.Printf("WARNING: -backend %s does not apply search synonyms or typo tolerance, or reload %s on changes", backendSQLite, dataPath)
+
+	if repo.GetProductCount() == 0 {
+		products, err := jsonRepo.LoadProducts(dataPath)
+		if err != nil {
+			log.Fatalf("Failed to load products to import: %v", err)
+		}
+		if err := repo.Import(products, validationMode); err != nil {
+			log.Fatalf("Failed to import products: %v", err)
+		}
+		log.Printf("Imported %d products from %s into %s", repo.GetProductCount(), dataPath, dbPath)
+	}
+
+	return repo
+}
+
+// setupLogRepository abre el registro append-only de productos, recuperándolo si la última
+// escritura quedó interrumpida, y si no tiene productos importa los del archivo JSON. La
+// importación se escribe como un lote, por lo que si se interrumpe se descarta completa y
+// se repite al iniciar. Se aplican la taxonomía y los esquemas de especificaciones, pero no
+// los sinónimos de búsqueda. El registro se compacta periódicamente.
+func setupLogRepository(logPath, dataPath string, validationMode domain.CatalogValidationMode) *logstore.ProductRepository {
+	store, err := logstore.Open(logPath)
+	if err != nil {
+		log.Fatalf("Failed to open product log: %v", err)
+	}
+	if recovered := store.RecoveredBytes(); recovered > 0 {
+		log.Printf("Discarded %d bytes of an interrupted write at the end of %s", recovered, logPath)
+	}
+	applyCatalogRules(store)
+	log.Printf("WARNING: -backend %s does not apply search synonyms or reload %s on changes", backendLog, dataPath)
+
+	if store.GetProductCount() == 0 {
+		products, err := jsonRepo.LoadProducts(dataPath)
+		if err != nil {
+			log.Fatalf("Failed to load products to import: %v", err)
+		}
+		if err := store.Import(products, validationMode); err != nil {
+			log.Fatalf("Failed to import products: %v", err)
+		}
+		log.Printf("Imported %d products from %s into %s", store.GetProductCount(), dataPath, logPath)
+	}
+
+	go store.RunCompaction(context.Background(), logCompactionInterval, logstore.DefaultCompactionRatio, func(before logstore.LogStats, err error) {
+		if err != nil {
+			log.Printf("Failed to compact product log: %v", err)
+			return
+		}
+		log.Printf("Compacted product log: %d records to %d", before.Records, before.LiveProducts)
+	})
+
+	return store
+}
+
+// logCompactionInterval es la frecuencia con la que se verifica si conviene compactar el
+// registro de productos
+const logCompactionInterval = time.Minute
+
+// catalogValidationEnv es la variable de entorno que elige el modo de validación de los
+// productos al cargar el catálogo: strict (por defecto) o lenient
+const catalogValidationEnv = "CATALOG_VALIDATION"
+
+// envOrDefault devuelve el valor de la variable de entorno, o el valor por defecto si no está definida
+func envOrDefault(name, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// searchLogDir es el directorio del registro rotativo de búsquedas y clics
+var searchLogDir = filepath.Join("logs", "search")
+
+// synonymsReloadInterval es la frecuencia con la que se verifica si cambió el archivo de sinónimos
+const synonymsReloadInterval = 5 * time.Second
+
+// reloadSynonyms vuelve a cargar el archivo de sinónimos; si es inválido se conservan los anteriores
+func reloadSynonyms(repo *jsonRepo.ProductRepository, path string) {
+	synonyms, err := jsonRepo.LoadSynonyms(path)
+	if err != nil {
+		log.Printf("Keeping previous synonyms, failed to reload: %v", err)
+		return
+	}
+
+	repo.SetSynonyms(synonyms)
+	log.Printf("Reloaded %d synonym rules from %s", len(synonyms.Rules), path)
+}
+
+// catalogReloadInterval es la frecuencia con la que se verifica si cambió el archivo de productos
+const catalogReloadInterval = 5 * time.Second
+
+// reloadCatalog vuelve a cargar el archivo de productos; si es inválido se conserva el catálogo anterior
+func reloadCatalog(repo *jsonRepo.ProductRepository, path string) {
+	if err := repo.Reload(); err != nil {
+		log.Printf("Keeping previous catalog, failed to reload: %v", err)
+		return
+	}
+
+	report := repo.GetValidationReport()
+	log.Printf("Reloaded %d products from %s (%d skipped as invalid)", report.LoadedProducts, path, len(report.Rejected))
+}
+
+// reloadOnSignal recarga el catálogo cada vez que el proceso recibe SIGHUP
+func reloadOnSignal(repo *jsonRepo.ProductRepository, path string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		log.Println("Received SIGHUP, reloading catalog")
+		reloadCatalog(repo, path)
+	}
+}
+
+// registerHandlers registra todos los handlers de queries y comandos con el mediator
+func registerHandlers(m mediator.Mediator, repo productStore, rules *domain.ComparisonRuleSet, searchLog domain.SearchLog) {
 	// Registrar handlers de productos
 	m.Register(&productQueries.GetProductQuery{}, product.NewGetProductHandler(repo))
 	m.Register(&productQueries.GetAllProductsQuery{}, product.NewGetAllProductsHandler(repo))
-	m.Register(&productQueries.CompareProductsQuery{}, product.NewCompareProductsHandler(repo))
-	m.Register(&productQueries.SearchProductsQuery{}, product.NewSearchProductsHandler(repo))
+	m.Register(&productQueries.QueryProductsQuery{}, product.NewQueryProductsHandler(repo))
+	m.Register(&productQueries.CompareProductsQuery{}, product.NewCompareProductsHandler(repo, rules))
+	m.Register(&productQueries.ScoreProductsQuery{}, product.NewScoreProductsHandler(repo, rules))
+	m.Register(&productQueries.SearchProductsQuery{}, analytics.NewSearchLoggingHandler(product.NewSearchProductsHandler(repo), searchLog))
+	m.Register(&productQueries.SuggestProductsQuery{}, product.NewSuggestProductsHandler(repo))
+
+	// Registrar handlers de escritura de productos
+	m.Register(&productCommands.CreateProductCommand{}, product.NewCreateProductHandler(repo))
+	m.Register(&productCommands.UpdateProductCommand{}, product.NewUpdateProductHandler(repo))
+	m.Register(&productCommands.PatchProductCommand{}, product.NewPatchProductHandler(repo))
+	m.Register(&productCommands.DeleteProductCommand{}, product.NewDeleteProductHandler(repo))
 
 	// Registrar handlers de metadatos
 	m.Register(&productQueries.GetCategoriesQuery{}, product.NewGetCategoriesHandler(repo))
 	m.Register(&productQueries.GetBrandsQuery{}, product.NewGetBrandsHandler(repo))
+
+	// Registrar handlers del registro y los reportes de búsquedas
+	m.Register(&analyticsCommands.RecordSearchClickCommand{}, analytics.NewRecordSearchClickHandler(repo, searchLog))
+	m.Register(&analyticsQueries.GetSearchReportQuery{}, analytics.NewGetSearchReportHandler(searchLog))
+
+	// Registrar handlers de administración del catálogo
+	m.Register(&catalogQueries.GetValidationReportQuery{}, catalog.NewGetValidationReportHandler(repo))
 }
 
 // setupRouter configura y devuelve el router de Gin con todas las rutas y middleware
-func setupRouter(productController *controllers.ProductController) *gin.Engine {
+func setupRouter(productController *controllers.ProductController, analyticsController *controllers.AnalyticsController, catalogController *controllers.CatalogController) *gin.Engine {
 	// Establecer Gin en modo release para producción (comentar para desarrollo)
 	// gin.SetMode(gin.ReleaseMode)
 
@@ -122,13 +405,32 @@ func setupRouter(productController *controllers.ProductController) *gin.Engine {
 		{
 			products.GET("", productController.GetAllProducts)
 			products.GET("/search", productController.SearchProducts)
+			products.POST("/search/clicks", analyticsController.RecordSearchClick)
+			products.GET("/suggest", productController.SuggestProducts)
+			products.POST("/query", productController.QueryProducts)
 			products.GET("/compare", productController.CompareProducts)
+			products.POST("/compare/score", productController.ScoreProducts)
 			products.GET("/:id", productController.GetProduct)
+			products.POST("", productController.CreateProduct)
+			products.PUT("/:id", productController.UpdateProduct)
+			products.PATCH("/:id", productController.PatchProduct)
+			products.DELETE("/:id", productController.DeleteProduct)
 		}
 
 		// Rutas de metadatos
 		v1.GET("/categories", productController.GetCategories)
 		v1.GET("/brands", productController.GetBrands)
+
+		// Rutas de administración: reportes de búsquedas
+		admin := v1.Group("/admin/search")
+		{
+			admin.GET("/top-queries", analyticsController.GetTopQueries)
+			admin.GET("/zero-result-queries", analyticsController.GetZeroResultQueries)
+			admin.GET("/clickless-queries", analyticsController.GetClicklessQueries)
+		}
+
+		// Rutas de administración: catálogo
+		v1.GET("/admin/catalog/validation", catalogController.GetValidationReport)
 	}
 
 	// Redirección de raíz a swagger
@@ -24,6 +24,224 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/catalog/validation": {
+            "get": {
+                "description": "Report the validation mode applied at the last catalog load and, in lenient mode, the products that were skipped with the fields that failed validation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Catalog validation report",
+                "responses": {
+                    "200": {
+                        "description": "Validation report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CatalogValidationReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/search/clickless-queries": {
+            "get": {
+                "description": "Report the normalized queries that returned results but none of whose searches led to a recorded click in the time window ending now",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Click-less search queries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d\"",
+                        "description": "Time window ending now, e.g. 1h, 24h or 7d (default 24h, max 90d)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Maximum number of queries (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SearchReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid window or limit",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/search/top-queries": {
+            "get": {
+                "description": "Report the most searched normalized queries in the time window ending now, with result counts, latency and click-through rate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Top search queries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d\"",
+                        "description": "Time window ending now, e.g. 1h, 24h or 7d (default 24h, max 90d)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Maximum number of queries (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SearchReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid window or limit",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/search/zero-result-queries": {
+            "get": {
+                "description": "Report the normalized queries that returned no results in the time window ending now, ordered by failed searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Zero-result search queries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"7d\"",
+                        "description": "Time window ending now, e.g. 1h, 24h or 7d (default 24h, max 90d)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Maximum number of queries (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SearchReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid window or limit",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/brands": {
             "get": {
                 "description": "Retrieve a list of all available product brands",
@@ -55,7 +273,7 @@ const docTemplate = `{
         },
         "/categories": {
             "get": {
-                "description": "Retrieve a list of all available product categories",
+                "description": "Retrieve the category tree defined by the taxonomy, with the number of products in each subtree. Products belong to leaf categories; filtering by any category includes its subcategories",
                 "consumes": [
                     "application/json"
                 ],
@@ -65,12 +283,28 @@ const docTemplate = `{
                 "tags": [
                     "metadata"
                 ],
-                "summary": "Get all available categories",
+                "summary": "Get the category tree",
                 "responses": {
                     "200": {
                         "description": "Categories retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "description": "Response model for the category tree",
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CategoryTreeNode"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
@@ -107,7 +341,7 @@ const docTemplate = `{
         },
         "/products": {
             "get": {
-                "description": "Retrieve all products with optional filtering by category and price range",
+                "description": "Retrieve all products with optional filtering by category, price range, brand, availability, rating and specifications.\nSpecification predicates are passed as raw query parameters of the form spec.\u003cname\u003e\u003cop\u003e\u003cvalue\u003e, where op is one of =, !=, \u003e, \u003e=, \u003c, \u003c= or ~ (contains), e.g. spec.RAM\u003e=12 or spec.Almacenamiento\u003e=1TB",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,6 +373,68 @@ const docTemplate = `{
                         "description": "Maximum price filter",
                         "name": "max_price",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Samsung\"",
+                        "description": "Filter by brand",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Filter by availability",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "example": 4.5,
+                        "description": "Minimum rating filter (0-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number (starting at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Page size (1-100, default 20)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"-rating,price\"",
+                        "description": "Comma-separated sort keys: price, rating, name or completeness, with optional '-' prefix or ':asc'/':desc' suffix",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.next_cursor, alternative to page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"brand,price,spec.RAM\"",
+                        "description": "Comma-separated facets computed over all matching products and returned in meta.facets: brand, category, price, rating or spec.\u003cname\u003e",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"500,1000,2000\"",
+                        "description": "Ascending comma-separated price bucket limits for the price facet (default 250,500,1000,2000)",
+                        "name": "price_buckets",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -157,6 +453,9 @@ const docTemplate = `{
                                             "items": {
                                                 "$ref": "#/definitions/domain.Product"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/meli-products-api_pkg_response.Meta"
                                         }
                                     }
                                 }
@@ -176,11 +475,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products/compare": {
-            "get": {
-                "description": "Retrieve and compare multiple products by their IDs for feature comparison",
+            },
+            "post": {
+                "description": "Add a product to the catalog and persist it to the data file. The product is validated with the same rules as the catalog load: required fields, taxonomy category and specification schema",
                 "consumes": [
                     "application/json"
                 ],
@@ -190,32 +487,57 @@ const docTemplate = `{
                 "tags": [
                     "products"
                 ],
-                "summary": "Compare multiple products",
+                "summary": "Create a product",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"PHONE001,PHONE002,PHONE003\"",
-                        "description": "Comma-separated product IDs",
-                        "name": "ids",
-                        "in": "query",
-                        "required": true
+                        "description": "Product to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_internal_application_commands_product.CreateProductCommand"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Products comparison retrieved successfully",
+                    "201": {
+                        "description": "Product created successfully",
                         "schema": {
-                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Product version"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid product IDs or insufficient products for comparison",
+                        "description": "Malformed request body",
                         "schema": {
                             "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "One or more products not found",
+                    "409": {
+                        "description": "A product with the same ID already exists",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid product fields, listed in error.fields",
                         "schema": {
                             "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
                         }
@@ -229,9 +551,206 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/search": {
+        "/products/compare": {
+            "get": {
+                "description": "Retrieve and compare multiple products by their IDs, including a specification matrix aligned by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Compare multiple products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"PHONE001,PHONE002,PHONE003\"",
+                        "description": "Comma-separated product IDs",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products comparison retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_delivery_rest_controllers.ProductComparisonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product IDs or insufficient products for comparison",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "One or more products not found",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/compare/score": {
+            "post": {
+                "description": "Rank the compared products by a weighted score. Each numeric criterion (price, rating or a specification name) is normalized across the compared set and weighted as requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Score compared products with weighted preferences",
+                "parameters": [
+                    {
+                        "description": "Product IDs and weights per criterion",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_internal_application_queries_product.ScoreProductsQuery"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products scored successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_delivery_rest_controllers.ProductScoreResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or product IDs",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid weights or criteria",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/query": {
+            "post": {
+                "description": "Retrieve products matching a boolean filter tree with sorting, pagination and field projection.\nEach filter node has exactly one of: and/or (list of nodes), not (node), field+op+value over id, name, description, brand, category, price, rating or available, spec+op+value over a specification, or text. Operators are =, !=, \u003e, \u003e=, \u003c, \u003c= and ~ (contains)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Query products with a JSON document",
+                "parameters": [
+                    {
+                        "description": "Filter tree, sort keys, pagination and projected fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_internal_application_queries_product.QueryProductsQuery"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Product"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/meli-products-api_pkg_response.Meta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fields in the query document, listed in error.fields",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/search": {
             "get": {
-                "description": "Search for products by name, description, brand, or category",
+                "description": "Search for products by name, description, brand, or category.\nThe query may combine free text with structured terms: field terms \u003cfield\u003e\u003cop\u003e\u003cvalue\u003e over id, name, description, brand, category, price, rating, available, a specification declared by some product, or any specification with the spec. prefix (op is :, =, !=, \u003e, \u003e=, \u003c, \u003c= or ~); other field names fail with 400, quoted phrases, negation with - or NOT, and OR groups in parentheses, e.g. brand:Samsung price\u003c1000 ram\u003e=8 \"S Pen\"",
                 "consumes": [
                     "application/json"
                 ],
@@ -245,22 +764,92 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"Samsung Galaxy\"",
-                        "description": "Search query",
+                        "example": "\"brand:Samsung price\u003c1000 \\\"S Pen\\\"\"",
+                        "description": "Search query, optionally with structured terms",
                         "name": "q",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Page number (starting at 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Page size (1-100, default 20)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"price:asc\"",
+                        "description": "Comma-separated sort keys: price, rating, name or completeness, with optional '-' prefix or ':asc'/':desc' suffix",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.next_cursor, alternative to page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"brand,price,spec.RAM\"",
+                        "description": "Comma-separated facets computed over all matching products and returned in meta.facets: brand, category, price, rating or spec.\u003cname\u003e",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"500,1000,2000\"",
+                        "description": "Ascending comma-separated price bucket limits for the price facet (default 250,500,1000,2000)",
+                        "name": "price_buckets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "Return name and description snippets with the matched terms wrapped in \u003cem\u003e",
+                        "name": "highlight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": false,
+                        "description": "Return the matched terms per field and the BM25 score components of each hit",
+                        "name": "explain",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Products search completed successfully",
                         "schema": {
-                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_delivery_rest_controllers.ProductSearchResponse"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/meli-products-api_pkg_response.Meta"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid or missing search query",
+                        "description": "Invalid or missing search query, or syntax error in a structured query",
                         "schema": {
                             "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
                         }
@@ -274,160 +863,1680 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieve detailed information about a specific product by its unique identifier",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "summary": "Get a product by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"PHONE001\"",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Product retrieved successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.Product"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid product ID",
-                        "schema": {
-                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Product not found",
-                        "schema": {
-                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
-                        }
+        "/products/search/clicks": {
+            "post": {
+                "description": "Record that the user opened a product from the results of a search, identified by the search_id returned by /products/search",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Record a search result click",
+                "parameters": [
+                    {
+                        "description": "Search ID and opened product ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_internal_application_commands_analytics.RecordSearchClickCommand"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Search click recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SearchClick"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing search or product ID, listed in error.fields",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/suggest": {
+            "get": {
+                "description": "Suggest product names, brands and categories with a word starting with the typed prefix, ranked by popularity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Autocomplete search queries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"gal\"",
+                        "description": "Prefix typed by the user (at least 1 character)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Maximum number of suggestions (1-10, default 5)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suggestions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_delivery_rest_controllers.ProductSuggestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing prefix or limit",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieve detailed information about a specific product by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"PHONE001\"",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Product version, to send in If-Match when modifying the product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace every editable field of an existing product and persist the change to the data file. Fields missing from the body are cleared.\nThe expected version is optional: when sent in If-Match or in the version field, the product is only replaced if it is still at that version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Replace a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"PHONE001\"",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3\"",
+                        "description": "ETag of the version the change is based on, or * to replace any version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New product fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_internal_application_commands_product.UpdateProductCommand"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New product version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID, If-Match header or request body",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The product is no longer at the expected version; error.details has the current version",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid product fields, listed in error.fields",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a product from the catalog and the data file, returning the deleted product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"PHONE001\"",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Modify some fields of an existing product and persist the change to the data file. Fields missing from the body keep their value; specifications, when present, replace the whole list.\nThe version the change is based on is required, in the If-Match header (the ETag of the product) or in the version field; if another change was applied first the request fails with 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Modify a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"PHONE001\"",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3\"",
+                        "description": "ETag of the version the change is based on; required unless the body has a version, * is not accepted",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Product fields to modify",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_internal_application_commands_product.PatchProductCommand"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New product version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID, If-Match header or request body",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The product is no longer at the expected version; error.details has the current version",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Missing version or invalid product fields, listed in error.fields",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/meli-products-api_pkg_response.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.CatalogValidationMode": {
+            "type": "string",
+            "enum": [
+                "disabled",
+                "strict",
+                "lenient"
+            ],
+            "x-enum-varnames": [
+                "ValidationDisabled",
+                "ValidationStrict",
+                "ValidationLenient"
+            ]
+        },
+        "domain.CatalogValidationReport": {
+            "description": "Result of validating the products at the last catalog load",
+            "type": "object",
+            "properties": {
+                "loaded_products": {
+                    "description": "Cantidad de productos cargados en el catálogo",
+                    "type": "integer",
+                    "example": 118
+                },
+                "mode": {
+                    "description": "Modo de validación aplicado",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CatalogValidationMode"
+                        }
+                    ],
+                    "example": "lenient"
+                },
+                "rejected": {
+                    "description": "Productos descartados por ser inválidos, en el orden del archivo",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RejectedProduct"
+                    }
+                },
+                "total_products": {
+                    "description": "Cantidad de productos del archivo de datos",
+                    "type": "integer",
+                    "example": 120
+                },
+                "validated_at": {
+                    "description": "Momento de la validación",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CategoryRef": {
+            "description": "Category in a product breadcrumb, from the root to the product category",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Identificador de la categoría",
+                    "type": "string",
+                    "example": "telefonia"
+                },
+                "name": {
+                    "description": "Nombre de la categoría",
+                    "type": "string",
+                    "example": "Telefonía"
+                }
+            }
+        },
+        "domain.CategoryTreeNode": {
+            "description": "Category tree node with the number of products in its subtree",
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "Subcategorías",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CategoryTreeNode"
+                    }
+                },
+                "id": {
+                    "description": "Identificador de la categoría",
+                    "type": "string",
+                    "example": "smartphones"
+                },
+                "name": {
+                    "description": "Nombre de la categoría",
+                    "type": "string",
+                    "example": "Smartphones"
+                },
+                "product_count": {
+                    "description": "Cantidad de productos de la categoría y de todas sus subcategorías",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.ComparisonCell": {
+            "description": "Single cell of a comparison row",
+            "type": "object",
+            "properties": {
+                "missing": {
+                    "description": "Indica que el producto no declara esta especificación",
+                    "type": "boolean",
+                    "example": false
+                },
+                "product_id": {
+                    "description": "ID del producto al que pertenece la celda",
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "unit": {
+                    "description": "Unidad de medida si aplica",
+                    "type": "string",
+                    "example": "GB"
+                },
+                "value": {
+                    "description": "Valor de la especificación",
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "domain.ComparisonMatrix": {
+            "description": "Aligned specification matrix for product comparison",
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "description": "Filas de los campos principales del producto (price, rating)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SpecComparisonRow"
+                    }
+                },
+                "product_ids": {
+                    "description": "IDs de los productos comparados, en el orden de las celdas de cada fila",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PHONE001",
+                        "PHONE002"
+                    ]
+                },
+                "rows": {
+                    "description": "Filas de la matriz, una por nombre de especificación",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SpecComparisonRow"
+                    }
+                }
+            }
+        },
+        "domain.CriterionContribution": {
+            "description": "Contribution of a single criterion to a product score",
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "description": "Aporte ponderado a la puntuación total",
+                    "type": "number",
+                    "example": 0.4
+                },
+                "criterion": {
+                    "description": "Nombre del criterio (\"price\", \"rating\" o nombre de especificación)",
+                    "type": "string",
+                    "example": "RAM"
+                },
+                "missing": {
+                    "description": "Indica que el producto no tiene un valor utilizable para el criterio",
+                    "type": "boolean",
+                    "example": false
+                },
+                "normalized": {
+                    "description": "Valor normalizado entre 0 (peor) y 1 (mejor) dentro del conjunto comparado",
+                    "type": "number",
+                    "example": 1
+                },
+                "value": {
+                    "description": "Valor original del producto para el criterio",
+                    "type": "string",
+                    "example": "12"
+                },
+                "weight": {
+                    "description": "Peso solicitado para el criterio",
+                    "type": "number",
+                    "example": 2
+                }
+            }
+        },
+        "domain.FilterNode": {
+            "description": "Boolean filter tree node",
+            "type": "object",
+            "properties": {
+                "and": {
+                    "description": "Condiciones que deben cumplirse todas",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FilterNode"
+                    }
+                },
+                "field": {
+                    "description": "Campo del producto (id, name, description, brand, category, price, rating, available)",
+                    "type": "string",
+                    "example": "brand"
+                },
+                "not": {
+                    "description": "Condición que no debe cumplirse",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FilterNode"
+                        }
+                    ]
+                },
+                "op": {
+                    "description": "Operador de comparación (=, !=, \u003e, \u003e=, \u003c, \u003c=, ~)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FilterOperator"
+                        }
+                    ],
+                    "example": "="
+                },
+                "or": {
+                    "description": "Condiciones de las que debe cumplirse al menos una",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FilterNode"
+                    }
+                },
+                "spec": {
+                    "description": "Nombre de la especificación",
+                    "type": "string",
+                    "example": "RAM"
+                },
+                "text": {
+                    "description": "Palabra o frase que debe aparecer en el nombre, la marca, la categoría o la descripción",
+                    "type": "string",
+                    "example": "S Pen"
+                },
+                "value": {
+                    "description": "Valor esperado: texto, número o booleano según el campo",
+                    "type": "string",
+                    "example": "Samsung"
+                }
+            }
+        },
+        "domain.FilterOperator": {
+            "type": "string",
+            "enum": [
+                "=",
+                "!=",
+                "\u003e",
+                "\u003e=",
+                "\u003c",
+                "\u003c=",
+                "~"
+            ],
+            "x-enum-varnames": [
+                "OpEqual",
+                "OpNotEqual",
+                "OpGreater",
+                "OpGreaterOrEqual",
+                "OpLess",
+                "OpLessOrEqual",
+                "OpContains"
+            ]
+        },
+        "domain.Product": {
+            "description": "Product model for comparison",
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "image_url",
+                "name",
+                "price",
+                "rating"
+            ],
+            "properties": {
+                "available": {
+                    "description": "Estado de disponibilidad",
+                    "type": "boolean",
+                    "example": true
+                },
+                "brand": {
+                    "description": "Marca del producto",
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "breadcrumbs": {
+                    "description": "Camino de categorías desde la raíz de la taxonomía hasta la categoría del producto",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CategoryRef"
+                    }
+                },
+                "category": {
+                    "description": "Categoría del producto",
+                    "type": "string",
+                    "example": "Smartphones"
+                },
+                "completeness": {
+                    "description": "Completitud de las especificaciones según el esquema de su categoría; nil si no hay esquema",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SpecCompleteness"
+                        }
+                    ]
+                },
+                "description": {
+                    "description": "Descripción detallada del producto",
+                    "type": "string",
+                    "example": "Latest Samsung flagship smartphone with advanced camera technology"
+                },
+                "id": {
+                    "description": "Identificador único del producto",
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "image_url": {
+                    "description": "URL de la imagen del producto",
+                    "type": "string",
+                    "example": "https://images.example.com/samsung-s24.jpg"
+                },
+                "name": {
+                    "description": "Nombre del producto",
+                    "type": "string",
+                    "example": "Samsung Galaxy S24 Ultra"
+                },
+                "price": {
+                    "description": "Precio del producto en formato decimal",
+                    "type": "number",
+                    "example": 1299.99
+                },
+                "rating": {
+                    "description": "Calificación del producto (escala 1-5)",
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 4.5
+                },
+                "specifications": {
+                    "description": "Especificaciones técnicas del producto",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Specification"
+                    }
+                },
+                "version": {
+                    "description": "Versión del producto: empieza en 1 y aumenta con cada modificación",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.ProductScore": {
+            "description": "Weighted score of a product within a comparison",
+            "type": "object",
+            "properties": {
+                "contributions": {
+                    "description": "Aporte de cada criterio a la puntuación total",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CriterionContribution"
+                    }
+                },
+                "product_id": {
+                    "description": "ID del producto puntuado",
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "rank": {
+                    "description": "Posición en el ranking (1 es el mejor)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "description": "Puntuación total normalizada entre 0 y 1",
+                    "type": "number",
+                    "example": 0.82
+                }
+            }
+        },
+        "domain.QueryStat": {
+            "description": "Search statistics of a normalized query",
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "description": "Latencia promedio en milisegundos",
+                    "type": "number",
+                    "example": 0.38
+                },
+                "avg_results": {
+                    "description": "Promedio de resultados por búsqueda",
+                    "type": "number",
+                    "example": 3
+                },
+                "click_through_rate": {
+                    "description": "Proporción de búsquedas en las que se abrió al menos un producto",
+                    "type": "number",
+                    "example": 0.4048
+                },
+                "clicked_searches": {
+                    "description": "Cantidad de búsquedas en las que se abrió al menos un producto",
+                    "type": "integer",
+                    "example": 17
+                },
+                "last_searched_at": {
+                    "description": "Momento de la última búsqueda",
+                    "type": "string"
+                },
+                "query": {
+                    "description": "Consulta normalizada",
+                    "type": "string",
+                    "example": "samsung galaxy"
+                },
+                "searches": {
+                    "description": "Cantidad de búsquedas",
+                    "type": "integer",
+                    "example": 42
+                },
+                "zero_result_searches": {
+                    "description": "Cantidad de búsquedas sin resultados",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "domain.RejectedProduct": {
+            "description": "Product skipped at catalog load because it failed validation",
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "Errores de validación, uno por campo inválido",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ValidationError"
+                    }
+                },
+                "index": {
+                    "description": "Posición del producto en el archivo de datos",
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_id": {
+                    "description": "ID del producto, si lo tiene",
+                    "type": "string",
+                    "example": "PHONE004"
+                }
+            }
+        },
+        "domain.SearchClick": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "description": "ID del producto abierto",
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "search_id": {
+                    "description": "ID de la búsqueda de la que proviene el clic, devuelto como search_id por la búsqueda",
+                    "type": "string",
+                    "example": "srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"
+                },
+                "timestamp": {
+                    "description": "Momento del clic",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SearchExplanation": {
+            "description": "Score breakdown of a search hit",
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "description": "Coincidencias de los términos de la consulta por campo",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TermMatch"
+                    }
+                },
+                "score": {
+                    "description": "Puntuación de relevancia del resultado",
+                    "type": "number",
+                    "example": 3.42
+                }
+            }
+        },
+        "domain.SearchHit": {
+            "description": "Product matched by a search with its relevance score",
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "image_url",
+                "name",
+                "price",
+                "rating"
+            ],
+            "properties": {
+                "available": {
+                    "description": "Estado de disponibilidad",
+                    "type": "boolean",
+                    "example": true
+                },
+                "brand": {
+                    "description": "Marca del producto",
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "breadcrumbs": {
+                    "description": "Camino de categorías desde la raíz de la taxonomía hasta la categoría del producto",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CategoryRef"
+                    }
+                },
+                "category": {
+                    "description": "Categoría del producto",
+                    "type": "string",
+                    "example": "Smartphones"
+                },
+                "completeness": {
+                    "description": "Completitud de las especificaciones según el esquema de su categoría; nil si no hay esquema",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SpecCompleteness"
+                        }
+                    ]
+                },
+                "description": {
+                    "description": "Descripción detallada del producto",
+                    "type": "string",
+                    "example": "Latest Samsung flagship smartphone with advanced camera technology"
+                },
+                "explanation": {
+                    "description": "Detalle de las coincidencias y de los componentes del puntaje, si se solicitó",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SearchExplanation"
+                        }
+                    ]
+                },
+                "highlights": {
+                    "description": "Nombre y descripción con los términos coincidentes marcados con \u003cem\u003e, si se solicitaron",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Identificador único del producto",
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "image_url": {
+                    "description": "URL de la imagen del producto",
+                    "type": "string",
+                    "example": "https://images.example.com/samsung-s24.jpg"
+                },
+                "name": {
+                    "description": "Nombre del producto",
+                    "type": "string",
+                    "example": "Samsung Galaxy S24 Ultra"
+                },
+                "price": {
+                    "description": "Precio del producto en formato decimal",
+                    "type": "number",
+                    "example": 1299.99
+                },
+                "rating": {
+                    "description": "Calificación del producto (escala 1-5)",
+                    "type": "number",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 4.5
+                },
+                "score": {
+                    "description": "Puntuación de relevancia; mayor es más relevante",
+                    "type": "number",
+                    "example": 3.42
+                },
+                "specifications": {
+                    "description": "Especificaciones técnicas del producto",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Specification"
+                    }
+                },
+                "version": {
+                    "description": "Versión del producto: empieza en 1 y aumenta con cada modificación",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.SearchReport": {
+            "description": "Search analytics report over a time window",
+            "type": "object",
+            "properties": {
+                "queries": {
+                    "description": "Consultas del reporte, de mayor a menor cantidad de búsquedas (sin resultados, en el\nreporte de consultas sin resultados)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QueryStat"
+                    }
+                },
+                "report": {
+                    "description": "Reporte calculado",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SearchReportKind"
+                        }
+                    ],
+                    "example": "top_queries"
+                },
+                "since": {
+                    "description": "Inicio del período, inclusive",
+                    "type": "string"
+                },
+                "total_searches": {
+                    "description": "Cantidad total de búsquedas en el período",
+                    "type": "integer",
+                    "example": 1280
+                },
+                "until": {
+                    "description": "Fin del período, exclusive",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SearchReportKind": {
+            "type": "string",
+            "enum": [
+                "top_queries",
+                "zero_result_queries",
+                "clickless_queries"
+            ],
+            "x-enum-varnames": [
+                "ReportTopQueries",
+                "ReportZeroResultQueries",
+                "ReportClicklessQueries"
+            ]
+        },
+        "domain.SortKey": {
+            "type": "object",
+            "properties": {
+                "descending": {
+                    "description": "Orden descendente",
+                    "type": "boolean",
+                    "example": false
+                },
+                "field": {
+                    "description": "Campo por el que se ordena (price, rating, name, completeness)",
+                    "type": "string",
+                    "example": "price"
+                }
+            }
+        },
+        "domain.SpecComparisonRow": {
+            "description": "Specification row of a comparison matrix",
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "description": "Una celda por producto, en el mismo orden que ProductIDs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ComparisonCell"
+                    }
+                },
+                "differs": {
+                    "description": "Indica si los valores difieren entre los productos",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "description": "Nombre de la especificación",
+                    "type": "string",
+                    "example": "RAM"
+                },
+                "rule_conflict": {
+                    "description": "Motivo por el que no se anotaron ganadores cuando las categorías de los productos\ncomparados tienen reglas distintas para la especificación",
+                    "type": "string",
+                    "example": "categories 'Audífonos' and 'Parlantes' use different rules for 'Drivers'"
+                },
+                "tie": {
+                    "description": "Indica que más de un producto comparte el mejor valor",
+                    "type": "boolean",
+                    "example": false
+                },
+                "winners": {
+                    "description": "IDs de los productos con el mejor valor según las reglas de comparación",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PHONE001"
+                    ]
+                }
+            }
+        },
+        "domain.SpecCompleteness": {
+            "description": "Share of the category schema specifications a product provides",
+            "type": "object",
+            "properties": {
+                "missing": {
+                    "description": "Especificaciones del esquema que el producto no informa",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Peso",
+                        "Carga Inalámbrica"
+                    ]
+                },
+                "present": {
+                    "description": "Cantidad de especificaciones del esquema presentes en el producto",
+                    "type": "integer",
+                    "example": 7
+                },
+                "score": {
+                    "description": "Proporción de las especificaciones del esquema presentes en el producto (0 a 1)",
+                    "type": "number",
+                    "example": 0.7778
+                },
+                "total": {
+                    "description": "Cantidad de especificaciones declaradas en el esquema",
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "domain.Specification": {
+            "description": "Technical specification model",
+            "type": "object",
+            "required": [
+                "name",
+                "value"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Nombre de la especificación",
+                    "type": "string",
+                    "example": "Display Size"
+                },
+                "unit": {
+                    "description": "Unidad de medida si aplica",
+                    "type": "string",
+                    "example": "inches"
+                },
+                "value": {
+                    "description": "Valor de la especificación",
+                    "type": "string",
+                    "example": "6.8 inches"
+                }
+            }
+        },
+        "domain.Suggestion": {
+            "description": "Autocomplete suggestion for a partially typed query",
+            "type": "object",
+            "properties": {
+                "product_count": {
+                    "description": "Cantidad de productos de la marca o categoría",
+                    "type": "integer",
+                    "example": 3
+                },
+                "product_id": {
+                    "description": "ID del producto, solo para sugerencias de tipo product",
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "score": {
+                    "description": "Puntuación de popularidad utilizada para ordenar las sugerencias",
+                    "type": "number",
+                    "example": 4.8
+                },
+                "text": {
+                    "description": "Texto sugerido tal como aparece en el catálogo",
+                    "type": "string",
+                    "example": "Samsung Galaxy S24 Ultra"
+                },
+                "type": {
+                    "description": "Tipo de entidad sugerida (category, brand o product)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SuggestionType"
+                        }
+                    ],
+                    "example": "product"
+                }
+            }
+        },
+        "domain.SuggestionType": {
+            "type": "string",
+            "enum": [
+                "category",
+                "brand",
+                "product"
+            ],
+            "x-enum-varnames": [
+                "SuggestionCategory",
+                "SuggestionBrand",
+                "SuggestionProduct"
+            ]
+        },
+        "domain.TermMatch": {
+            "description": "Match of a query term in a product field with its BM25 score components",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Campo en el que coincidió (name, brand, category o description)",
+                    "type": "string",
+                    "example": "name"
+                },
+                "field_boost": {
+                    "description": "Peso del campo",
+                    "type": "number",
+                    "example": 3
+                },
+                "idf": {
+                    "description": "Frecuencia inversa de documentos del término",
+                    "type": "number",
+                    "example": 1.6094
+                },
+                "match_type": {
+                    "description": "Tipo de coincidencia: exact, prefix, fuzzy o synonym",
+                    "type": "string",
+                    "example": "fuzzy"
+                },
+                "query_term": {
+                    "description": "Término de la consulta, normalizado",
+                    "type": "string",
+                    "example": "galaxi"
+                },
+                "score": {
+                    "description": "Aporte de la coincidencia a la puntuación",
+                    "type": "number",
+                    "example": 1.2034
+                },
+                "term": {
+                    "description": "Término del producto que coincidió, normalizado",
+                    "type": "string",
+                    "example": "galaxy"
+                },
+                "term_frequency": {
+                    "description": "Cantidad de apariciones del término en el campo",
+                    "type": "integer",
+                    "example": 1
+                },
+                "weight": {
+                    "description": "Peso del tipo de coincidencia (1 para coincidencias exactas)",
+                    "type": "number",
+                    "example": 0.4
+                }
+            }
+        },
+        "domain.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "price"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be greater than 0"
+                }
+            }
+        },
+        "internal_delivery_rest_controllers.ProductComparisonResponse": {
+            "description": "Response model for product comparison",
+            "type": "object",
+            "properties": {
+                "matrix": {
+                    "description": "Matriz de especificaciones alineadas por nombre",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ComparisonMatrix"
+                        }
+                    ]
+                },
+                "products": {
+                    "description": "Lista de productos que se están comparando",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Product"
+                    }
+                },
+                "requested_ids": {
+                    "description": "Lista de IDs de productos solicitados",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_count": {
+                    "description": "Número total de productos en la comparación",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "internal_delivery_rest_controllers.ProductScoreResponse": {
+            "description": "Response model for weighted product scoring",
+            "type": "object",
+            "properties": {
+                "ranking": {
+                    "description": "Productos ordenados de mejor a peor puntuación",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProductScore"
+                    }
+                },
+                "requested_ids": {
+                    "description": "Lista de IDs de productos solicitados",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weights": {
+                    "description": "Pesos utilizados por criterio",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "internal_delivery_rest_controllers.ProductSearchResponse": {
+            "description": "Response model for product search",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Número total de productos encontrados (todas las páginas)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "products": {
+                    "description": "Lista de productos que coinciden con la búsqueda, con su puntuación de relevancia",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchHit"
+                    }
+                },
+                "query": {
+                    "description": "Consulta de búsqueda utilizada",
+                    "type": "string",
+                    "example": "Samsung Galaxy"
+                },
+                "search_id": {
+                    "description": "ID de la búsqueda registrada, que se envía al registrar un clic sobre sus resultados",
+                    "type": "string",
+                    "example": "srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"
+                },
+                "suggestions": {
+                    "description": "Consultas corregidas propuestas cuando hay pocos o ningún resultado",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "samsung galaxy"
+                    ]
+                }
+            }
+        },
+        "internal_delivery_rest_controllers.ProductSuggestResponse": {
+            "description": "Response model for search autocomplete",
+            "type": "object",
+            "properties": {
+                "query": {
+                    "description": "Prefijo consultado",
+                    "type": "string",
+                    "example": "gal"
+                },
+                "suggestions": {
+                    "description": "Sugerencias de más a menos popular",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Suggestion"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Product": {
-            "description": "Product model for comparison",
+        },
+        "meli-products-api_internal_application_commands_analytics.RecordSearchClickCommand": {
             "type": "object",
             "required": [
-                "description",
-                "image_url",
-                "name",
-                "price",
-                "rating"
+                "product_id",
+                "search_id"
             ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "PHONE001"
+                },
+                "search_id": {
+                    "type": "string",
+                    "example": "srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"
+                }
+            }
+        },
+        "meli-products-api_internal_application_commands_product.CreateProductCommand": {
+            "type": "object",
             "properties": {
                 "available": {
-                    "description": "Estado de disponibilidad",
                     "type": "boolean",
                     "example": true
                 },
                 "brand": {
-                    "description": "Marca del producto",
                     "type": "string",
                     "example": "Samsung"
                 },
                 "category": {
-                    "description": "Categoría del producto",
                     "type": "string",
                     "example": "Smartphones"
                 },
                 "description": {
-                    "description": "Descripción detallada del producto",
                     "type": "string",
                     "example": "Latest Samsung flagship smartphone with advanced camera technology"
                 },
                 "id": {
-                    "description": "Identificador único del producto",
                     "type": "string",
-                    "example": "PHONE001"
+                    "example": "PHONE009"
                 },
                 "image_url": {
-                    "description": "URL de la imagen del producto",
                     "type": "string",
                     "example": "https://images.example.com/samsung-s24.jpg"
                 },
                 "name": {
-                    "description": "Nombre del producto",
                     "type": "string",
                     "example": "Samsung Galaxy S24 Ultra"
                 },
                 "price": {
-                    "description": "Precio del producto en formato decimal",
                     "type": "number",
                     "example": 1299.99
                 },
                 "rating": {
-                    "description": "Calificación del producto (escala 1-5)",
                     "type": "number",
-                    "maximum": 5,
-                    "minimum": 0,
                     "example": 4.5
                 },
                 "specifications": {
-                    "description": "Especificaciones técnicas del producto",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Specification"
+                        "$ref": "#/definitions/meli-products-api_internal_application_commands_product.SpecificationInput"
                     }
                 }
             }
         },
-        "domain.Specification": {
-            "description": "Technical specification model",
+        "meli-products-api_internal_application_commands_product.PatchProductCommand": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Smartphones"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Latest Samsung flagship smartphone"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://images.example.com/samsung-s24.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Samsung Galaxy S24 Ultra"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1199.99
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.6
+                },
+                "specifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meli-products-api_internal_application_commands_product.SpecificationInput"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "meli-products-api_internal_application_commands_product.SpecificationInput": {
             "type": "object",
-            "required": [
-                "name",
-                "value"
-            ],
             "properties": {
                 "name": {
-                    "description": "Nombre de la especificación",
                     "type": "string",
-                    "example": "Display Size"
+                    "example": "RAM"
                 },
                 "unit": {
-                    "description": "Unidad de medida si aplica",
                     "type": "string",
-                    "example": "inches"
+                    "example": "GB"
                 },
                 "value": {
-                    "description": "Valor de la especificación",
                     "type": "string",
-                    "example": "6.8 inches"
+                    "example": "12"
+                }
+            }
+        },
+        "meli-products-api_internal_application_commands_product.UpdateProductCommand": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Samsung"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Smartphones"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Latest Samsung flagship smartphone with advanced camera technology"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://images.example.com/samsung-s24.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Samsung Galaxy S24 Ultra"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1299.99
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "specifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meli-products-api_internal_application_commands_product.SpecificationInput"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "meli-products-api_internal_application_queries_product.QueryProductsQuery": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "Cursor opaco de la página anterior; si se informa, Page se ignora",
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "id",
+                        "name",
+                        "price"
+                    ]
+                },
+                "filter": {
+                    "$ref": "#/definitions/domain.FilterNode"
+                },
+                "page": {
+                    "description": "Número de página (comienza en 1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "description": "Cantidad de elementos por página",
+                    "type": "integer",
+                    "example": 20
+                },
+                "sort": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SortKey"
+                    }
+                }
+            }
+        },
+        "meli-products-api_internal_application_queries_product.ScoreProductsQuery": {
+            "type": "object",
+            "required": [
+                "product_ids",
+                "weights"
+            ],
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PHONE001",
+                        "PHONE002"
+                    ]
+                },
+                "weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
                 }
             }
         },
@@ -462,15 +2571,45 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Please check the product ID and try again"
                 },
+                "fields": {
+                    "description": "Errores por campo, cuando la solicitud tiene varios campos inválidos",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meli-products-api_pkg_response.FieldError"
+                    }
+                },
                 "message": {
                     "type": "string",
                     "example": "Product with ID 'INVALID_ID' not found"
                 }
             }
         },
+        "meli-products-api_pkg_response.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "filter.and[1].op"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "is required"
+                }
+            }
+        },
         "meli-products-api_pkg_response.Meta": {
             "type": "object",
             "properties": {
+                "facets": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoicHJpY2U6YXNjIiwiaWQiOiJQSE9ORTAwMSJ9"
+                },
                 "page": {
                     "type": "integer",
                     "example": 1
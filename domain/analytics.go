@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parámetros de los reportes de búsquedas
+const (
+	// DefaultReportWindow es el período que abarca un reporte si no se indica otro
+	DefaultReportWindow = 24 * time.Hour
+
+	// MaxReportWindow es el período máximo que puede abarcar un reporte
+	MaxReportWindow = 90 * 24 * time.Hour
+
+	// DefaultReportLimit es la cantidad de consultas que devuelve un reporte por defecto
+	DefaultReportLimit = 20
+
+	// MaxReportLimit es la cantidad máxima de consultas que puede devolver un reporte
+	MaxReportLimit = 100
+)
+
+// SearchEvent registra una búsqueda de productos
+type SearchEvent struct {
+	// ID de la búsqueda generado por el servidor (ver NewSearchID); permite asociarle los clics
+	SearchID string `json:"search_id" example:"srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"`
+
+	// Consulta tal como la escribió el usuario
+	Query string `json:"query" example:"Samsung  Galaxy"`
+
+	// Consulta normalizada con la que se agrupan las búsquedas (ver NormalizeQuery)
+	NormalizedQuery string `json:"normalized_query" example:"samsung galaxy"`
+
+	// Cantidad total de resultados
+	ResultCount int `json:"result_count" example:"3"`
+
+	// Tiempo que tomó resolver la búsqueda, en milisegundos
+	LatencyMs float64 `json:"latency_ms" example:"0.42"`
+
+	// Momento de la búsqueda
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SearchClick registra que el usuario abrió un producto desde los resultados de una búsqueda
+type SearchClick struct {
+	// ID de la búsqueda de la que proviene el clic, devuelto como search_id por la búsqueda
+	SearchID string `json:"search_id" example:"srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"`
+
+	// ID del producto abierto
+	ProductID string `json:"product_id" example:"PHONE001"`
+
+	// Momento del clic
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewSearchID genera un ID aleatorio para una búsqueda registrada. A diferencia del header
+// X-Request-ID, que puede enviar el cliente, no es predecible ni se repite entre búsquedas.
+func NewSearchID() (string, error) {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("failed to generate search ID: %w", err)
+	}
+	return "srch-" + hex.EncodeToString(buf[:]), nil
+}
+
+// SearchLog define el registro de búsquedas y clics utilizado por los reportes de búsquedas
+type SearchLog interface {
+	// RecordSearch registra una búsqueda
+	RecordSearch(event SearchEvent) error
+
+	// RecordClick registra un clic sobre un resultado de búsqueda
+	RecordClick(click SearchClick) error
+
+	// Events devuelve las búsquedas y los clics registrados en el período [since, until)
+	Events(since, until time.Time) ([]SearchEvent, []SearchClick, error)
+}
+
+// NormalizeQuery normaliza una consulta para agrupar las búsquedas equivalentes: ignora
+// mayúsculas, acentos y espacios repetidos ("  Audífonos SONY" y "audifonos sony" son la misma)
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(FoldText(query)), " ")
+}
+
+// SearchReportKind identifica un reporte de búsquedas
+type SearchReportKind string
+
+// Reportes de búsquedas disponibles
+const (
+	// ReportTopQueries lista las consultas más buscadas
+	ReportTopQueries SearchReportKind = "top_queries"
+
+	// ReportZeroResultQueries lista las consultas que no devolvieron resultados
+	ReportZeroResultQueries SearchReportKind = "zero_result_queries"
+
+	// ReportClicklessQueries lista las consultas con resultados en las que nunca se abrió un producto
+	ReportClicklessQueries SearchReportKind = "clickless_queries"
+)
+
+// QueryStat resume las búsquedas de una consulta normalizada en el período de un reporte
+// @Description Search statistics of a normalized query
+type QueryStat struct {
+	// Consulta normalizada
+	Query string `json:"query" example:"samsung galaxy"`
+
+	// Cantidad de búsquedas
+	Searches int `json:"searches" example:"42"`
+
+	// Cantidad de búsquedas sin resultados
+	ZeroResultSearches int `json:"zero_result_searches" example:"0"`
+
+	// Cantidad de búsquedas en las que se abrió al menos un producto
+	ClickedSearches int `json:"clicked_searches" example:"17"`
+
+	// Proporción de búsquedas en las que se abrió al menos un producto
+	ClickThroughRate float64 `json:"click_through_rate" example:"0.4048"`
+
+	// Promedio de resultados por búsqueda
+	AvgResults float64 `json:"avg_results" example:"3"`
+
+	// Latencia promedio en milisegundos
+	AvgLatencyMs float64 `json:"avg_latency_ms" example:"0.38"`
+
+	// Momento de la última búsqueda
+	LastSearchedAt time.Time `json:"last_searched_at"`
+}
+
+// SearchReport es el resultado de un reporte de búsquedas
+// @Description Search analytics report over a time window
+type SearchReport struct {
+	// Reporte calculado
+	Report SearchReportKind `json:"report" example:"top_queries"`
+
+	// Inicio del período, inclusive
+	Since time.Time `json:"since"`
+
+	// Fin del período, exclusive
+	Until time.Time `json:"until"`
+
+	// Cantidad total de búsquedas en el período
+	TotalSearches int `json:"total_searches" example:"1280"`
+
+	// Consultas del reporte, de mayor a menor cantidad de búsquedas (sin resultados, en el
+	// reporte de consultas sin resultados)
+	Queries []QueryStat `json:"queries"`
+}
+
+// ParseReportWindow interpreta la duración de un reporte. Acepta las unidades de
+// time.ParseDuration y además días ("7d").
+func ParseReportWindow(value string) (time.Duration, error) {
+	invalid := &ValidationError{Field: "window", Message: fmt.Sprintf("invalid window '%s', use a duration such as 1h, 24h or 7d", value)}
+
+	var window time.Duration
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, invalid
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if window, err = time.ParseDuration(value); err != nil {
+			return 0, invalid
+		}
+	}
+
+	if window <= 0 || window > MaxReportWindow {
+		return 0, &ValidationError{Field: "window", Message: fmt.Sprintf("window must be between 1s and %dd", int(MaxReportWindow.Hours()/24))}
+	}
+
+	return window, nil
+}
+
+// BuildSearchReport agrupa las búsquedas por consulta normalizada y devuelve hasta limit
+// consultas del reporte indicado, de mayor a menor cantidad de búsquedas. Una búsqueda se
+// considera con clic si algún clic tiene su mismo SearchID. El reporte de consultas sin
+// clics omite las consultas que nunca devolvieron resultados, que ya informa el reporte de
+// consultas sin resultados.
+func BuildSearchReport(kind SearchReportKind, since, until time.Time, events []SearchEvent, clicks []SearchClick, limit int) (*SearchReport, error) {
+	switch kind {
+	case ReportTopQueries, ReportZeroResultQueries, ReportClicklessQueries:
+	default:
+		return nil, &ValidationError{Field: "report", Message: fmt.Sprintf("unknown report '%s'", kind)}
+	}
+
+	clicked := make(map[string]bool, len(clicks))
+	for _, click := range clicks {
+		if click.SearchID != "" {
+			clicked[click.SearchID] = true
+		}
+	}
+
+	type totals struct {
+		QueryStat
+		results int
+		latency float64
+	}
+
+	byQuery := make(map[string]*totals)
+	for _, event := range events {
+		query := event.NormalizedQuery
+		if query == "" {
+			query = NormalizeQuery(event.Query)
+		}
+
+		t, ok := byQuery[query]
+		if !ok {
+			t = &totals{QueryStat: QueryStat{Query: query}}
+			byQuery[query] = t
+		}
+
+		t.Searches++
+		t.results += event.ResultCount
+		t.latency += event.LatencyMs
+		if event.ResultCount == 0 {
+			t.ZeroResultSearches++
+		}
+		if event.SearchID != "" && clicked[event.SearchID] {
+			t.ClickedSearches++
+		}
+		if event.Timestamp.After(t.LastSearchedAt) {
+			t.LastSearchedAt = event.Timestamp
+		}
+	}
+
+	stats := make([]QueryStat, 0, len(byQuery))
+	for _, t := range byQuery {
+		switch kind {
+		case ReportZeroResultQueries:
+			if t.ZeroResultSearches == 0 {
+				continue
+			}
+		case ReportClicklessQueries:
+			if t.ClickedSearches > 0 || t.ZeroResultSearches == t.Searches {
+				continue
+			}
+		}
+
+		stat := t.QueryStat
+		stat.ClickThroughRate = roundRatio(float64(t.ClickedSearches) / float64(t.Searches))
+		stat.AvgResults = roundRatio(float64(t.results) / float64(t.Searches))
+		stat.AvgLatencyMs = roundRatio(t.latency / float64(t.Searches))
+		stats = append(stats, stat)
+	}
+
+	// El reporte de consultas sin resultados se ordena por las búsquedas que fallaron
+	count := func(stat QueryStat) int {
+		if kind == ReportZeroResultQueries {
+			return stat.ZeroResultSearches
+		}
+		return stat.Searches
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if count(stats[i]) != count(stats[j]) {
+			return count(stats[i]) > count(stats[j])
+		}
+		return stats[i].Query < stats[j].Query
+	})
+
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+
+	return &SearchReport{
+		Report:        kind,
+		Since:         since,
+		Until:         until,
+		TotalSearches: len(events),
+		Queries:       stats,
+	}, nil
+}
+
+// roundRatio redondea una proporción o promedio a 4 decimales
+func roundRatio(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CatalogValidationMode indica qué hacer con los productos inválidos al cargar el catálogo
+type CatalogValidationMode string
+
+const (
+	// ValidationDisabled carga todos los productos sin validarlos
+	ValidationDisabled CatalogValidationMode = "disabled"
+
+	// ValidationStrict rechaza el catálogo completo si algún producto es inválido
+	ValidationStrict CatalogValidationMode = "strict"
+
+	// ValidationLenient descarta los productos inválidos y carga el resto
+	ValidationLenient CatalogValidationMode = "lenient"
+)
+
+// ParseCatalogValidationMode interpreta el modo de validación del catálogo ("strict" o "lenient")
+func ParseCatalogValidationMode(value string) (CatalogValidationMode, error) {
+	switch mode := CatalogValidationMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case ValidationStrict, ValidationLenient:
+		return mode, nil
+	}
+
+	return "", &ValidationError{Field: "mode", Message: fmt.Sprintf("invalid catalog validation mode '%s', use strict or lenient", value)}
+}
+
+// RejectedProduct describe un producto descartado al cargar el catálogo
+// @Description Product skipped at catalog load because it failed validation
+type RejectedProduct struct {
+	// Posición del producto en el archivo de datos
+	Index int `json:"index" example:"3"`
+
+	// ID del producto, si lo tiene
+	ProductID string `json:"product_id" example:"PHONE004"`
+
+	// Errores de validación, uno por campo inválido
+	Errors []*ValidationError `json:"errors"`
+}
+
+// CatalogValidationReport resume la validación de los productos en la última carga del catálogo
+// @Description Result of validating the products at the last catalog load
+type CatalogValidationReport struct {
+	// Modo de validación aplicado
+	Mode CatalogValidationMode `json:"mode" example:"lenient"`
+
+	// Momento de la validación
+	ValidatedAt time.Time `json:"validated_at"`
+
+	// Cantidad de productos del archivo de datos
+	TotalProducts int `json:"total_products" example:"120"`
+
+	// Cantidad de productos cargados en el catálogo
+	LoadedProducts int `json:"loaded_products" example:"118"`
+
+	// Productos descartados por ser inválidos, en el orden del archivo
+	Rejected []RejectedProduct `json:"rejected"`
+}
@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ComparisonMatrix representa las especificaciones de varios productos alineadas lado a lado
+// @Description Aligned specification matrix for product comparison
+type ComparisonMatrix struct {
+	// IDs de los productos comparados, en el orden de las celdas de cada fila
+	ProductIDs []string `json:"product_ids" example:"PHONE001,PHONE002"`
+
+	// Filas de los campos principales del producto (price, rating)
+	Fields []SpecComparisonRow `json:"fields"`
+
+	// Filas de la matriz, una por nombre de especificación
+	Rows []SpecComparisonRow `json:"rows"`
+}
+
+// SpecComparisonRow representa una especificación comparada entre todos los productos
+// @Description Specification row of a comparison matrix
+type SpecComparisonRow struct {
+	// Nombre de la especificación
+	Name string `json:"name" example:"RAM"`
+
+	// Una celda por producto, en el mismo orden que ProductIDs
+	Cells []ComparisonCell `json:"cells"`
+
+	// Indica si los valores difieren entre los productos
+	Differs bool `json:"differs" example:"true"`
+
+	// IDs de los productos con el mejor valor según las reglas de comparación
+	Winners []string `json:"winners,omitempty" example:"PHONE001"`
+
+	// Indica que más de un producto comparte el mejor valor
+	Tie bool `json:"tie" example:"false"`
+
+	// Motivo por el que no se anotaron ganadores cuando las categorías de los productos
+	// comparados tienen reglas distintas para la especificación
+	RuleConflict string `json:"rule_conflict,omitempty" example:"categories 'Audífonos' and 'Parlantes' use different rules for 'Drivers'"`
+
+	// Regla de comparación aplicada a la fila
+	rule *ComparisonRule
+}
+
+// ComparisonCell representa el valor de una especificación para un producto
+// @Description Single cell of a comparison row
+type ComparisonCell struct {
+	// ID del producto al que pertenece la celda
+	ProductID string `json:"product_id" example:"PHONE001"`
+
+	// Valor de la especificación
+	Value string `json:"value,omitempty" example:"12"`
+
+	// Unidad de medida si aplica
+	Unit string `json:"unit,omitempty" example:"GB"`
+
+	// Indica que el producto no declara esta especificación
+	Missing bool `json:"missing" example:"false"`
+
+	// Valor tipado utilizado para comparar las celdas
+	typed SpecValue
+}
+
+// NewComparisonMatrix construye la matriz de comparación a partir de la unión de las
+// especificaciones de los productos. Las filas respetan el orden de primera aparición.
+// Si se proveen reglas, cada fila se anota con los productos ganadores; las reglas de una
+// especificación se resuelven por la categoría de cada producto que la declara.
+func NewComparisonMatrix(products []*Product, rules *ComparisonRuleSet) *ComparisonMatrix {
+	matrix := &ComparisonMatrix{
+		ProductIDs: make([]string, 0, len(products)),
+		Fields:     newFieldRows(products),
+		Rows:       []SpecComparisonRow{},
+	}
+
+	rowIndex := make(map[string]int)
+
+	for i, product := range products {
+		matrix.ProductIDs = append(matrix.ProductIDs, product.ID)
+
+		for _, spec := range product.Specifications {
+			key := specKey(spec.Name)
+
+			idx, exists := rowIndex[key]
+			if !exists {
+				idx = len(matrix.Rows)
+				rowIndex[key] = idx
+				matrix.Rows = append(matrix.Rows, newMissingRow(spec.Name, products))
+			}
+
+			// Si el producto repite una especificación se conserva la primera
+			cell := &matrix.Rows[idx].Cells[i]
+			if cell.Missing {
+				cell.Value = spec.Value
+				cell.Unit = spec.Unit
+				cell.Missing = false
+				cell.typed = spec.Typed
+				if cell.typed.Kind == "" {
+					cell.typed = ParseSpecValue(spec.Value, spec.Unit)
+				}
+			}
+		}
+	}
+
+	for i := range matrix.Fields {
+		row := &matrix.Fields[i]
+		row.Differs = cellsDiffer(row.Cells)
+		row.rule = rules.FieldRule(row.Name)
+		row.annotateWinners(row.rule)
+	}
+
+	for i := range matrix.Rows {
+		row := &matrix.Rows[i]
+		row.Differs = cellsDiffer(row.Cells)
+		row.resolveSpecRule(products, rules)
+		row.annotateWinners(row.rule)
+	}
+
+	return matrix
+}
+
+// newFieldRows crea las filas de los campos principales del producto
+func newFieldRows(products []*Product) []SpecComparisonRow {
+	price := SpecComparisonRow{Name: "price", Cells: make([]ComparisonCell, len(products))}
+	rating := SpecComparisonRow{Name: "rating", Cells: make([]ComparisonCell, len(products))}
+
+	for i, product := range products {
+		price.Cells[i] = ComparisonCell{
+			ProductID: product.ID,
+			Value:     strconv.FormatFloat(product.Price, 'f', -1, 64),
+			typed:     SpecValue{Kind: SpecKindNumber, Number: product.Price},
+		}
+		rating.Cells[i] = ComparisonCell{
+			ProductID: product.ID,
+			Value:     strconv.FormatFloat(float64(product.Rating), 'f', -1, 32),
+			typed:     SpecValue{Kind: SpecKindNumber, Number: float64(product.Rating)},
+		}
+	}
+
+	return []SpecComparisonRow{price, rating}
+}
+
+// annotateWinners marca los productos con el mejor valor de la fila según la regla.
+// Los valores numéricos se comparan en su unidad canónica. No se anotan ganadores si no
+// hay regla, si menos de dos celdas son comparables o si las unidades son incompatibles.
+func (row *SpecComparisonRow) annotateWinners(rule *ComparisonRule) {
+	if rule == nil {
+		return
+	}
+
+	var best float64
+	var winners []string
+	comparable := 0
+	unit := ""
+
+	for i, cell := range row.Cells {
+		if cell.Missing {
+			continue
+		}
+
+		rank, ok := rule.rank(cell.typed, cell.Value)
+		if !ok {
+			continue
+		}
+
+		if rule.Type == NumericRule {
+			if comparable > 0 && !strings.EqualFold(cell.typed.Unit, unit) {
+				return
+			}
+			unit = cell.typed.Unit
+		}
+
+		comparable++
+		switch {
+		case len(winners) == 0 || rule.better(rank, best):
+			best = rank
+			winners = []string{row.Cells[i].ProductID}
+		case rank == best:
+			winners = append(winners, row.Cells[i].ProductID)
+		}
+	}
+
+	if comparable < 2 {
+		return
+	}
+
+	row.Winners = winners
+	row.Tie = len(winners) > 1
+}
+
+// resolveSpecRule busca la regla de la fila para la categoría de cada producto que declara
+// la especificación. Si todas las categorías resuelven la misma regla se usa esa; si no, la
+// fila queda sin regla y RuleConflict explica por qué no se anotaron ganadores.
+func (row *SpecComparisonRow) resolveSpecRule(products []*Product, rules *ComparisonRuleSet) {
+	first := -1
+	for i, cell := range row.Cells {
+		if cell.Missing {
+			continue
+		}
+
+		rule := rules.SpecRule(products[i].Category, row.Name)
+		if first < 0 {
+			first = i
+			row.rule = rule
+			continue
+		}
+
+		if rule != row.rule {
+			row.rule = nil
+			row.RuleConflict = fmt.Sprintf("categories '%s' and '%s' use different rules for '%s'",
+				products[first].Category, products[i].Category, row.Name)
+			return
+		}
+	}
+}
+
+// newMissingRow crea una fila con todas sus celdas marcadas como faltantes
+func newMissingRow(name string, products []*Product) SpecComparisonRow {
+	cells := make([]ComparisonCell, len(products))
+	for i, product := range products {
+		cells[i] = ComparisonCell{ProductID: product.ID, Missing: true}
+	}
+
+	return SpecComparisonRow{Name: name, Cells: cells}
+}
+
+// cellsDiffer indica si alguna celda de la fila difiere de la primera. Los valores se
+// comparan tipados, por lo que "1 TB" y "1024 GB" se consideran iguales.
+func cellsDiffer(cells []ComparisonCell) bool {
+	for i := 1; i < len(cells); i++ {
+		if cells[i].Missing != cells[0].Missing {
+			return true
+		}
+		if !cells[i].Missing && !cells[i].typed.Equal(cells[0].typed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// specKey normaliza el nombre de una especificación para agrupar filas
+func specKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
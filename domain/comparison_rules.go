@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleDirection indica qué valor gana en una comparación
+type RuleDirection string
+
+const (
+	// HigherIsBetter indica que gana el valor más alto
+	HigherIsBetter RuleDirection = "higher"
+
+	// LowerIsBetter indica que gana el valor más bajo
+	LowerIsBetter RuleDirection = "lower"
+)
+
+// RuleType indica cómo se interpretan los valores al compararlos
+type RuleType string
+
+const (
+	// NumericRule compara los valores como números
+	NumericRule RuleType = "numeric"
+
+	// BooleanRule compara los valores como sí/no (verdadero es el valor más alto)
+	BooleanRule RuleType = "boolean"
+
+	// OrdinalRule compara los valores según su posición en una lista ordenada de menor a mayor
+	OrdinalRule RuleType = "ordinal"
+)
+
+// ComparisonRule declara cómo elegir el mejor valor de un campo o especificación
+type ComparisonRule struct {
+	// Nombre del campo ("price", "rating") o de la especificación ("RAM")
+	Name string `json:"name"`
+
+	// Categoría a la que aplica la regla; vacío aplica a todas
+	Category string `json:"category,omitempty"`
+
+	// Dirección de la comparación
+	Direction RuleDirection `json:"direction"`
+
+	// Tipo de comparación
+	Type RuleType `json:"type"`
+
+	// Valores posibles de menor a mayor, solo para reglas ordinales
+	Order []string `json:"order,omitempty"`
+}
+
+// ComparisonRuleSet agrupa las reglas de comparación de campos y especificaciones
+type ComparisonRuleSet struct {
+	// Reglas para los campos del producto (price, rating)
+	Fields []ComparisonRule `json:"fields"`
+
+	// Reglas para las especificaciones técnicas
+	Specifications []ComparisonRule `json:"specifications"`
+}
+
+// Validate verifica que todas las reglas del conjunto estén bien formadas
+func (rs *ComparisonRuleSet) Validate() error {
+	for _, rule := range rs.Fields {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	for _, rule := range rs.Specifications {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FieldRule devuelve la regla para un campo del producto, o nil si no existe
+func (rs *ComparisonRuleSet) FieldRule(name string) *ComparisonRule {
+	if rs == nil {
+		return nil
+	}
+
+	return findRule(rs.Fields, "", name)
+}
+
+// SpecRule devuelve la regla para una especificación, priorizando la regla específica
+// de la categoría sobre la general. Devuelve nil si no existe.
+func (rs *ComparisonRuleSet) SpecRule(category, name string) *ComparisonRule {
+	if rs == nil {
+		return nil
+	}
+
+	return findRule(rs.Specifications, category, name)
+}
+
+// findRule busca una regla por nombre, priorizando las que coinciden con la categoría
+func findRule(rules []ComparisonRule, category, name string) *ComparisonRule {
+	var generic *ComparisonRule
+
+	for i := range rules {
+		rule := &rules[i]
+		if specKey(rule.Name) != specKey(name) {
+			continue
+		}
+
+		if rule.Category == "" {
+			if generic == nil {
+				generic = rule
+			}
+			continue
+		}
+
+		if category != "" && strings.EqualFold(rule.Category, category) {
+			return rule
+		}
+	}
+
+	return generic
+}
+
+// validate verifica que la regla esté bien formada
+func (r ComparisonRule) validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return &ValidationError{Field: "name", Message: "comparison rule name is required"}
+	}
+
+	if r.Direction != HigherIsBetter && r.Direction != LowerIsBetter {
+		return &ValidationError{
+			Field:   "direction",
+			Message: fmt.Sprintf("invalid direction '%s' for rule '%s'", r.Direction, r.Name),
+		}
+	}
+
+	switch r.Type {
+	case NumericRule, BooleanRule:
+	case OrdinalRule:
+		if len(r.Order) == 0 {
+			return &ValidationError{
+				Field:   "order",
+				Message: fmt.Sprintf("ordinal rule '%s' requires at least one value", r.Name),
+			}
+		}
+	default:
+		return &ValidationError{
+			Field:   "type",
+			Message: fmt.Sprintf("invalid type '%s' for rule '%s'", r.Type, r.Name),
+		}
+	}
+
+	return nil
+}
+
+// rank convierte un valor tipado en un número comparable según el tipo de la regla.
+// Devuelve false si el valor no puede interpretarse.
+func (r ComparisonRule) rank(value SpecValue, raw string) (float64, bool) {
+	switch r.Type {
+	case NumericRule:
+		if value.IsNumber() {
+			return value.Number, true
+		}
+	case BooleanRule:
+		if value.Kind == SpecKindBoolean && value.Boolean != nil {
+			if *value.Boolean {
+				return 1, true
+			}
+			return 0, true
+		}
+	case OrdinalRule:
+		raw = strings.TrimSpace(raw)
+		for i, candidate := range r.Order {
+			if strings.EqualFold(candidate, raw) {
+				return float64(i), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// parseBool interpreta valores afirmativos y negativos en español e inglés
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "sí", "si", "yes", "true", "1":
+		return true, true
+	case "no", "false", "0":
+		return false, true
+	}
+
+	return false, false
+}
+
+// better indica si el rango a es mejor que b según la dirección de la regla
+func (r ComparisonRule) better(a, b float64) bool {
+	if r.Direction == LowerIsBetter {
+		return a < b
+	}
+
+	return a > b
+}
@@ -0,0 +1,298 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Condition representa una condición booleana sobre un producto. Las condiciones se pueden
+// combinar con AndCondition, OrCondition y NotCondition para formar un árbol de filtros.
+type Condition interface {
+	// Matches indica si el producto cumple la condición
+	Matches(product *Product) bool
+
+	// String devuelve una representación legible de la condición
+	String() string
+}
+
+// Campos del producto que admiten condiciones
+const (
+	FieldID          = "id"
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldBrand       = "brand"
+	FieldCategory    = "category"
+	FieldPrice       = "price"
+	FieldRating      = "rating"
+	FieldAvailable   = "available"
+)
+
+// fieldOperators define los operadores admitidos por cada campo del producto
+var fieldOperators = map[string][]FilterOperator{
+	FieldID:          {OpEqual, OpNotEqual},
+	FieldName:        {OpEqual, OpNotEqual, OpContains},
+	FieldDescription: {OpEqual, OpNotEqual, OpContains},
+	FieldBrand:       {OpEqual, OpNotEqual, OpContains},
+	FieldCategory:    {OpEqual, OpNotEqual, OpContains},
+	FieldPrice:       {OpEqual, OpNotEqual, OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual},
+	FieldRating:      {OpEqual, OpNotEqual, OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual},
+	FieldAvailable:   {OpEqual, OpNotEqual},
+}
+
+// IsProductField indica si el nombre corresponde a un campo del producto que admite condiciones
+func IsProductField(name string) bool {
+	_, ok := fieldOperators[strings.ToLower(name)]
+	return ok
+}
+
+// AndCondition se cumple si se cumplen todas las condiciones
+type AndCondition struct {
+	Conditions []Condition
+}
+
+// Matches indica si el producto cumple todas las condiciones
+func (c AndCondition) Matches(product *Product) bool {
+	for _, condition := range c.Conditions {
+		if !condition.Matches(product) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String devuelve la representación de la conjunción
+func (c AndCondition) String() string {
+	return joinConditions(c.Conditions, " AND ")
+}
+
+// OrCondition se cumple si se cumple al menos una de las condiciones
+type OrCondition struct {
+	Conditions []Condition
+}
+
+// Matches indica si el producto cumple alguna de las condiciones
+func (c OrCondition) Matches(product *Product) bool {
+	for _, condition := range c.Conditions {
+		if condition.Matches(product) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String devuelve la representación de la disyunción
+func (c OrCondition) String() string {
+	return joinConditions(c.Conditions, " OR ")
+}
+
+// NotCondition se cumple si no se cumple la condición negada
+type NotCondition struct {
+	Condition Condition
+}
+
+// Matches indica si el producto no cumple la condición negada
+func (c NotCondition) Matches(product *Product) bool {
+	return !c.Condition.Matches(product)
+}
+
+// String devuelve la representación de la negación
+func (c NotCondition) String() string {
+	return "NOT " + c.Condition.String()
+}
+
+// joinConditions representa una lista de condiciones entre paréntesis
+func joinConditions(conditions []Condition, separator string) string {
+	parts := make([]string, len(conditions))
+	for i, condition := range conditions {
+		parts[i] = condition.String()
+	}
+
+	return "(" + strings.Join(parts, separator) + ")"
+}
+
+// FieldCondition compara un campo del producto con un valor
+type FieldCondition struct {
+	// Campo del producto (id, name, description, brand, category, price, rating, available)
+	Field string
+
+	// Operador de comparación
+	Operator FilterOperator
+
+	// Valor esperado tal como fue escrito
+	Value string
+
+	number  float64
+	boolean bool
+}
+
+// NewFieldCondition crea una condición sobre un campo del producto, validando que el campo
+// admita el operador y que el valor tenga el tipo del campo
+func NewFieldCondition(field string, operator FilterOperator, value string) (FieldCondition, error) {
+	condition := FieldCondition{Field: strings.ToLower(strings.TrimSpace(field)), Operator: operator, Value: strings.TrimSpace(value)}
+
+	operators, ok := fieldOperators[condition.Field]
+	if !ok {
+		return FieldCondition{}, &ValidationError{Field: field, Message: fmt.Sprintf("unknown field '%s'", field)}
+	}
+
+	supported := false
+	for _, candidate := range operators {
+		supported = supported || candidate == operator
+	}
+	if !supported {
+		return FieldCondition{}, &ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("operator '%s' is not supported for field '%s'", operator, condition.Field),
+		}
+	}
+
+	switch condition.Field {
+	case FieldPrice, FieldRating:
+		number, err := strconv.ParseFloat(condition.Value, 64)
+		if err != nil {
+			return FieldCondition{}, &ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("invalid number '%s' for field '%s'", value, condition.Field),
+			}
+		}
+		condition.number = number
+	case FieldAvailable:
+		boolean, ok := parseBool(condition.Value)
+		if !ok {
+			return FieldCondition{}, &ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("invalid boolean '%s' for field '%s'", value, condition.Field),
+			}
+		}
+		condition.boolean = boolean
+	}
+
+	return condition, nil
+}
+
+// Matches indica si el producto cumple la condición
+func (c FieldCondition) Matches(product *Product) bool {
+	switch c.Field {
+	case FieldID:
+		return c.matchesText(product.ID)
+	case FieldName:
+		return c.matchesText(product.Name)
+	case FieldDescription:
+		return c.matchesText(product.Description)
+	case FieldBrand:
+		return c.matchesText(product.Brand)
+	case FieldCategory:
+		return c.matchesCategory(product)
+	case FieldPrice:
+		return compareNumbers(product.Price, c.number, c.Operator)
+	case FieldRating:
+		// Las calificaciones se guardan en float32; se compara con la misma precisión
+		return compareNumbers(float64(product.Rating), float64(float32(c.number)), c.Operator)
+	case FieldAvailable:
+		if c.Operator == OpNotEqual {
+			return product.Available != c.boolean
+		}
+		return product.Available == c.boolean
+	}
+
+	return false
+}
+
+// matchesText compara un campo de texto sin distinguir mayúsculas ni acentos
+func (c FieldCondition) matchesText(actual string) bool {
+	switch c.Operator {
+	case OpEqual:
+		return FoldText(strings.TrimSpace(actual)) == FoldText(c.Value)
+	case OpNotEqual:
+		return FoldText(strings.TrimSpace(actual)) != FoldText(c.Value)
+	case OpContains:
+		return strings.Contains(FoldText(actual), FoldText(c.Value))
+	}
+
+	return false
+}
+
+// matchesCategory compara la categoría del producto y las de sus migas de pan, de modo que
+// una categoría abarca todas sus subcategorías
+func (c FieldCondition) matchesCategory(product *Product) bool {
+	switch c.Operator {
+	case OpEqual:
+		return product.InCategory(c.Value)
+	case OpNotEqual:
+		return !product.InCategory(c.Value)
+	case OpContains:
+		if c.matchesText(product.Category) {
+			return true
+		}
+		for _, ref := range product.Breadcrumbs {
+			if c.matchesText(ref.Name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// String devuelve la representación de la condición
+func (c FieldCondition) String() string {
+	return c.Field + string(c.Operator) + c.Value
+}
+
+// TextCondition se cumple si el texto aparece como palabra o frase completa en el nombre,
+// la marca, la categoría o la descripción del producto, sin distinguir mayúsculas ni acentos
+type TextCondition struct {
+	Text string
+}
+
+// Matches indica si el texto aparece en el producto
+func (c TextCondition) Matches(product *Product) bool {
+	needle := FoldText(strings.TrimSpace(c.Text))
+	if needle == "" {
+		return true
+	}
+
+	for _, field := range []string{product.Name, product.Brand, product.Category, product.Description} {
+		if containsWords(FoldText(field), needle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String devuelve la representación de la condición
+func (c TextCondition) String() string {
+	return strconv.Quote(c.Text)
+}
+
+// containsWords indica si needle aparece en haystack sin cortar palabras
+func containsWords(haystack, needle string) bool {
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+	for offset := 0; offset <= len(haystack)-len(needle); {
+		idx := strings.Index(haystack[offset:], needle)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(needle)
+
+		previous, _ := utf8.DecodeLastRuneInString(haystack[:start])
+		next, _ := utf8.DecodeRuneInString(haystack[end:])
+		before := start == 0 || !isWord(previous)
+		after := end == len(haystack) || !isWord(next)
+		if before && after {
+			return true
+		}
+
+		offset = start + 1
+	}
+
+	return false
+}
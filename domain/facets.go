@@ -0,0 +1,329 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Facetas disponibles además de las de especificaciones ("spec.<nombre>")
+const (
+	FacetBrand    = "brand"
+	FacetCategory = "category"
+	FacetPrice    = "price"
+	FacetRating   = "rating"
+
+	// FacetSpecPrefix antecede al nombre de una especificación en la lista de facetas
+	FacetSpecPrefix = "spec."
+)
+
+// DefaultPriceBuckets son los límites de los rangos de precio cuando no se configuran otros
+var DefaultPriceBuckets = []float64{250, 500, 1000, 2000}
+
+// RatingBands son las calificaciones mínimas de las bandas de calificación ("4+")
+var RatingBands = []float64{4.5, 4, 3}
+
+// FacetRequest indica qué facetas calcular sobre el conjunto de resultados
+type FacetRequest struct {
+	// Facetas de campos del producto (brand, category, price, rating)
+	Fields []string `json:"fields,omitempty" example:"brand,price"`
+
+	// Nombres de especificaciones para las que se cuentan los valores
+	Specs []string `json:"specs,omitempty" example:"RAM"`
+
+	// Límites de los rangos de precio, en orden ascendente
+	PriceBuckets []float64 `json:"price_buckets,omitempty" example:"500,1000"`
+}
+
+// Empty indica que no se solicitó ninguna faceta
+func (r *FacetRequest) Empty() bool {
+	return r == nil || (len(r.Fields) == 0 && len(r.Specs) == 0)
+}
+
+// ParseFacetRequest interpreta la lista de facetas separada por comas ("brand,price,spec.RAM")
+// y los límites opcionales de los rangos de precio ("500,1000,2000")
+func ParseFacetRequest(facets, priceBuckets string) (*FacetRequest, error) {
+	request := &FacetRequest{}
+	seen := make(map[string]bool)
+
+	for _, raw := range strings.Split(facets, ",") {
+		name := strings.TrimSpace(raw)
+		if name == "" {
+			continue
+		}
+
+		if len(name) > len(FacetSpecPrefix) && strings.EqualFold(name[:len(FacetSpecPrefix)], FacetSpecPrefix) {
+			spec := strings.TrimSpace(name[len(FacetSpecPrefix):])
+			if spec != "" && !seen[FacetSpecPrefix+specKey(spec)] {
+				seen[FacetSpecPrefix+specKey(spec)] = true
+				request.Specs = append(request.Specs, spec)
+			}
+			continue
+		}
+
+		field := strings.ToLower(name)
+		switch field {
+		case FacetBrand, FacetCategory, FacetPrice, FacetRating:
+		default:
+			return nil, &ValidationError{
+				Field:   "facets",
+				Message: fmt.Sprintf("invalid facet '%s', use brand, category, price, rating or spec.<name>", name),
+			}
+		}
+		if !seen[field] {
+			seen[field] = true
+			request.Fields = append(request.Fields, field)
+		}
+	}
+
+	for _, raw := range strings.Split(priceBuckets, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		limit, err := strconv.ParseFloat(raw, 64)
+		if err != nil || limit <= 0 {
+			return nil, &ValidationError{Field: "price_buckets", Message: fmt.Sprintf("invalid price bucket limit '%s'", raw)}
+		}
+		if n := len(request.PriceBuckets); n > 0 && limit <= request.PriceBuckets[n-1] {
+			return nil, &ValidationError{Field: "price_buckets", Message: "price bucket limits must be in ascending order"}
+		}
+		request.PriceBuckets = append(request.PriceBuckets, limit)
+	}
+
+	return request, nil
+}
+
+// Facet representa los conteos de una faceta sobre el conjunto de resultados
+// @Description Counts of a facet over the filtered result set
+type Facet struct {
+	// Nombre de la faceta ("brand", "price", "spec.RAM")
+	Name string `json:"name" example:"brand"`
+
+	// Valores de la faceta con su cantidad de productos
+	Values []FacetValue `json:"values"`
+}
+
+// FacetValue representa un valor de una faceta y la cantidad de productos que lo tienen
+// @Description Value of a facet with its product count
+type FacetValue struct {
+	// Valor o etiqueta del rango ("Samsung", "500-1000", "4+", "12 GB")
+	Value string `json:"value" example:"Samsung"`
+
+	// Cantidad de productos con el valor
+	Count int `json:"count" example:"3"`
+
+	// Límite inferior del rango (precio o calificación), inclusivo
+	From *float64 `json:"from,omitempty" example:"500"`
+
+	// Límite superior del rango de precio, exclusivo
+	To *float64 `json:"to,omitempty" example:"1000"`
+}
+
+// ComputeFacets calcula las facetas solicitadas sobre los productos. Los valores sin
+// productos se omiten.
+func ComputeFacets(products []*Product, request *FacetRequest) []Facet {
+	if request.Empty() {
+		return nil
+	}
+
+	facets := make([]Facet, 0, len(request.Fields)+len(request.Specs))
+
+	for _, field := range request.Fields {
+		switch field {
+		case FacetBrand:
+			facets = append(facets, termFacet(FacetBrand, products, func(p *Product) (string, bool) {
+				return p.Brand, p.Brand != ""
+			}))
+		case FacetCategory:
+			facets = append(facets, termFacet(FacetCategory, products, func(p *Product) (string, bool) {
+				return p.Category, p.Category != ""
+			}))
+		case FacetPrice:
+			buckets := request.PriceBuckets
+			if len(buckets) == 0 {
+				buckets = DefaultPriceBuckets
+			}
+			facets = append(facets, priceFacet(products, buckets))
+		case FacetRating:
+			facets = append(facets, ratingFacet(products))
+		}
+	}
+
+	for _, spec := range request.Specs {
+		facets = append(facets, specFacet(spec, products))
+	}
+
+	return facets
+}
+
+// termFacet cuenta los productos por valor textual, sin distinguir mayúsculas. Los valores
+// se ordenan de mayor a menor cantidad y luego alfabéticamente.
+func termFacet(name string, products []*Product, value func(*Product) (string, bool)) Facet {
+	counts := make(map[string]*FacetValue)
+	var order []string
+
+	for _, product := range products {
+		v, ok := value(product)
+		if !ok {
+			continue
+		}
+
+		key := strings.ToLower(v)
+		if _, exists := counts[key]; !exists {
+			counts[key] = &FacetValue{Value: v}
+			order = append(order, key)
+		}
+		counts[key].Count++
+	}
+
+	values := make([]FacetValue, len(order))
+	for i, key := range order {
+		values[i] = *counts[key]
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return strings.ToLower(values[i].Value) < strings.ToLower(values[j].Value)
+	})
+
+	return Facet{Name: name, Values: values}
+}
+
+// priceFacet cuenta los productos por rango de precio: [0, l1), [l1, l2), ..., [ln, ∞)
+func priceFacet(products []*Product, limits []float64) Facet {
+	counts := make([]int, len(limits)+1)
+
+	for _, product := range products {
+		// Índice del primer límite mayor al precio: el producto cae en el rango que termina ahí
+		bucket := sort.Search(len(limits), func(i int) bool { return limits[i] > product.Price })
+		counts[bucket]++
+	}
+
+	facet := Facet{Name: FacetPrice, Values: []FacetValue{}}
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		from := 0.0
+		if i > 0 {
+			from = limits[i-1]
+		}
+
+		value := FacetValue{Count: count, From: &from}
+		if i < len(limits) {
+			to := limits[i]
+			value.To = &to
+			value.Value = formatAmount(from) + "-" + formatAmount(to)
+		} else {
+			value.Value = formatAmount(from) + "+"
+		}
+
+		facet.Values = append(facet.Values, value)
+	}
+
+	return facet
+}
+
+// ratingFacet cuenta los productos con calificación mayor o igual a cada banda ("4+"),
+// de modo que cada valor corresponde a un filtro min_rating
+func ratingFacet(products []*Product) Facet {
+	facet := Facet{Name: FacetRating, Values: []FacetValue{}}
+
+	for _, band := range RatingBands {
+		count := 0
+		for _, product := range products {
+			if product.Rating >= float32(band) {
+				count++
+			}
+		}
+
+		if count > 0 {
+			from := band
+			facet.Values = append(facet.Values, FacetValue{Value: formatAmount(band) + "+", Count: count, From: &from})
+		}
+	}
+
+	return facet
+}
+
+// specFacet cuenta los productos por valor de una especificación. Los valores numéricos se
+// agrupan en la unidad canónica y se ordenan de menor a mayor; el resto se ordena por cantidad.
+func specFacet(name string, products []*Product) Facet {
+	type specCount struct {
+		value   FacetValue
+		number  float64
+		numeric bool
+	}
+
+	counts := make(map[string]*specCount)
+	var order []string
+
+	for _, product := range products {
+		for _, spec := range product.Specifications {
+			if specKey(spec.Name) != specKey(name) {
+				continue
+			}
+
+			typed := spec.Typed
+			if typed.Kind == "" {
+				typed = ParseSpecValue(spec.Value, spec.Unit)
+			}
+
+			label := strings.TrimSpace(spec.Value + " " + spec.Unit)
+			key := strings.ToLower(label)
+			if typed.IsNumber() {
+				label = strings.TrimSpace(formatAmount(math.Round(typed.Number*100)/100) + " " + typed.Unit)
+				key = "#" + strings.ToLower(label)
+			}
+
+			if _, exists := counts[key]; !exists {
+				counts[key] = &specCount{
+					value:   FacetValue{Value: label},
+					number:  typed.Number,
+					numeric: typed.IsNumber(),
+				}
+				order = append(order, key)
+			}
+			counts[key].value.Count++
+			break
+		}
+	}
+
+	entries := make([]*specCount, len(order))
+	for i, key := range order {
+		entries[i] = counts[key]
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.numeric != b.numeric {
+			return a.numeric
+		}
+		if a.numeric && a.number != b.number {
+			return a.number < b.number
+		}
+		if !a.numeric && a.value.Count != b.value.Count {
+			return a.value.Count > b.value.Count
+		}
+		return strings.ToLower(a.value.Value) < strings.ToLower(b.value.Value)
+	})
+
+	facet := Facet{Name: FacetSpecPrefix + name, Values: make([]FacetValue, len(entries))}
+	for i, entry := range entries {
+		facet.Values[i] = entry.value
+	}
+
+	return facet
+}
+
+// formatAmount formatea un número sin decimales innecesarios
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FilterOperator representa el operador de comparación de un predicado de especificación
+type FilterOperator string
+
+// Operadores soportados por los predicados de especificación
+const (
+	OpEqual          FilterOperator = "="
+	OpNotEqual       FilterOperator = "!="
+	OpGreater        FilterOperator = ">"
+	OpGreaterOrEqual FilterOperator = ">="
+	OpLess           FilterOperator = "<"
+	OpLessOrEqual    FilterOperator = "<="
+	OpContains       FilterOperator = "~"
+)
+
+// filterOperators lista los operadores de mayor a menor longitud para reconocerlos sin ambigüedad
+var filterOperators = []FilterOperator{
+	OpGreaterOrEqual, OpLessOrEqual, OpNotEqual, OpGreater, OpLess, OpEqual, OpContains,
+}
+
+// SpecPredicate representa una condición sobre una especificación ("RAM>=12")
+type SpecPredicate struct {
+	// Nombre de la especificación
+	Name string `json:"name" example:"RAM"`
+
+	// Operador de comparación
+	Operator FilterOperator `json:"operator" example:">="`
+
+	// Valor esperado tal como fue escrito, opcionalmente con unidad ("1TB")
+	Value string `json:"value" example:"12"`
+}
+
+// ParseSpecPredicate interpreta una expresión de la forma <nombre><operador><valor>,
+// por ejemplo "RAM>=12", "Almacenamiento=1TB" o "Sistema Operativo~Android".
+func ParseSpecPredicate(expr string) (SpecPredicate, error) {
+	idx := strings.IndexAny(expr, "<>=!~")
+	if idx < 0 {
+		return SpecPredicate{}, &ValidationError{
+			Field:   "spec",
+			Message: fmt.Sprintf("missing operator in specification filter '%s'", expr),
+		}
+	}
+
+	var operator FilterOperator
+	for _, candidate := range filterOperators {
+		if strings.HasPrefix(expr[idx:], string(candidate)) {
+			operator = candidate
+			break
+		}
+	}
+	if operator == "" {
+		return SpecPredicate{}, &ValidationError{
+			Field:   "spec",
+			Message: fmt.Sprintf("invalid operator in specification filter '%s'", expr),
+		}
+	}
+
+	predicate := SpecPredicate{
+		Name:     strings.TrimSpace(expr[:idx]),
+		Operator: operator,
+		Value:    strings.TrimSpace(expr[idx+len(operator):]),
+	}
+
+	if predicate.Name == "" || predicate.Value == "" {
+		return SpecPredicate{}, &ValidationError{
+			Field:   "spec",
+			Message: fmt.Sprintf("specification filter '%s' must have a name and a value", expr),
+		}
+	}
+
+	return predicate, nil
+}
+
+// String devuelve la representación textual del predicado
+func (p SpecPredicate) String() string {
+	return p.Name + string(p.Operator) + p.Value
+}
+
+// Matches indica si el producto cumple el predicado. Un producto que no declara la
+// especificación nunca cumple el predicado.
+func (p SpecPredicate) Matches(product *Product) bool {
+	for _, spec := range product.Specifications {
+		if specKey(spec.Name) == specKey(p.Name) {
+			return p.matchesSpec(spec)
+		}
+	}
+
+	return false
+}
+
+// matchesSpec evalúa el predicado contra una especificación. Si la especificación y el valor
+// esperado son numéricos se comparan como números en la unidad canónica; un valor esperado
+// sin unidad se interpreta en la unidad en que se declaró la especificación.
+func (p SpecPredicate) matchesSpec(spec Specification) bool {
+	actual := spec.Typed
+	if actual.Kind == "" {
+		actual = ParseSpecValue(spec.Value, spec.Unit)
+	}
+
+	if p.Operator == OpContains {
+		display := strings.ToLower(strings.TrimSpace(spec.Value + " " + spec.Unit))
+		return strings.Contains(display, strings.ToLower(p.Value))
+	}
+
+	expected := ParseSpecValue(p.Value, "")
+
+	if actual.IsNumber() && expected.IsNumber() {
+		if expected.Unit != "" && !strings.EqualFold(expected.Unit, actual.Unit) {
+			return false
+		}
+		number := expected.Number
+		if expected.Unit == "" {
+			number = inDeclaredUnit(number, spec, actual)
+		}
+		return compareNumbers(actual.Number, number, p.Operator)
+	}
+
+	switch p.Operator {
+	case OpEqual:
+		return valuesEqual(actual, expected, spec, p.Value)
+	case OpNotEqual:
+		return !valuesEqual(actual, expected, spec, p.Value)
+	}
+
+	// Los operadores de orden solo aplican a valores numéricos
+	return false
+}
+
+// inDeclaredUnit convierte un número sin unidad desde la unidad en que se declaró la
+// especificación ("30" para "30 mm") a la unidad canónica del valor interpretado. Si la unidad
+// declarada no es conocida el número se usa tal cual.
+func inDeclaredUnit(number float64, spec Specification, actual SpecValue) float64 {
+	declared := strings.TrimSpace(spec.Unit)
+	if declared == "" {
+		_, declared, _ = splitLeadingNumber(strings.TrimSpace(spec.Value))
+	}
+	if _, known := LookupUnit(declared); !known {
+		// La unidad puede traer un calificador ("GB SSD")
+		if fields := strings.Fields(declared); len(fields) > 0 {
+			declared = fields[0]
+		}
+	}
+
+	converted, err := ConvertUnit(number, declared, actual.Unit)
+	if err != nil {
+		return number
+	}
+
+	return converted
+}
+
+// valuesEqual compara valores no numéricos; el texto se compara sin distinguir mayúsculas
+func valuesEqual(actual, expected SpecValue, spec Specification, raw string) bool {
+	if actual.Kind == SpecKindBoolean && expected.Kind == SpecKindBoolean {
+		return actual.Equal(expected)
+	}
+
+	return strings.EqualFold(strings.TrimSpace(spec.Value), raw)
+}
+
+// compareNumbers aplica un operador de orden entre dos números
+func compareNumbers(actual, expected float64, operator FilterOperator) bool {
+	const epsilon = 1e-9
+
+	switch operator {
+	case OpEqual:
+		return math.Abs(actual-expected) < epsilon
+	case OpNotEqual:
+		return math.Abs(actual-expected) >= epsilon
+	case OpGreater:
+		return actual > expected+epsilon
+	case OpGreaterOrEqual:
+		return actual >= expected-epsilon
+	case OpLess:
+		return actual < expected-epsilon
+	case OpLessOrEqual:
+		return actual <= expected+epsilon
+	}
+
+	return false
+}
+
+// ProductFilter agrupa los criterios de filtrado del listado de productos.
+// El valor cero no filtra ningún producto.
+type ProductFilter struct {
+	// Categoría, por nombre o ID, incluyendo sus subcategorías (sin distinguir mayúsculas)
+	Category string `json:"category,omitempty" example:"Smartphones"`
+
+	// Precio mínimo; 0 desactiva el filtro
+	MinPrice float64 `json:"min_price,omitempty" example:"0"`
+
+	// Precio máximo; 0 desactiva el filtro
+	MaxPrice float64 `json:"max_price,omitempty" example:"2000"`
+
+	// Marca exacta (sin distinguir mayúsculas)
+	Brand string `json:"brand,omitempty" example:"Samsung"`
+
+	// Disponibilidad; nil desactiva el filtro
+	Available *bool `json:"available,omitempty"`
+
+	// Calificación mínima; 0 desactiva el filtro
+	MinRating float64 `json:"min_rating,omitempty" example:"4"`
+
+	// Predicados sobre especificaciones; todos deben cumplirse
+	Specs []SpecPredicate `json:"specs,omitempty"`
+
+	// Condición adicional, por ejemplo la de una consulta estructurada; nil desactiva el filtro
+	Condition Condition `json:"-"`
+}
+
+// Matches indica si el producto cumple todos los criterios del filtro
+func (f ProductFilter) Matches(product *Product) bool {
+	if f.Category != "" && !product.InCategory(f.Category) {
+		return false
+	}
+
+	if f.MinPrice > 0 && product.Price < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && product.Price > f.MaxPrice {
+		return false
+	}
+
+	if f.Brand != "" && !strings.EqualFold(product.Brand, f.Brand) {
+		return false
+	}
+
+	if f.Available != nil && product.Available != *f.Available {
+		return false
+	}
+
+	if f.MinRating > 0 && product.Rating < float32(f.MinRating) {
+		return false
+	}
+
+	for _, predicate := range f.Specs {
+		if !predicate.Matches(product) {
+			return false
+		}
+	}
+
+	if f.Condition != nil && !f.Condition.Matches(product) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FilterNode representa un nodo de un árbol de filtros expresado como documento JSON.
+// Cada nodo es exactamente uno de: una combinación (and, or, not), una comparación sobre
+// un campo del producto (field, op, value), una comparación sobre una especificación
+// (spec, op, value) o un texto que debe aparecer en el producto (text).
+// @Description Boolean filter tree node
+type FilterNode struct {
+	// Condiciones que deben cumplirse todas
+	And []FilterNode `json:"and,omitempty"`
+
+	// Condiciones de las que debe cumplirse al menos una
+	Or []FilterNode `json:"or,omitempty"`
+
+	// Condición que no debe cumplirse
+	Not *FilterNode `json:"not,omitempty"`
+
+	// Campo del producto (id, name, description, brand, category, price, rating, available)
+	Field string `json:"field,omitempty" example:"brand"`
+
+	// Nombre de la especificación
+	Spec string `json:"spec,omitempty" example:"RAM"`
+
+	// Operador de comparación (=, !=, >, >=, <, <=, ~)
+	Op FilterOperator `json:"op,omitempty" example:"="`
+
+	// Valor esperado: texto, número o booleano según el campo
+	Value interface{} `json:"value,omitempty" swaggertype:"string" example:"Samsung"`
+
+	// Palabra o frase que debe aparecer en el nombre, la marca, la categoría o la descripción
+	Text string `json:"text,omitempty" example:"S Pen"`
+}
+
+// CompileFilter valida el árbol de filtros y lo convierte en una condición. Los errores se
+// informan por campo con la ruta del nodo dentro del documento ("filter.and[1].op"). Un
+// árbol nil no filtra ningún producto.
+func CompileFilter(node *FilterNode) (Condition, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	var errs ValidationErrors
+	condition := node.compile("filter", &errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return condition, nil
+}
+
+// compile convierte el nodo en una condición, acumulando los errores de validación
+func (n *FilterNode) compile(path string, errs *ValidationErrors) Condition {
+	invalid := func(field, message string) Condition {
+		*errs = append(*errs, &ValidationError{Field: field, Message: message})
+		return nil
+	}
+
+	var kinds []string
+	if n.And != nil {
+		kinds = append(kinds, "and")
+	}
+	if n.Or != nil {
+		kinds = append(kinds, "or")
+	}
+	if n.Not != nil {
+		kinds = append(kinds, "not")
+	}
+	if n.Field != "" {
+		kinds = append(kinds, "field")
+	}
+	if n.Spec != "" {
+		kinds = append(kinds, "spec")
+	}
+	if n.Text != "" {
+		kinds = append(kinds, "text")
+	}
+
+	switch len(kinds) {
+	case 0:
+		return invalid(path, "node must have one of 'and', 'or', 'not', 'field', 'spec' or 'text'")
+	case 1:
+	default:
+		return invalid(path, fmt.Sprintf("node must have only one of 'and', 'or', 'not', 'field', 'spec' or 'text', got %s", strings.Join(kinds, ", ")))
+	}
+
+	switch kinds[0] {
+	case "and", "or":
+		children := n.And
+		if kinds[0] == "or" {
+			children = n.Or
+		}
+		if len(children) == 0 {
+			return invalid(path+"."+kinds[0], "must contain at least one condition")
+		}
+
+		conditions := make([]Condition, len(children))
+		for i := range children {
+			conditions[i] = children[i].compile(fmt.Sprintf("%s.%s[%d]", path, kinds[0], i), errs)
+		}
+		if kinds[0] == "or" {
+			return OrCondition{Conditions: conditions}
+		}
+		return AndCondition{Conditions: conditions}
+	case "not":
+		return NotCondition{Condition: n.Not.compile(path+".not", errs)}
+	case "text":
+		if n.Op != "" || n.Value != nil {
+			return invalid(path, "'text' nodes do not accept 'op' or 'value'")
+		}
+		return TextCondition{Text: n.Text}
+	}
+
+	return n.compileComparison(path, errs)
+}
+
+// compileComparison convierte un nodo field o spec en una condición
+func (n *FilterNode) compileComparison(path string, errs *ValidationErrors) Condition {
+	failed := len(*errs)
+	invalid := func(field, message string) {
+		*errs = append(*errs, &ValidationError{Field: field, Message: message})
+	}
+
+	if n.Op == "" {
+		invalid(path+".op", "is required")
+	} else if !isFilterOperator(n.Op) {
+		invalid(path+".op", fmt.Sprintf("invalid operator '%s', use =, !=, >, >=, <, <= or ~", n.Op))
+	}
+
+	value, ok := filterValueString(n.Value)
+	if n.Value == nil {
+		invalid(path+".value", "is required")
+	} else if !ok {
+		invalid(path+".value", "must be a string, number or boolean")
+	}
+
+	if n.Spec != "" {
+		if strings.TrimSpace(n.Spec) == "" {
+			invalid(path+".spec", "must not be blank")
+		}
+		if len(*errs) > failed {
+			return nil
+		}
+		return SpecPredicate{Name: strings.TrimSpace(n.Spec), Operator: n.Op, Value: strings.TrimSpace(value)}
+	}
+
+	field := strings.ToLower(strings.TrimSpace(n.Field))
+	if !IsProductField(field) {
+		invalid(path+".field", fmt.Sprintf("unknown field '%s', use id, name, description, brand, category, price, rating or available", n.Field))
+	} else if n.Value != nil && ok {
+		// El tipo del valor debe coincidir con el del campo
+		switch n.Value.(type) {
+		case float64:
+			if field != FieldPrice && field != FieldRating {
+				invalid(path+".value", fmt.Sprintf("must be a string for field '%s'", field))
+			}
+		case bool:
+			if field != FieldAvailable {
+				invalid(path+".value", fmt.Sprintf("must be a %s for field '%s'", fieldValueType(field), field))
+			}
+		case string:
+			if field == FieldPrice || field == FieldRating || field == FieldAvailable {
+				invalid(path+".value", fmt.Sprintf("must be a %s for field '%s'", fieldValueType(field), field))
+			}
+		}
+	}
+	if len(*errs) > failed {
+		return nil
+	}
+
+	condition, err := NewFieldCondition(field, n.Op, value)
+	if err != nil {
+		message := err.Error()
+		if validation, ok := err.(*ValidationError); ok {
+			message = validation.Message
+		}
+		invalid(path+".op", message)
+		return nil
+	}
+
+	return condition
+}
+
+// fieldValueType devuelve el tipo JSON del valor esperado por un campo del producto
+func fieldValueType(field string) string {
+	switch field {
+	case FieldPrice, FieldRating:
+		return "number"
+	case FieldAvailable:
+		return "boolean"
+	}
+
+	return "string"
+}
+
+// filterValueString representa como texto un valor JSON escalar
+func filterValueString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+
+	return "", false
+}
+
+// isFilterOperator indica si el operador es uno de los operadores de filtro soportados
+func isFilterOperator(operator FilterOperator) bool {
+	for _, candidate := range filterOperators {
+		if candidate == operator {
+			return true
+		}
+	}
+
+	return false
+}
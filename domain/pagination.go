@@ -0,0 +1,419 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Valores por defecto y límites de paginación
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Campos por los que se puede ordenar un listado de productos
+const (
+	SortByPrice  = "price"
+	SortByRating = "rating"
+	SortByName   = "name"
+
+	// SortByCompleteness ordena por el puntaje de completitud de las especificaciones
+	SortByCompleteness = "completeness"
+)
+
+// SortKey representa un criterio de ordenamiento
+type SortKey struct {
+	// Campo por el que se ordena (price, rating, name, completeness)
+	Field string `json:"field" example:"price"`
+
+	// Orden descendente
+	Descending bool `json:"descending" example:"false"`
+}
+
+// String devuelve la representación textual del criterio ("price:asc")
+func (k SortKey) String() string {
+	if k.Descending {
+		return k.Field + ":desc"
+	}
+
+	return k.Field + ":asc"
+}
+
+// ParseSortKeys interpreta una lista de criterios separados por comas. Cada criterio puede
+// escribirse como "price", "-price" (descendente) o "price:asc" / "price:desc".
+func ParseSortKeys(value string) ([]SortKey, error) {
+	var keys []SortKey
+
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		key := SortKey{}
+		if strings.HasPrefix(raw, "-") {
+			key.Descending = true
+			raw = raw[1:]
+		}
+
+		if field, direction, found := strings.Cut(raw, ":"); found {
+			raw = field
+			switch strings.ToLower(strings.TrimSpace(direction)) {
+			case "asc":
+				key.Descending = false
+			case "desc":
+				key.Descending = true
+			default:
+				return nil, &ValidationError{
+					Field:   "sort",
+					Message: fmt.Sprintf("invalid sort direction '%s', use 'asc' or 'desc'", direction),
+				}
+			}
+		}
+
+		key.Field = strings.ToLower(strings.TrimSpace(raw))
+		switch key.Field {
+		case SortByPrice, SortByRating, SortByName, SortByCompleteness:
+		default:
+			return nil, &ValidationError{
+				Field:   "sort",
+				Message: fmt.Sprintf("invalid sort field '%s', use price, rating, name or completeness", raw),
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// ValidateSortKeys verifica los criterios de ordenamiento recibidos en un documento y los
+// devuelve normalizados. Los errores se informan con la posición del criterio ("sort[1].field").
+func ValidateSortKeys(keys []SortKey) ([]SortKey, error) {
+	var errs ValidationErrors
+	normalized := make([]SortKey, len(keys))
+
+	for i, key := range keys {
+		key.Field = strings.ToLower(strings.TrimSpace(key.Field))
+		switch key.Field {
+		case SortByPrice, SortByRating, SortByName, SortByCompleteness:
+		default:
+			errs = append(errs, &ValidationError{
+				Field:   fmt.Sprintf("sort[%d].field", i),
+				Message: fmt.Sprintf("invalid sort field '%s', use price, rating, name or completeness", keys[i].Field),
+			})
+		}
+		normalized[i] = key
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return normalized, nil
+}
+
+// SortProducts ordena los productos de forma estable según los criterios indicados.
+// El ID se usa como desempate final para que el orden sea determinista.
+func SortProducts(products []*Product, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		return compareBySortKeys(products[i], products[j], keys) < 0
+	})
+}
+
+// compareBySortKeys compara dos productos según los criterios y, en empate, por ID
+func compareBySortKeys(a, b *Product, keys []SortKey) int {
+	for _, key := range keys {
+		var cmp int
+
+		switch key.Field {
+		case SortByPrice:
+			cmp = compareFloat(a.Price, b.Price)
+		case SortByRating:
+			cmp = compareFloat(float64(a.Rating), float64(b.Rating))
+		case SortByName:
+			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case SortByCompleteness:
+			cmp = compareFloat(a.CompletenessScore(), b.CompletenessScore())
+		}
+
+		if key.Descending {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	return strings.Compare(a.ID, b.ID)
+}
+
+// compareFloat compara dos números devolviendo -1, 0 o 1
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// PageRequest representa la solicitud de una página de resultados. Se puede paginar por
+// número de página o, alternativamente, con un cursor opaco obtenido de la página anterior.
+type PageRequest struct {
+	// Número de página (comienza en 1)
+	Page int `json:"page,omitempty" example:"1"`
+
+	// Cantidad de elementos por página
+	PageSize int `json:"page_size,omitempty" example:"20"`
+
+	// Cursor opaco de la página anterior; si se informa, Page se ignora
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// PageInfo describe la página devuelta dentro del total de resultados
+type PageInfo struct {
+	// Total de elementos que cumplen la consulta
+	TotalCount int `json:"total_count" example:"150"`
+
+	// Número de página devuelta (0 si se paginó por cursor)
+	Page int `json:"page,omitempty" example:"1"`
+
+	// Cantidad de elementos por página
+	PageSize int `json:"page_size" example:"20"`
+
+	// Total de páginas
+	TotalPages int `json:"total_pages" example:"8"`
+
+	// Cursor para obtener la página siguiente; vacío si no hay más resultados
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ProductPage representa una página de productos
+type ProductPage struct {
+	// Productos de la página
+	Items []*Product `json:"items"`
+
+	// Información de paginación
+	PageInfo
+
+	// Facetas calculadas sobre todos los productos que cumplen la consulta, si se solicitaron
+	Facets []Facet `json:"facets,omitempty"`
+}
+
+// pageCursor es el contenido codificado de un cursor de paginación
+type pageCursor struct {
+	// Criterios de ordenamiento con los que se generó el cursor
+	Sort string `json:"s"`
+
+	// ID del último producto devuelto
+	LastID string `json:"id"`
+
+	// Valores de los campos de ordenamiento del último producto
+	Values []string `json:"v,omitempty"`
+}
+
+// Paginate ordena los productos y devuelve la página solicitada. Con cursor, la página
+// comienza inmediatamente después del último producto de la página anterior, lo que
+// mantiene la iteración estable aunque cambie el total de resultados.
+func Paginate(products []*Product, keys []SortKey, request PageRequest) (*ProductPage, error) {
+	pageSize := request.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	// Se ordena una copia para no alterar el slice recibido
+	products = append([]*Product(nil), products...)
+	SortProducts(products, keys)
+
+	info := PageInfo{
+		TotalCount: len(products),
+		PageSize:   pageSize,
+		TotalPages: (len(products) + pageSize - 1) / pageSize,
+	}
+
+	var start int
+	if request.Cursor != "" {
+		cursor, err := decodeCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		start, err = cursorStart(products, keys, cursor)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		info.Page = request.Page
+		if info.Page <= 0 {
+			info.Page = 1
+		}
+		start = (info.Page - 1) * pageSize
+	}
+
+	if start > len(products) {
+		start = len(products)
+	}
+	end := start + pageSize
+	if end > len(products) {
+		end = len(products)
+	}
+
+	items := products[start:end]
+	if end < len(products) && len(items) > 0 {
+		info.NextCursor = encodeCursor(items[len(items)-1], keys)
+	}
+
+	return &ProductPage{Items: items, PageInfo: info}, nil
+}
+
+// ValidatePageRequest verifica los parámetros de paginación recibidos en un documento
+func ValidatePageRequest(request PageRequest) error {
+	var errs ValidationErrors
+
+	if request.Page < 0 {
+		errs = append(errs, &ValidationError{Field: "page", Message: "must be a positive integer"})
+	}
+	if request.PageSize < 0 || request.PageSize > MaxPageSize {
+		errs = append(errs, &ValidationError{Field: "page_size", Message: fmt.Sprintf("must be an integer between 1 and %d", MaxPageSize)})
+	}
+	if request.Cursor != "" {
+		if request.Page > 0 {
+			errs = append(errs, &ValidationError{Field: "cursor", Message: "use either 'page' or 'cursor', not both"})
+		} else if err := ValidateCursor(request.Cursor); err != nil {
+			errs = append(errs, &ValidationError{Field: "cursor", Message: "malformed cursor"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidateCursor verifica que un cursor tenga un formato válido
+func ValidateCursor(cursor string) error {
+	_, err := decodeCursor(cursor)
+	return err
+}
+
+// cursorStart devuelve la posición del primer producto posterior al cursor
+func cursorStart(products []*Product, keys []SortKey, cursor *pageCursor) (int, error) {
+	if cursor.Sort != sortSignature(keys) {
+		return 0, &ValidationError{Field: "cursor", Message: "cursor was generated with a different sort order"}
+	}
+
+	// Sin criterios de ordenamiento se continúa a partir de la posición del último ID
+	if len(keys) == 0 {
+		for i, product := range products {
+			if product.ID == cursor.LastID {
+				return i + 1, nil
+			}
+		}
+		return 0, &ValidationError{Field: "cursor", Message: "cursor refers to a product that no longer matches the query"}
+	}
+
+	anchor, err := cursor.anchor(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	return sort.Search(len(products), func(i int) bool {
+		return compareBySortKeys(products[i], anchor, keys) > 0
+	}), nil
+}
+
+// anchor reconstruye un producto con los valores de ordenamiento guardados en el cursor
+func (c *pageCursor) anchor(keys []SortKey) (*Product, error) {
+	if len(c.Values) != len(keys) {
+		return nil, &ValidationError{Field: "cursor", Message: "malformed cursor"}
+	}
+
+	anchor := &Product{ID: c.LastID}
+	for i, key := range keys {
+		switch key.Field {
+		case SortByPrice:
+			price, err := strconv.ParseFloat(c.Values[i], 64)
+			if err != nil {
+				return nil, &ValidationError{Field: "cursor", Message: "malformed cursor"}
+			}
+			anchor.Price = price
+		case SortByRating:
+			rating, err := strconv.ParseFloat(c.Values[i], 32)
+			if err != nil {
+				return nil, &ValidationError{Field: "cursor", Message: "malformed cursor"}
+			}
+			anchor.Rating = float32(rating)
+		case SortByName:
+			anchor.Name = c.Values[i]
+		case SortByCompleteness:
+			score, err := strconv.ParseFloat(c.Values[i], 64)
+			if err != nil {
+				return nil, &ValidationError{Field: "cursor", Message: "malformed cursor"}
+			}
+			anchor.Completeness = &SpecCompleteness{Score: score}
+		}
+	}
+
+	return anchor, nil
+}
+
+// encodeCursor genera el cursor opaco que apunta después del producto indicado
+func encodeCursor(last *Product, keys []SortKey) string {
+	cursor := pageCursor{Sort: sortSignature(keys), LastID: last.ID}
+
+	for _, key := range keys {
+		switch key.Field {
+		case SortByPrice:
+			cursor.Values = append(cursor.Values, strconv.FormatFloat(last.Price, 'g', -1, 64))
+		case SortByRating:
+			cursor.Values = append(cursor.Values, strconv.FormatFloat(float64(last.Rating), 'g', -1, 32))
+		case SortByName:
+			cursor.Values = append(cursor.Values, last.Name)
+		case SortByCompleteness:
+			cursor.Values = append(cursor.Values, strconv.FormatFloat(last.CompletenessScore(), 'g', -1, 64))
+		}
+	}
+
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// decodeCursor decodifica un cursor opaco
+func decodeCursor(value string) (*pageCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, &ValidationError{Field: "cursor", Message: "malformed cursor"}
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil || cursor.LastID == "" {
+		return nil, &ValidationError{Field: "cursor", Message: "malformed cursor"}
+	}
+
+	return &cursor, nil
+}
+
+// sortSignature representa los criterios de ordenamiento como texto para validar cursores
+func sortSignature(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.String()
+	}
+
+	return strings.Join(parts, ",")
+}
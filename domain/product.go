@@ -1,12 +1,15 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Product representa una entidad de producto para comparación
 // @Description Product model for comparison
 type Product struct {
 	// Identificador único del producto
-	ID string `json:"id" example:"PHONE001"`
+	ID string `json:"id" example:"PHONE001" validate:"required"`
 	
 	// Nombre del producto
 	Name string `json:"name" example:"Samsung Galaxy S24 Ultra" validate:"required"`
@@ -24,16 +27,25 @@ type Product struct {
 	Rating float32 `json:"rating" example:"4.5" validate:"required,gte=0,lte=5"`
 	
 	// Especificaciones técnicas del producto
-	Specifications []Specification `json:"specifications"`
+	Specifications []Specification `json:"specifications" validate:"dive"`
 	
 	// Categoría del producto
 	Category string `json:"category" example:"Smartphones"`
+
+	// Camino de categorías desde la raíz de la taxonomía hasta la categoría del producto
+	Breadcrumbs []CategoryRef `json:"breadcrumbs,omitempty"`
 	
 	// Marca del producto
 	Brand string `json:"brand" example:"Samsung"`
 	
 	// Estado de disponibilidad
 	Available bool `json:"available" example:"true"`
+
+	// Versión del producto: empieza en 1 y aumenta con cada modificación
+	Version int64 `json:"version" example:"3"`
+
+	// Completitud de las especificaciones según el esquema de su categoría; nil si no hay esquema
+	Completeness *SpecCompleteness `json:"completeness,omitempty"`
 }
 
 // Specification representa una especificación técnica de un producto
@@ -47,6 +59,11 @@ type Specification struct {
 	
 	// Unidad de medida si aplica
 	Unit string `json:"unit,omitempty" example:"inches"`
+
+	// Valor interpretado (número con unidad canónica, booleano o texto), usado por los
+	// filtros, el orden, las facetas y la comparación. No forma parte de la API: se deriva de
+	// Value y Unit al cargar o escribir el producto.
+	Typed SpecValue `json:"-"`
 }
 
 // ProductRepository define la interfaz para acceso a datos de productos
@@ -54,8 +71,8 @@ type ProductRepository interface {
 	// GetByID obtiene un producto por su ID
 	GetByID(id string) (*Product, error)
 	
-	// GetAll obtiene todos los productos con filtrado opcional
-	GetAll(category string, minPrice, maxPrice float64) ([]*Product, error)
+	// GetAll obtiene todos los productos que cumplen el filtro; el filtro vacío no excluye ninguno
+	GetAll(filter ProductFilter) ([]*Product, error)
 	
 	// GetByIDs obtiene múltiples productos por sus IDs para comparación
 	GetByIDs(ids []string) ([]*Product, error)
@@ -64,6 +81,23 @@ type ProductRepository interface {
 	Search(query string) ([]*Product, error)
 }
 
+// WritableProductRepository extiende ProductRepository con las operaciones de escritura.
+// Los cambios se persisten antes de devolver y quedan visibles para las lecturas siguientes.
+type WritableProductRepository interface {
+	ProductRepository
+
+	// Create agrega un producto nuevo y lo devuelve tal como quedó en el catálogo
+	Create(product *Product) (*Product, error)
+
+	// Update reemplaza el producto con el mismo ID y lo devuelve tal como quedó en el catálogo,
+	// con la versión siguiente. Si product.Version no es cero debe ser la versión actual; si no
+	// lo es devuelve ProductVersionConflictError sin modificar el producto.
+	Update(product *Product) (*Product, error)
+
+	// Delete elimina el producto con el ID indicado y lo devuelve
+	Delete(id string) (*Product, error)
+}
+
 // ProductNotFoundError representa un error cuando no se encuentra un producto
 type ProductNotFoundError struct {
 	ID string
@@ -73,6 +107,27 @@ func (e *ProductNotFoundError) Error() string {
 	return fmt.Sprintf("product with ID '%s' not found", e.ID)
 }
 
+// ProductAlreadyExistsError representa un error al crear un producto con un ID que ya existe
+type ProductAlreadyExistsError struct {
+	ID string
+}
+
+func (e *ProductAlreadyExistsError) Error() string {
+	return fmt.Sprintf("product with ID '%s' already exists", e.ID)
+}
+
+// ProductVersionConflictError representa un error al modificar un producto a partir de una
+// versión que ya no es la actual, porque otra modificación se aplicó antes
+type ProductVersionConflictError struct {
+	ID       string
+	Expected int64
+	Current  int64
+}
+
+func (e *ProductVersionConflictError) Error() string {
+	return fmt.Sprintf("product with ID '%s' is at version %d, not %d", e.ID, e.Current, e.Expected)
+}
+
 // InvalidProductIDError representa un error cuando el ID del producto es inválido
 type InvalidProductIDError struct {
 	ID string
@@ -84,10 +139,22 @@ func (e *InvalidProductIDError) Error() string {
 
 // ValidationError representa un error de validación
 type ValidationError struct {
-	Field   string
-	Message string
+	Field   string `json:"field" example:"price"`
+	Message string `json:"message" example:"must be greater than 0"`
 }
 
 func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error on field '%s': %s", e.Field, e.Message)
 }
+
+// ValidationErrors agrupa los errores de validación de un documento, uno por campo inválido
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
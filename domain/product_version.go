@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InitialProductVersion es la versión de un producto recién creado, o cargado sin versión
+const InitialProductVersion int64 = 1
+
+// RecreatedProductVersion devuelve la versión con la que se crea un producto cuyo ID
+// perteneció a uno eliminado en la versión indicada: continúa su numeración, para que una
+// ETag del producto eliminado no coincida con el nuevo. Sin versión previa es la inicial.
+func RecreatedProductVersion(deletedVersion int64) int64 {
+	if deletedVersion < InitialProductVersion {
+		return InitialProductVersion
+	}
+
+	return deletedVersion + 1
+}
+
+// ProductETag devuelve la ETag HTTP que identifica la versión de un producto ("3" entre comillas)
+func ProductETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseProductETag interpreta una ETag de producto, como la recibida en el encabezado If-Match.
+// Solo se aceptan ETags fuertes con una versión válida: las débiles (W/"3") no identifican
+// una versión exacta y se rechazan.
+func ParseProductETag(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, fmt.Errorf("invalid ETag '%s', want a quoted product version such as \"3\"", value)
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version < InitialProductVersion {
+		return 0, fmt.Errorf("invalid ETag '%s', want a quoted product version such as \"3\"", value)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProjectableFields son los campos del producto que se pueden seleccionar en una proyección
+var ProjectableFields = []string{
+	"id", "name", "image_url", "description", "price", "rating",
+	"specifications", "category", "breadcrumbs", "brand", "available", "version", "completeness",
+}
+
+// ValidateProjection verifica que los campos de una proyección existan en el producto.
+// Los errores se informan con la posición del campo en la lista ("fields[2]").
+func ValidateProjection(fields []string) error {
+	var errs ValidationErrors
+
+	for i, field := range fields {
+		if !isProjectableField(field) {
+			errs = append(errs, &ValidationError{
+				Field:   fmt.Sprintf("fields[%d]", i),
+				Message: fmt.Sprintf("unknown field '%s', use %s", field, strings.Join(ProjectableFields, ", ")),
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// isProjectableField indica si el campo se puede seleccionar en una proyección
+func isProjectableField(field string) bool {
+	for _, candidate := range ProjectableFields {
+		if candidate == strings.ToLower(strings.TrimSpace(field)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProjectProduct devuelve solo los campos indicados del producto, con los mismos nombres que
+// en su representación JSON. Los campos desconocidos se ignoran.
+func ProjectProduct(product *Product, fields []string) map[string]interface{} {
+	projected := make(map[string]interface{}, len(fields))
+
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+
+		switch field {
+		case "id":
+			projected[field] = product.ID
+		case "name":
+			projected[field] = product.Name
+		case "image_url":
+			projected[field] = product.ImageURL
+		case "description":
+			projected[field] = product.Description
+		case "price":
+			projected[field] = product.Price
+		case "rating":
+			projected[field] = product.Rating
+		case "specifications":
+			projected[field] = product.Specifications
+		case "category":
+			projected[field] = product.Category
+		case "breadcrumbs":
+			projected[field] = product.Breadcrumbs
+		case "brand":
+			projected[field] = product.Brand
+		case "available":
+			projected[field] = product.Available
+		case "version":
+			projected[field] = product.Version
+		case "completeness":
+			projected[field] = product.Completeness
+		}
+	}
+
+	return projected
+}
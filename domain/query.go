@@ -0,0 +1,536 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SearchPlan es el resultado de interpretar una consulta de búsqueda estructurada: el texto
+// libre que se busca por relevancia y la condición que deben cumplir los resultados
+type SearchPlan struct {
+	// Texto libre de la consulta, utilizado para la búsqueda por relevancia
+	Text string
+
+	// Condición que deben cumplir los resultados; nil si la consulta solo tiene texto libre
+	Condition Condition
+
+	// Términos de campo cuyo nombre no es un campo del producto ni lleva el prefijo "spec.":
+	// se interpretan como especificaciones si el catálogo las tiene (ver CheckSpecNames)
+	specTerms []querySpecTerm
+}
+
+// querySpecTerm es el nombre de una especificación usado como campo y su posición en la consulta
+type querySpecTerm struct {
+	name     string
+	position int
+}
+
+// CheckSpecNames verifica que los términos de campo que no son campos del producto ni llevan
+// el prefijo "spec." sean especificaciones del catálogo. Devuelve un QuerySyntaxError en el
+// primero que no lo sea, para que un campo mal escrito (brnad:Samsung) no se interprete como
+// una especificación sin resultados.
+func (p *SearchPlan) CheckSpecNames(catalog SpecificationCatalog) error {
+	for _, term := range p.specTerms {
+		known, err := catalog.HasSpecification(term.name)
+		if err != nil {
+			return err
+		}
+		if !known {
+			return &QuerySyntaxError{
+				Position: term.position,
+				Message:  fmt.Sprintf("unknown field '%s', use id, name, description, brand, category, price, rating, available, a specification of the catalog or %s<name>", term.name, QuerySpecPrefix),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Filter devuelve el filtro equivalente a la condición del plan
+func (p *SearchPlan) Filter() ProductFilter {
+	return ProductFilter{Condition: p.Condition}
+}
+
+// QuerySyntaxError representa un error de sintaxis en una consulta de búsqueda estructurada
+type QuerySyntaxError struct {
+	// Posición del error dentro de la consulta, en caracteres y comenzando en 1
+	Position int
+
+	// Descripción del error
+	Message string
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Position, e.Message)
+}
+
+// Palabras reservadas del lenguaje de consultas; solo se reconocen en mayúsculas
+const (
+	queryKeywordOr  = "OR"
+	queryKeywordAnd = "AND"
+	queryKeywordNot = "NOT"
+)
+
+// queryOperatorChars son los caracteres con los que comienza el operador de un término de campo
+const queryOperatorChars = ":<>=!~"
+
+// QuerySpecPrefix es el prefijo que indica que el campo de un término es una especificación
+// ("spec.RAM>=8"), aunque el catálogo no la tenga o coincida con un campo del producto
+const QuerySpecPrefix = "spec."
+
+// ParseSearchQuery interpreta una consulta de búsqueda estructurada, por ejemplo
+// `brand:Samsung category:smartphones price<1000 ram>=8 "S Pen"`.
+//
+// La consulta admite:
+//   - Palabras sueltas, que se buscan por relevancia igual que en la búsqueda por texto
+//   - Frases entre comillas, que deben aparecer completas ("S Pen")
+//   - Términos de campo <campo><op><valor> sobre id, name, description, brand, category,
+//     price, rating y available, o sobre especificaciones con el prefijo "spec."
+//     (spec.RAM>=8). Cualquier otro nombre se interpreta como especificación, y
+//     CheckSpecNames rechaza los que no son especificaciones del catálogo. Los operadores
+//     son los de los filtros de especificación (=, !=, >, >=, <, <=, ~) y ":", que
+//     equivale a "~" en name y description y a "=" en el resto. El campo y el valor
+//     pueden ir entre comillas ("Sistema Operativo"~Android, brand:"Google")
+//   - Negación con "-" o NOT (-brand:Apple, NOT "reacondicionado")
+//   - Alternativas con OR y agrupación con paréntesis ((brand:Samsung OR brand:Apple))
+//
+// Los términos separados por espacios (o por AND) deben cumplirse todos. Las palabras sueltas
+// del nivel superior forman el texto del plan; el resto de la consulta forma su condición.
+func ParseSearchQuery(query string) (*SearchPlan, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &queryParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := parser.peek(); next.kind != tokenEOF {
+		if next.kind == tokenRParen {
+			return nil, &QuerySyntaxError{Position: next.pos + 1, Message: "unbalanced ')'"}
+		}
+		return nil, &QuerySyntaxError{Position: next.pos + 1, Message: fmt.Sprintf("unexpected '%s'", next.text)}
+	}
+	if root == nil {
+		return &SearchPlan{}, nil
+	}
+
+	plan := compilePlan(root)
+	plan.specTerms = parser.specTerms
+	return plan, nil
+}
+
+// queryTokenKind identifica el tipo de un token de la consulta
+type queryTokenKind int
+
+const (
+	tokenEOF queryTokenKind = iota
+	tokenWord
+	tokenPhrase
+	tokenLParen
+	tokenRParen
+	tokenOr
+	tokenAnd
+	tokenNot
+	tokenMinus
+)
+
+// queryToken es un token de la consulta con su posición en caracteres (desde 0)
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+	end  int
+}
+
+// lexQuery divide la consulta en tokens
+func lexQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	var tokens []queryToken
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, text: "(", pos: i, end: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, text: ")", pos: i, end: i + 1})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, &QuerySyntaxError{Position: i + 1, Message: "unterminated quoted phrase"}
+			}
+			tokens = append(tokens, queryToken{kind: tokenPhrase, text: string(runes[i+1 : end]), pos: i, end: end + 1})
+			i = end + 1
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')' && runes[i+1] != '-':
+			tokens = append(tokens, queryToken{kind: tokenMinus, text: "-", pos: i, end: i + 1})
+			i++
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+
+			token := queryToken{kind: tokenWord, text: string(runes[i:end]), pos: i, end: end}
+			switch token.text {
+			case queryKeywordOr:
+				token.kind = tokenOr
+			case queryKeywordAnd:
+				token.kind = tokenAnd
+			case queryKeywordNot:
+				token.kind = tokenNot
+			}
+			tokens = append(tokens, token)
+			i = end
+		}
+	}
+
+	return append(tokens, queryToken{kind: tokenEOF, pos: len(runes), end: len(runes)}), nil
+}
+
+// queryNodeKind identifica el tipo de un nodo del árbol de la consulta
+type queryNodeKind int
+
+const (
+	nodeWord queryNodeKind = iota
+	nodePhrase
+	nodeField
+	nodeAnd
+	nodeOr
+	nodeNot
+)
+
+// queryNode es un nodo del árbol sintáctico de la consulta
+type queryNode struct {
+	kind      queryNodeKind
+	text      string
+	condition Condition
+	children  []*queryNode
+}
+
+// queryParser es un parser descendente recursivo sobre los tokens de la consulta:
+//
+//	or      := and (OR and)*
+//	and     := unary (AND? unary)*
+//	unary   := (NOT | -) unary | primary
+//	primary := ( or ) | field | phrase | word
+type queryParser struct {
+	tokens []queryToken
+	next   int
+
+	// Términos de campo interpretados como especificaciones sin el prefijo "spec."
+	specTerms []querySpecTerm
+}
+
+// peek devuelve el próximo token sin consumirlo
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+// advance consume y devuelve el próximo token
+func (p *queryParser) advance() queryToken {
+	token := p.tokens[p.next]
+	if token.kind != tokenEOF {
+		p.next++
+	}
+
+	return token
+}
+
+// parseOr interpreta una lista de alternativas separadas por OR
+func (p *queryParser) parseOr() (*queryNode, error) {
+	if next := p.peek(); next.kind == tokenOr {
+		return nil, &QuerySyntaxError{Position: next.pos + 1, Message: "expected a term before 'OR'"}
+	}
+
+	first, err := p.parseAnd()
+	if err != nil || first == nil {
+		return first, err
+	}
+
+	alternatives := []*queryNode{first}
+	for p.peek().kind == tokenOr {
+		operator := p.advance()
+
+		alternative, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if alternative == nil {
+			return nil, &QuerySyntaxError{Position: operator.pos + 1, Message: "expected a term after 'OR'"}
+		}
+		alternatives = append(alternatives, alternative)
+	}
+
+	if len(alternatives) == 1 {
+		return first, nil
+	}
+
+	return &queryNode{kind: nodeOr, children: alternatives}, nil
+}
+
+// parseAnd interpreta una secuencia de términos que deben cumplirse todos. Devuelve nil si
+// no hay ningún término antes del fin de la consulta, de un OR o de un paréntesis de cierre.
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	var terms []*queryNode
+
+	for {
+		next := p.peek()
+		switch next.kind {
+		case tokenEOF, tokenOr, tokenRParen:
+			switch len(terms) {
+			case 0:
+				return nil, nil
+			case 1:
+				return terms[0], nil
+			}
+			return &queryNode{kind: nodeAnd, children: terms}, nil
+		case tokenAnd:
+			p.advance()
+			if len(terms) == 0 {
+				return nil, &QuerySyntaxError{Position: next.pos + 1, Message: "expected a term before 'AND'"}
+			}
+			if after := p.peek().kind; after == tokenEOF || after == tokenOr || after == tokenRParen || after == tokenAnd {
+				return nil, &QuerySyntaxError{Position: next.pos + 1, Message: "expected a term after 'AND'"}
+			}
+			continue
+		}
+
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+}
+
+// parseUnary interpreta un término, opcionalmente negado
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	next := p.peek()
+	if next.kind != tokenNot && next.kind != tokenMinus {
+		return p.parsePrimary()
+	}
+
+	p.advance()
+	switch p.peek().kind {
+	case tokenEOF, tokenOr, tokenAnd, tokenRParen:
+		return nil, &QuerySyntaxError{Position: next.pos + 1, Message: fmt.Sprintf("expected a term after '%s'", next.text)}
+	}
+
+	negated, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &queryNode{kind: nodeNot, children: []*queryNode{negated}}, nil
+}
+
+// parsePrimary interpreta un grupo entre paréntesis, un término de campo, una frase o una palabra
+func (p *queryParser) parsePrimary() (*queryNode, error) {
+	token := p.advance()
+
+	switch token.kind {
+	case tokenLParen:
+		group, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			return nil, &QuerySyntaxError{Position: token.pos + 1, Message: "empty group"}
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, &QuerySyntaxError{Position: token.pos + 1, Message: "missing closing ')'"}
+		}
+		p.advance()
+		return group, nil
+	case tokenPhrase:
+		// Una frase seguida inmediatamente de un operador es el nombre de un campo
+		if next := p.peek(); next.kind == tokenWord && next.pos == token.end && strings.ContainsRune(queryOperatorChars, []rune(next.text)[0]) {
+			p.advance()
+			return p.parseField(token.text, token.pos, next.text, next.pos)
+		}
+		if strings.TrimSpace(token.text) == "" {
+			return nil, &QuerySyntaxError{Position: token.pos + 1, Message: "empty quoted phrase"}
+		}
+		return &queryNode{kind: nodePhrase, text: token.text}, nil
+	case tokenWord:
+		runes := []rune(token.text)
+		for i, r := range runes {
+			if !strings.ContainsRune(queryOperatorChars, r) {
+				continue
+			}
+			if _, ok := matchQueryOperator(string(runes[i:])); !ok {
+				// Un carácter como "!" que no forma un operador es parte de la palabra
+				break
+			}
+			if i == 0 {
+				return nil, &QuerySyntaxError{Position: token.pos + 1, Message: fmt.Sprintf("missing field name before '%s'", token.text)}
+			}
+			return p.parseField(string(runes[:i]), token.pos, string(runes[i:]), token.pos+i)
+		}
+		return &queryNode{kind: nodeWord, text: token.text}, nil
+	case tokenEOF:
+		return nil, &QuerySyntaxError{Position: token.pos + 1, Message: "unexpected end of query"}
+	}
+
+	return nil, &QuerySyntaxError{Position: token.pos + 1, Message: fmt.Sprintf("unexpected '%s'", token.text)}
+}
+
+// parseField interpreta un término de campo a partir del nombre del campo y del texto que
+// comienza con el operador; si el valor no sigue al operador, se toma de una frase contigua
+func (p *queryParser) parseField(name string, namePos int, rest string, operatorPos int) (*queryNode, error) {
+	operator, ok := matchQueryOperator(rest)
+	if !ok {
+		return nil, &QuerySyntaxError{Position: operatorPos + 1, Message: fmt.Sprintf("invalid operator in '%s'", rest)}
+	}
+
+	value := rest[len(operator):]
+	valueEnd := operatorPos + len([]rune(rest))
+	if value == "" {
+		if next := p.peek(); next.kind == tokenPhrase && next.pos == valueEnd {
+			p.advance()
+			value = next.text
+		}
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, &QuerySyntaxError{Position: valueEnd + 1, Message: fmt.Sprintf("missing value after '%s%s'", name, operator)}
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, &QuerySyntaxError{Position: namePos + 1, Message: "missing field name"}
+	}
+
+	condition, err := fieldTermCondition(name, operator, value)
+	if err != nil {
+		message := err.Error()
+		if validation, ok := err.(*ValidationError); ok {
+			message = validation.Message
+		}
+		return nil, &QuerySyntaxError{Position: namePos + 1, Message: message}
+	}
+	if predicate, ok := condition.(SpecPredicate); ok && !hasSpecPrefix(name) {
+		p.specTerms = append(p.specTerms, querySpecTerm{name: predicate.Name, position: namePos + 1})
+	}
+
+	return &queryNode{kind: nodeField, condition: condition}, nil
+}
+
+// matchQueryOperator reconoce el operador al comienzo del texto: ":" o un operador de filtro
+func matchQueryOperator(text string) (string, bool) {
+	if strings.HasPrefix(text, ":") {
+		return ":", true
+	}
+
+	for _, candidate := range filterOperators {
+		if strings.HasPrefix(text, string(candidate)) {
+			return string(candidate), true
+		}
+	}
+
+	return "", false
+}
+
+// hasSpecPrefix indica si el nombre de campo lleva el prefijo "spec."
+func hasSpecPrefix(name string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(name)), QuerySpecPrefix)
+}
+
+// fieldTermCondition crea la condición de un término de campo. Los campos del producto se
+// evalúan con FieldCondition y los nombres con el prefijo "spec.", o cualquier otro nombre,
+// como predicado de especificación.
+func fieldTermCondition(name, operator, value string) (Condition, error) {
+	field := strings.ToLower(strings.TrimSpace(name))
+	if hasSpecPrefix(name) {
+		name = strings.TrimSpace(name)[len(QuerySpecPrefix):]
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("missing specification name after '%s'", QuerySpecPrefix)
+		}
+		field = ""
+	}
+
+	if IsProductField(field) {
+		op := FilterOperator(operator)
+		if operator == ":" {
+			op = OpEqual
+			if field == FieldName || field == FieldDescription {
+				op = OpContains
+			}
+		}
+		return NewFieldCondition(field, op, value)
+	}
+
+	op := FilterOperator(operator)
+	if operator == ":" {
+		op = OpEqual
+	}
+
+	return SpecPredicate{Name: strings.TrimSpace(name), Operator: op, Value: strings.TrimSpace(value)}, nil
+}
+
+// compilePlan separa las palabras sueltas del nivel superior, que forman el texto del plan,
+// del resto de la consulta, que forma su condición. Las frases del nivel superior se exigen
+// como condición y además aportan su texto a la relevancia.
+func compilePlan(root *queryNode) *SearchPlan {
+	terms := []*queryNode{root}
+	if root.kind == nodeAnd {
+		terms = root.children
+	}
+
+	var words []string
+	var conditions []Condition
+	for _, term := range terms {
+		switch term.kind {
+		case nodeWord:
+			words = append(words, term.text)
+			continue
+		case nodePhrase:
+			words = append(words, term.text)
+		}
+		conditions = append(conditions, compileCondition(term))
+	}
+
+	plan := &SearchPlan{Text: strings.Join(words, " ")}
+	switch len(conditions) {
+	case 0:
+	case 1:
+		plan.Condition = conditions[0]
+	default:
+		plan.Condition = AndCondition{Conditions: conditions}
+	}
+
+	return plan
+}
+
+// compileCondition convierte un nodo del árbol de la consulta en una condición
+func compileCondition(node *queryNode) Condition {
+	switch node.kind {
+	case nodeWord, nodePhrase:
+		return TextCondition{Text: node.text}
+	case nodeField:
+		return node.condition
+	case nodeNot:
+		return NotCondition{Condition: compileCondition(node.children[0])}
+	}
+
+	conditions := make([]Condition, len(node.children))
+	for i, child := range node.children {
+		conditions[i] = compileCondition(child)
+	}
+
+	if node.kind == nodeOr {
+		return OrCondition{Conditions: conditions}
+	}
+
+	return AndCondition{Conditions: conditions}
+}
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProductScore representa la puntuación ponderada de un producto dentro de una comparación
+// @Description Weighted score of a product within a comparison
+type ProductScore struct {
+	// Posición en el ranking (1 es el mejor)
+	Rank int `json:"rank" example:"1"`
+
+	// ID del producto puntuado
+	ProductID string `json:"product_id" example:"PHONE001"`
+
+	// Puntuación total normalizada entre 0 y 1
+	Score float64 `json:"score" example:"0.82"`
+
+	// Aporte de cada criterio a la puntuación total
+	Contributions []CriterionContribution `json:"contributions"`
+}
+
+// CriterionContribution explica el aporte de un criterio a la puntuación de un producto
+// @Description Contribution of a single criterion to a product score
+type CriterionContribution struct {
+	// Nombre del criterio ("price", "rating" o nombre de especificación)
+	Criterion string `json:"criterion" example:"RAM"`
+
+	// Peso solicitado para el criterio
+	Weight float64 `json:"weight" example:"2"`
+
+	// Valor original del producto para el criterio
+	Value string `json:"value,omitempty" example:"12"`
+
+	// Valor normalizado entre 0 (peor) y 1 (mejor) dentro del conjunto comparado
+	Normalized float64 `json:"normalized" example:"1"`
+
+	// Aporte ponderado a la puntuación total
+	Contribution float64 `json:"contribution" example:"0.4"`
+
+	// Indica que el producto no tiene un valor utilizable para el criterio
+	Missing bool `json:"missing" example:"false"`
+}
+
+// ScoreProducts calcula una puntuación ponderada para cada producto y devuelve el ranking.
+// Cada criterio se normaliza entre el mínimo y el máximo del conjunto comparado, respetando
+// la dirección declarada en las reglas (por defecto, mayor es mejor). Los productos sin valor
+// para un criterio reciben 0 en ese criterio.
+func ScoreProducts(products []*Product, weights map[string]float64, rules *ComparisonRuleSet) ([]ProductScore, error) {
+	if len(weights) == 0 {
+		return nil, &ValidationError{Field: "weights", Message: "at least one weighted criterion is required"}
+	}
+
+	criteria := make([]string, 0, len(weights))
+	totalWeight := 0.0
+	for criterion, weight := range weights {
+		if weight < 0 {
+			return nil, &ValidationError{
+				Field:   "weights." + criterion,
+				Message: "weight must be a non-negative number",
+			}
+		}
+		criteria = append(criteria, criterion)
+		totalWeight += weight
+	}
+	sort.Strings(criteria)
+
+	if totalWeight == 0 {
+		return nil, &ValidationError{Field: "weights", Message: "at least one weight must be greater than zero"}
+	}
+
+	matrix := NewComparisonMatrix(products, rules)
+
+	scores := make([]ProductScore, len(products))
+	for i, product := range products {
+		scores[i] = ProductScore{
+			ProductID:     product.ID,
+			Contributions: make([]CriterionContribution, 0, len(criteria)),
+		}
+	}
+
+	for _, criterion := range criteria {
+		row, rule := matrix.criterionRow(criterion)
+		if row == nil {
+			return nil, &ValidationError{
+				Field:   "weights." + criterion,
+				Message: fmt.Sprintf("unknown criterion '%s'", criterion),
+			}
+		}
+		if row.RuleConflict != "" {
+			return nil, &ValidationError{Field: "weights." + criterion, Message: row.RuleConflict}
+		}
+
+		normalized, present, err := normalizeRow(row, rule)
+		if err != nil {
+			return nil, err
+		}
+
+		weight := weights[criterion]
+		for i, cell := range row.Cells {
+			contribution := CriterionContribution{
+				Criterion: row.Name,
+				Weight:    weight,
+				Value:     cell.Value,
+				Missing:   !present[i],
+			}
+			if present[i] {
+				contribution.Normalized = normalized[i]
+				contribution.Contribution = normalized[i] * weight / totalWeight
+			}
+
+			scores[i].Score += contribution.Contribution
+			scores[i].Contributions = append(scores[i].Contributions, contribution)
+		}
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	for i := range scores {
+		scores[i].Rank = i + 1
+	}
+
+	return scores, nil
+}
+
+// criterionRow busca la fila de un criterio en la matriz junto con la regla que le aplica.
+// Si no hay regla configurada se asume una comparación numérica donde mayor es mejor.
+func (m *ComparisonMatrix) criterionRow(criterion string) (*SpecComparisonRow, *ComparisonRule) {
+	find := func(rows []SpecComparisonRow) *SpecComparisonRow {
+		for i := range rows {
+			if specKey(rows[i].Name) == specKey(criterion) {
+				return &rows[i]
+			}
+		}
+		return nil
+	}
+
+	row := find(m.Fields)
+	if row == nil {
+		row = find(m.Rows)
+	}
+	if row == nil {
+		return nil, nil
+	}
+
+	rule := row.rule
+	if rule == nil {
+		rule = &ComparisonRule{Name: row.Name, Direction: HigherIsBetter, Type: NumericRule}
+	}
+
+	return row, rule
+}
+
+// normalizeRow lleva los valores de la fila al rango [0, 1] donde 1 es el mejor valor
+func normalizeRow(row *SpecComparisonRow, rule *ComparisonRule) ([]float64, []bool, error) {
+	ranks := make([]float64, len(row.Cells))
+	present := make([]bool, len(row.Cells))
+
+	first := true
+	var min, max float64
+	unit := ""
+
+	for i, cell := range row.Cells {
+		if cell.Missing {
+			continue
+		}
+
+		rank, ok := rule.rank(cell.typed, cell.Value)
+		if !ok {
+			continue
+		}
+
+		if rule.Type == NumericRule {
+			if !first && !strings.EqualFold(cell.typed.Unit, unit) {
+				return nil, nil, &ValidationError{
+					Field:   "weights." + row.Name,
+					Message: fmt.Sprintf("criterion '%s' uses incompatible units across products", row.Name),
+				}
+			}
+			unit = cell.typed.Unit
+		}
+
+		if first || rank < min {
+			min = rank
+		}
+		if first || rank > max {
+			max = rank
+		}
+		first = false
+
+		ranks[i] = rank
+		present[i] = true
+	}
+
+	if first {
+		return nil, nil, &ValidationError{
+			Field:   "weights." + row.Name,
+			Message: fmt.Sprintf("criterion '%s' has no comparable values", row.Name),
+		}
+	}
+
+	normalized := make([]float64, len(row.Cells))
+	for i := range ranks {
+		if !present[i] {
+			continue
+		}
+
+		// Si todos los valores son iguales, todos los productos obtienen el puntaje máximo
+		if max == min {
+			normalized[i] = 1
+			continue
+		}
+
+		normalized[i] = (ranks[i] - min) / (max - min)
+		if rule.Direction == LowerIsBetter {
+			normalized[i] = 1 - normalized[i]
+		}
+	}
+
+	return normalized, present, nil
+}
@@ -0,0 +1,116 @@
+package domain
+
+// Parámetros de las sugerencias de búsqueda
+const (
+	// MaxSearchSuggestions es la cantidad máxima de consultas corregidas que se proponen
+	MaxSearchSuggestions = 3
+
+	// ScarceResultsThreshold es la cantidad de resultados por debajo de la cual se proponen
+	// consultas corregidas
+	ScarceResultsThreshold = 3
+)
+
+// SearchRequest representa una búsqueda de productos por texto
+type SearchRequest struct {
+	// Texto de búsqueda tal como lo escribió el usuario
+	Query string `json:"query" example:"Samsung Galaxy"`
+
+	// Filtro que deben cumplir los resultados; el valor cero no excluye ninguno
+	Filter ProductFilter `json:"filter"`
+}
+
+// SearchHit representa un producto encontrado junto con su relevancia
+// @Description Product matched by a search with its relevance score
+type SearchHit struct {
+	*Product
+
+	// Puntuación de relevancia; mayor es más relevante
+	Score float64 `json:"score" example:"3.42"`
+
+	// Nombre y descripción con los términos coincidentes marcados con <em>, si se solicitaron
+	Highlights map[string]string `json:"highlights,omitempty"`
+
+	// Detalle de las coincidencias y de los componentes del puntaje, si se solicitó
+	Explanation *SearchExplanation `json:"explanation,omitempty"`
+}
+
+// Tipos de coincidencia de un término de la consulta
+const (
+	MatchExact   = "exact"
+	MatchPrefix  = "prefix"
+	MatchFuzzy   = "fuzzy"
+	MatchSynonym = "synonym"
+)
+
+// SearchExplanation detalla cómo se obtuvo la puntuación de un resultado. El puntaje es
+// la suma de los puntajes de las coincidencias.
+// @Description Score breakdown of a search hit
+type SearchExplanation struct {
+	// Puntuación de relevancia del resultado
+	Score float64 `json:"score" example:"3.42"`
+
+	// Coincidencias de los términos de la consulta por campo
+	Matches []TermMatch `json:"matches"`
+}
+
+// TermMatch representa la coincidencia de un término de la consulta en un campo del producto,
+// con los componentes de su puntaje BM25
+// @Description Match of a query term in a product field with its BM25 score components
+type TermMatch struct {
+	// Término de la consulta, normalizado
+	QueryTerm string `json:"query_term" example:"galaxi"`
+
+	// Término del producto que coincidió, normalizado
+	Term string `json:"term" example:"galaxy"`
+
+	// Campo en el que coincidió (name, brand, category o description)
+	Field string `json:"field" example:"name"`
+
+	// Tipo de coincidencia: exact, prefix, fuzzy o synonym
+	MatchType string `json:"match_type" example:"fuzzy"`
+
+	// Cantidad de apariciones del término en el campo
+	TermFrequency int `json:"term_frequency" example:"1"`
+
+	// Frecuencia inversa de documentos del término
+	IDF float64 `json:"idf" example:"1.6094"`
+
+	// Peso del campo
+	FieldBoost float64 `json:"field_boost" example:"3"`
+
+	// Peso del tipo de coincidencia (1 para coincidencias exactas)
+	Weight float64 `json:"weight" example:"0.4"`
+
+	// Aporte de la coincidencia a la puntuación
+	Score float64 `json:"score" example:"1.2034"`
+}
+
+// ProductSearcher define la búsqueda de productos ordenada por relevancia
+type ProductSearcher interface {
+	// SearchRanked devuelve los productos que coinciden con la búsqueda, de mayor a menor relevancia
+	SearchRanked(request SearchRequest) ([]SearchHit, error)
+}
+
+// SearchAnnotator define el detalle de las coincidencias de un producto con una búsqueda
+type SearchAnnotator interface {
+	// Highlight devuelve el nombre y la descripción del producto con los términos de la
+	// consulta marcados; los campos sin coincidencias se omiten
+	Highlight(query string, product *Product) (map[string]string, error)
+
+	// Explain devuelve las coincidencias del producto con la consulta y los componentes de
+	// su puntuación, o nil si el producto no coincide
+	Explain(query string, product *Product) (*SearchExplanation, error)
+}
+
+// SpecificationCatalog define la consulta de las especificaciones que declaran los productos
+type SpecificationCatalog interface {
+	// HasSpecification indica si algún producto declara la especificación, sin distinguir
+	// mayúsculas
+	HasSpecification(name string) (bool, error)
+}
+
+// QuerySuggester define la propuesta de consultas corregidas para búsquedas mal escritas
+type QuerySuggester interface {
+	// SuggestQueries devuelve hasta limit consultas corregidas que producen resultados
+	SuggestQueries(query string, limit int) ([]string, error)
+}
@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpecAttribute declara una especificación esperada en los productos de una categoría
+type SpecAttribute struct {
+	// Nombre de la especificación ("RAM")
+	Name string `json:"name"`
+
+	// Tipo de dato del valor: number, boolean o text
+	Type SpecValueKind `json:"type"`
+
+	// Indica si todos los productos de la categoría deben informarla
+	Required bool `json:"required,omitempty"`
+
+	// Unidades aceptadas, solo para especificaciones numéricas; vacío acepta cualquiera
+	Units []string `json:"units,omitempty"`
+}
+
+// CategorySpecSchema declara las especificaciones de los productos de una categoría
+type CategorySpecSchema struct {
+	// Categoría, por ID o por nombre. Con una taxonomía puede ser cualquier nivel del árbol:
+	// el esquema aplica a los productos de todas sus subcategorías.
+	Category string `json:"category"`
+
+	// Especificaciones de la categoría
+	Specifications []SpecAttribute `json:"specifications"`
+}
+
+// SpecSchemaSet agrupa los esquemas de especificaciones por categoría
+type SpecSchemaSet struct {
+	Schemas []CategorySpecSchema `json:"schemas"`
+}
+
+// SpecCompleteness resume qué especificaciones de su esquema informa un producto
+// @Description Share of the category schema specifications a product provides
+type SpecCompleteness struct {
+	// Proporción de las especificaciones del esquema presentes en el producto (0 a 1)
+	Score float64 `json:"score" example:"0.7778"`
+
+	// Cantidad de especificaciones del esquema presentes en el producto
+	Present int `json:"present" example:"7"`
+
+	// Cantidad de especificaciones declaradas en el esquema
+	Total int `json:"total" example:"9"`
+
+	// Especificaciones del esquema que el producto no informa
+	Missing []string `json:"missing,omitempty" example:"Peso,Carga Inalámbrica"`
+}
+
+// Validate verifica que cada esquema tenga categoría, que ninguna categoría tenga dos esquemas
+// y que sus especificaciones tengan nombre único, un tipo válido y unidades conocidas
+func (s *SpecSchemaSet) Validate() error {
+	var errs ValidationErrors
+
+	categories := make(map[string]bool, len(s.Schemas))
+	for i, schema := range s.Schemas {
+		path := fmt.Sprintf("schemas[%d]", i)
+
+		category := FoldText(strings.TrimSpace(schema.Category))
+		switch {
+		case category == "":
+			errs = append(errs, &ValidationError{Field: path + ".category", Message: "is required"})
+		case categories[category]:
+			errs = append(errs, &ValidationError{Field: path + ".category", Message: fmt.Sprintf("category '%s' already has a schema", schema.Category)})
+		}
+		categories[category] = true
+
+		names := make(map[string]bool, len(schema.Specifications))
+		for j, attribute := range schema.Specifications {
+			specPath := fmt.Sprintf("%s.specifications[%d]", path, j)
+
+			name := specKey(attribute.Name)
+			switch {
+			case name == "":
+				errs = append(errs, &ValidationError{Field: specPath + ".name", Message: "is required"})
+			case names[name]:
+				errs = append(errs, &ValidationError{Field: specPath + ".name", Message: fmt.Sprintf("specification '%s' is declared twice", attribute.Name)})
+			}
+			names[name] = true
+
+			switch attribute.Type {
+			case SpecKindNumber, SpecKindBoolean, SpecKindText:
+			default:
+				errs = append(errs, &ValidationError{Field: specPath + ".type", Message: fmt.Sprintf("invalid type '%s', use number, boolean or text", attribute.Type)})
+			}
+
+			if len(attribute.Units) > 0 && attribute.Type != SpecKindNumber {
+				errs = append(errs, &ValidationError{Field: specPath + ".units", Message: "units are only allowed for number specifications"})
+			}
+			for k, unit := range attribute.Units {
+				if _, ok := LookupUnit(unit); !ok {
+					errs = append(errs, &ValidationError{Field: fmt.Sprintf("%s.units[%d]", specPath, k), Message: fmt.Sprintf("unknown unit '%s'", unit)})
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// AttributesFor devuelve las especificaciones que el esquema exige al producto. Se combinan
+// los esquemas de su categoría y de sus categorías ancestro; si dos esquemas declaran la misma
+// especificación prevalece el de la categoría más específica. Devuelve nil si ningún esquema
+// aplica al producto.
+func (s *SpecSchemaSet) AttributesFor(product *Product) []SpecAttribute {
+	if s == nil {
+		return nil
+	}
+
+	// Profundidad de cada categoría del producto: 0 es la raíz
+	depth := make(map[string]int, 2*len(product.Breadcrumbs)+1)
+	depth[FoldText(strings.TrimSpace(product.Category))] = len(product.Breadcrumbs)
+	for i, ref := range product.Breadcrumbs {
+		depth[FoldText(ref.ID)] = i
+		depth[FoldText(ref.Name)] = i
+	}
+
+	type declared struct {
+		attribute SpecAttribute
+		depth     int
+	}
+
+	var order []string
+	byName := make(map[string]declared)
+	for _, schema := range s.Schemas {
+		level, applies := depth[FoldText(strings.TrimSpace(schema.Category))]
+		if !applies {
+			continue
+		}
+
+		for _, attribute := range schema.Specifications {
+			name := specKey(attribute.Name)
+			previous, exists := byName[name]
+			if !exists {
+				order = append(order, name)
+			}
+			if !exists || level > previous.depth {
+				byName[name] = declared{attribute: attribute, depth: level}
+			}
+		}
+	}
+
+	if len(order) == 0 {
+		return nil
+	}
+
+	attributes := make([]SpecAttribute, len(order))
+	for i, name := range order {
+		attributes[i] = byName[name].attribute
+	}
+
+	return attributes
+}
+
+// ValidateSpecifications verifica las especificaciones del producto contra los atributos de
+// su esquema: las obligatorias deben estar presentes y todas las presentes deben tener el tipo
+// y una de las unidades declaradas. Los errores se informan con el prefijo indicado
+// ("products[3]").
+func ValidateSpecifications(product *Product, attributes []SpecAttribute, prefix string) ValidationErrors {
+	var errs ValidationErrors
+
+	for _, attribute := range attributes {
+		field := fmt.Sprintf("%s.specifications.%s", prefix, attribute.Name)
+
+		spec := findSpecification(product.Specifications, attribute.Name)
+		if spec == nil {
+			if attribute.Required {
+				errs = append(errs, &ValidationError{Field: field, Message: fmt.Sprintf("product %s is missing required specification '%s'", product.ID, attribute.Name)})
+			}
+			continue
+		}
+
+		if !attribute.accepts(spec.Typed) {
+			errs = append(errs, &ValidationError{Field: field, Message: fmt.Sprintf("product %s has a %s value '%s', want %s", product.ID, spec.Typed.Kind, spec.Value, attribute.Type)})
+			continue
+		}
+
+		if len(attribute.Units) > 0 && !attribute.allowsUnit(spec.Unit) {
+			errs = append(errs, &ValidationError{Field: field, Message: fmt.Sprintf("product %s uses unit '%s', want one of %s", product.ID, spec.Unit, strings.Join(attribute.Units, ", "))})
+		}
+	}
+
+	return errs
+}
+
+// Completeness calcula qué proporción de las especificaciones del esquema informa el producto
+func Completeness(product *Product, attributes []SpecAttribute) *SpecCompleteness {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	completeness := &SpecCompleteness{Total: len(attributes)}
+	for _, attribute := range attributes {
+		if findSpecification(product.Specifications, attribute.Name) != nil {
+			completeness.Present++
+		} else {
+			completeness.Missing = append(completeness.Missing, attribute.Name)
+		}
+	}
+	completeness.Score = roundRatio(float64(completeness.Present) / float64(completeness.Total))
+
+	return completeness
+}
+
+// CompletenessScore devuelve el puntaje de completitud del producto; los productos sin
+// esquema se consideran completos
+func (p *Product) CompletenessScore() float64 {
+	if p.Completeness == nil {
+		return 1
+	}
+
+	return p.Completeness.Score
+}
+
+// accepts indica si el valor interpretado tiene el tipo del atributo. Un atributo de texto
+// acepta cualquier valor, ya que números y booleanos también pueden describirse como texto.
+func (a SpecAttribute) accepts(value SpecValue) bool {
+	return a.Type == SpecKindText || value.Kind == a.Type
+}
+
+// allowsUnit indica si la unidad informada, o la primera palabra de ella ("GB" en "GB SSD"),
+// es una de las unidades aceptadas por el atributo
+func (a SpecAttribute) allowsUnit(unit string) bool {
+	def, ok := LookupUnit(unit)
+	if !ok {
+		fields := strings.Fields(unit)
+		if len(fields) == 0 {
+			return false
+		}
+		if def, ok = LookupUnit(fields[0]); !ok {
+			return false
+		}
+	}
+
+	for _, allowed := range a.Units {
+		if candidate, ok := LookupUnit(allowed); ok && candidate.Symbol == def.Symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findSpecification busca una especificación por nombre, sin distinguir mayúsculas
+func findSpecification(specs []Specification, name string) *Specification {
+	for i := range specs {
+		if specKey(specs[i].Name) == specKey(name) {
+			return &specs[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SpecValueKind indica el tipo de dato de una especificación interpretada
+type SpecValueKind string
+
+const (
+	// SpecKindNumber representa un valor numérico, opcionalmente con unidad
+	SpecKindNumber SpecValueKind = "number"
+
+	// SpecKindBoolean representa un valor sí/no
+	SpecKindBoolean SpecValueKind = "boolean"
+
+	// SpecKindText representa un valor de texto libre
+	SpecKindText SpecValueKind = "text"
+)
+
+// SpecValue representa el valor tipado de una especificación
+// @Description Typed specification value
+type SpecValue struct {
+	// Tipo de dato del valor
+	Kind SpecValueKind `json:"kind" example:"number"`
+
+	// Valor numérico expresado en la unidad canónica
+	Number float64 `json:"number,omitempty" example:"6.8"`
+
+	// Unidad canónica del valor numérico
+	Unit string `json:"unit,omitempty" example:"in"`
+
+	// Texto adicional que acompaña a la unidad ("SSD" en "GB SSD")
+	Qualifier string `json:"qualifier,omitempty" example:"SSD"`
+
+	// Valor booleano
+	Boolean *bool `json:"boolean,omitempty"`
+
+	// Valor de texto
+	Text string `json:"text,omitempty" example:"Snapdragon 8 Gen 3"`
+}
+
+// IsNumber indica si el valor es numérico
+func (v SpecValue) IsNumber() bool {
+	return v.Kind == SpecKindNumber
+}
+
+// Dimension devuelve la dimensión de la unidad del valor, o vacío si no es una unidad conocida
+func (v SpecValue) Dimension() Dimension {
+	if unit, ok := LookupUnit(v.Unit); ok {
+		return unit.Dimension
+	}
+
+	return ""
+}
+
+// In convierte el valor numérico a la unidad indicada
+func (v SpecValue) In(unit string) (float64, error) {
+	if v.Unit == "" || strings.EqualFold(v.Unit, unit) {
+		return v.Number, nil
+	}
+
+	return ConvertUnit(v.Number, v.Unit, unit)
+}
+
+// Equal indica si dos valores tipados son equivalentes (convirtiendo unidades si aplica)
+func (v SpecValue) Equal(other SpecValue) bool {
+	if v.Kind != other.Kind {
+		return false
+	}
+
+	switch v.Kind {
+	case SpecKindNumber:
+		return v.Number == other.Number &&
+			strings.EqualFold(v.Unit, other.Unit) &&
+			strings.EqualFold(v.Qualifier, other.Qualifier)
+	case SpecKindBoolean:
+		return v.Boolean != nil && other.Boolean != nil && *v.Boolean == *other.Boolean
+	default:
+		return strings.EqualFold(v.Text, other.Text)
+	}
+}
+
+// ParseSpecifications interpreta el valor tipado de todas las especificaciones del producto
+func (p *Product) ParseSpecifications() {
+	for i := range p.Specifications {
+		spec := &p.Specifications[i]
+		spec.Typed = ParseSpecValue(spec.Value, spec.Unit)
+	}
+}
+
+// ParseSpecValue interpreta el valor y la unidad de una especificación. Los números se
+// convierten a la unidad canónica de su dimensión cuando la unidad es conocida; las
+// unidades desconocidas se conservan tal cual. Si la unidad no viene informada se intenta
+// extraerla del propio valor ("6.8 inches").
+func ParseSpecValue(value, unit string) SpecValue {
+	value = strings.TrimSpace(value)
+	unit = strings.TrimSpace(unit)
+
+	number, rest, ok := splitLeadingNumber(value)
+	if !ok {
+		if b, isBool := parseBool(value); isBool && unit == "" {
+			return SpecValue{Kind: SpecKindBoolean, Boolean: &b}
+		}
+		return SpecValue{Kind: SpecKindText, Text: value}
+	}
+
+	switch {
+	case rest == "":
+		return numberWithUnit(number, unit, true)
+	case unit == "":
+		// La unidad embebida en el valor debe ser exactamente una unidad conocida
+		if _, known := LookupUnit(rest); known {
+			return numberWithUnit(number, rest, false)
+		}
+	}
+
+	return SpecValue{Kind: SpecKindText, Text: value}
+}
+
+// numberWithUnit construye un valor numérico normalizando la unidad. Si allowQualifier es
+// verdadero, el texto que sigue a la primera palabra de la unidad se conserva como calificador.
+func numberWithUnit(number float64, unit string, allowQualifier bool) SpecValue {
+	result := SpecValue{Kind: SpecKindNumber, Number: number, Unit: unit}
+	if unit == "" {
+		return result
+	}
+
+	symbol, qualifier := unit, ""
+	def, ok := LookupUnit(symbol)
+	if !ok && allowQualifier {
+		if fields := strings.Fields(unit); len(fields) > 1 {
+			symbol = fields[0]
+			qualifier = strings.TrimSpace(strings.TrimPrefix(unit, symbol))
+			def, ok = LookupUnit(symbol)
+		}
+	}
+
+	if !ok {
+		return result
+	}
+
+	canonical := CanonicalUnit(def.Dimension)
+	result.Number = number * def.Factor / canonical.Factor
+	result.Unit = canonical.Symbol
+	result.Qualifier = qualifier
+
+	return result
+}
+
+// splitLeadingNumber separa el número inicial de un texto y devuelve el resto. Acepta coma
+// decimal ("6,8") y comas de miles ("1,000"; ver normalizeCommas).
+func splitLeadingNumber(value string) (float64, string, bool) {
+	end := 0
+	for end < len(value) {
+		c := value[end]
+		if (c >= '0' && c <= '9') || c == '.' || c == ',' || ((c == '-' || c == '+') && end == 0) {
+			end++
+			continue
+		}
+		break
+	}
+
+	if end == 0 {
+		return 0, "", false
+	}
+
+	literal, ok := normalizeCommas(value[:end])
+	if !ok {
+		return 0, "", false
+	}
+
+	number, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return number, strings.TrimSpace(value[end:]), true
+}
+
+// normalizeCommas lleva las comas de un número al formato de strconv. Una única coma es
+// decimal si la siguen uno o dos dígitos y el número no tiene punto ("6,8"); si no, las
+// comas deben separar grupos de tres dígitos antes del punto decimal ("1,000" o
+// "1,000.5") y se descartan. Cualquier otro uso de la coma no es un número.
+func normalizeCommas(literal string) (string, bool) {
+	if !strings.Contains(literal, ",") {
+		return literal, true
+	}
+
+	groups := strings.Split(literal, ",")
+	if len(groups) == 2 && !strings.Contains(literal, ".") && len(groups[1]) >= 1 && len(groups[1]) <= 2 {
+		return groups[0] + "." + groups[1], true
+	}
+
+	for i, group := range groups[1:] {
+		digits := group
+		if i == len(groups)-2 {
+			digits, _, _ = strings.Cut(group, ".")
+		}
+		if len(digits) != 3 || strings.ContainsAny(digits, ".+-") || strings.Contains(groups[0], ".") {
+			return "", false
+		}
+	}
+
+	return strings.Join(groups, ""), true
+}
@@ -0,0 +1,46 @@
+package domain
+
+// Límites del autocompletado
+const (
+	// DefaultSuggestionLimit es la cantidad de sugerencias devueltas por defecto
+	DefaultSuggestionLimit = 5
+
+	// MaxSuggestionLimit es la cantidad máxima de sugerencias que se pueden solicitar
+	MaxSuggestionLimit = 10
+)
+
+// SuggestionType indica a qué tipo de entidad corresponde una sugerencia
+type SuggestionType string
+
+// Tipos de entidad sugeridos por el autocompletado
+const (
+	SuggestionCategory SuggestionType = "category"
+	SuggestionBrand    SuggestionType = "brand"
+	SuggestionProduct  SuggestionType = "product"
+)
+
+// Suggestion representa una sugerencia de autocompletado
+// @Description Autocomplete suggestion for a partially typed query
+type Suggestion struct {
+	// Texto sugerido tal como aparece en el catálogo
+	Text string `json:"text" example:"Samsung Galaxy S24 Ultra"`
+
+	// Tipo de entidad sugerida (category, brand o product)
+	Type SuggestionType `json:"type" example:"product"`
+
+	// ID del producto, solo para sugerencias de tipo product
+	ProductID string `json:"product_id,omitempty" example:"PHONE001"`
+
+	// Cantidad de productos de la marca o categoría
+	ProductCount int `json:"product_count,omitempty" example:"3"`
+
+	// Puntuación de popularidad utilizada para ordenar las sugerencias
+	Score float64 `json:"score" example:"4.8"`
+}
+
+// ProductAutocompleter define el autocompletado de búsquedas a partir de un prefijo
+type ProductAutocompleter interface {
+	// Autocomplete devuelve hasta limit sugerencias cuyo texto tiene una palabra que comienza
+	// con el prefijo, de más a menos popular
+	Autocomplete(prefix string, limit int) ([]Suggestion, error)
+}
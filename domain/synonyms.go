@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SynonymRule declara un grupo de sinónimos para la búsqueda. Puede ser bidireccional
+// (Equivalent: todos los términos son intercambiables) o en un solo sentido (From se expande
+// a To, pero no al revés). Cada término puede ser una palabra o una frase ("tarjeta gráfica").
+type SynonymRule struct {
+	// Términos equivalentes entre sí
+	Equivalent []string `json:"equivalent,omitempty"`
+
+	// Términos que, al buscarse, también encuentran los términos de To
+	From []string `json:"from,omitempty"`
+
+	// Términos en los que se expanden los términos de From
+	To []string `json:"to,omitempty"`
+}
+
+// SynonymSet agrupa los sinónimos aplicados al expandir las búsquedas
+type SynonymSet struct {
+	Rules []SynonymRule `json:"rules"`
+}
+
+// Validate verifica que todas las reglas del conjunto estén bien formadas
+func (s *SynonymSet) Validate() error {
+	for i, rule := range s.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+
+		if len(rule.Equivalent) > 0 && (len(rule.From) > 0 || len(rule.To) > 0) {
+			return &ValidationError{Field: field, Message: "a rule must use either 'equivalent' or 'from'/'to', not both"}
+		}
+
+		switch {
+		case len(rule.Equivalent) > 0:
+			if len(rule.Equivalent) < 2 {
+				return &ValidationError{Field: field, Message: "'equivalent' must list at least 2 terms"}
+			}
+		case len(rule.From) == 0 || len(rule.To) == 0:
+			return &ValidationError{Field: field, Message: "a rule must define 'equivalent' or both 'from' and 'to'"}
+		}
+
+		for _, term := range append(append(append([]string(nil), rule.Equivalent...), rule.From...), rule.To...) {
+			if strings.TrimSpace(term) == "" {
+				return &ValidationError{Field: field, Message: "terms must not be blank"}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CategoryNode es un nodo de la taxonomía de categorías. Los nodos sin hijos son hojas:
+// son las únicas categorías a las que se pueden asignar productos.
+type CategoryNode struct {
+	// Identificador estable de la categoría
+	ID string `json:"id"`
+
+	// Nombre visible de la categoría
+	Name string `json:"name"`
+
+	// Subcategorías
+	Children []CategoryNode `json:"children,omitempty"`
+}
+
+// Taxonomy define el árbol de categorías del catálogo
+type Taxonomy struct {
+	Categories []CategoryNode `json:"categories"`
+}
+
+// CategoryRef identifica una categoría dentro de las migas de pan de un producto
+// @Description Category in a product breadcrumb, from the root to the product category
+type CategoryRef struct {
+	// Identificador de la categoría
+	ID string `json:"id" example:"telefonia"`
+
+	// Nombre de la categoría
+	Name string `json:"name" example:"Telefonía"`
+}
+
+// CategoryPath es el camino desde la raíz de la taxonomía hasta una categoría
+type CategoryPath struct {
+	// Categorías desde la raíz hasta la categoría, inclusive
+	Path []CategoryRef
+
+	// Indica si la categoría es una hoja
+	Leaf bool
+}
+
+// CategoryTreeNode es un nodo del árbol de categorías con la cantidad de productos de su subárbol
+// @Description Category tree node with the number of products in its subtree
+type CategoryTreeNode struct {
+	// Identificador de la categoría
+	ID string `json:"id" example:"smartphones"`
+
+	// Nombre de la categoría
+	Name string `json:"name" example:"Smartphones"`
+
+	// Cantidad de productos de la categoría y de todas sus subcategorías
+	ProductCount int `json:"product_count" example:"3"`
+
+	// Subcategorías
+	Children []CategoryTreeNode `json:"children,omitempty"`
+}
+
+// Validate verifica que todos los nodos tengan ID y nombre, y que ningún ID ni nombre se
+// repita en el árbol (sin distinguir mayúsculas ni acentos), ya que los productos y los
+// filtros se refieren a las categorías por cualquiera de los dos
+func (t *Taxonomy) Validate() error {
+	var errs ValidationErrors
+	if len(t.Categories) == 0 {
+		errs = append(errs, &ValidationError{Field: "categories", Message: "must contain at least one category"})
+	}
+
+	seen := make(map[string]string)
+	var walk func(nodes []CategoryNode, path string)
+	walk = func(nodes []CategoryNode, path string) {
+		for i, node := range nodes {
+			nodePath := fmt.Sprintf("%s[%d]", path, i)
+
+			for _, key := range []struct{ field, value string }{{"id", node.ID}, {"name", node.Name}} {
+				folded := FoldText(strings.TrimSpace(key.value))
+				if folded == "" {
+					errs = append(errs, &ValidationError{Field: nodePath + "." + key.field, Message: "is required"})
+					continue
+				}
+
+				// Un nodo puede usar el mismo texto como ID y como nombre
+				if previous, exists := seen[folded]; exists && previous != nodePath {
+					errs = append(errs, &ValidationError{Field: nodePath + "." + key.field, Message: fmt.Sprintf("'%s' is already used by %s", key.value, previous)})
+					continue
+				}
+				seen[folded] = nodePath
+			}
+
+			walk(node.Children, nodePath+".children")
+		}
+	}
+	walk(t.Categories, "categories")
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Paths devuelve el camino de cada categoría de la taxonomía indexado por su ID y por su
+// nombre normalizados con FoldText
+func (t *Taxonomy) Paths() map[string]CategoryPath {
+	paths := make(map[string]CategoryPath)
+
+	var walk func(nodes []CategoryNode, parent []CategoryRef)
+	walk = func(nodes []CategoryNode, parent []CategoryRef) {
+		for _, node := range nodes {
+			path := append(append([]CategoryRef(nil), parent...), CategoryRef{ID: node.ID, Name: node.Name})
+			entry := CategoryPath{Path: path, Leaf: len(node.Children) == 0}
+
+			paths[FoldText(strings.TrimSpace(node.ID))] = entry
+			paths[FoldText(strings.TrimSpace(node.Name))] = entry
+
+			walk(node.Children, path)
+		}
+	}
+	walk(t.Categories, nil)
+
+	return paths
+}
+
+// CategorySlug genera el ID de una categoría a partir de su nombre ("Audífonos" -> "audifonos"),
+// para las categorías que no provienen de una taxonomía
+func CategorySlug(name string) string {
+	return strings.Join(strings.Fields(FoldText(name)), "-")
+}
+
+// InCategory indica si el producto pertenece a la categoría o a alguna de sus subcategorías.
+// La categoría puede indicarse por nombre o por ID, sin distinguir mayúsculas ni acentos.
+func (p *Product) InCategory(category string) bool {
+	category = FoldText(strings.TrimSpace(category))
+
+	if FoldText(strings.TrimSpace(p.Category)) == category {
+		return true
+	}
+	for _, ref := range p.Breadcrumbs {
+		if FoldText(ref.ID) == category || FoldText(ref.Name) == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subtree devuelve la categoría indicada, por nombre o por ID sin distinguir mayúsculas ni
+// acentos, seguida de todas sus subcategorías. Devuelve nil si la categoría no pertenece a
+// la taxonomía.
+func (t *Taxonomy) Subtree(category string) []CategoryRef {
+	key := FoldText(strings.TrimSpace(category))
+
+	var subtree []CategoryRef
+	var collect func(nodes []CategoryNode)
+	collect = func(nodes []CategoryNode) {
+		for _, node := range nodes {
+			subtree = append(subtree, CategoryRef{ID: node.ID, Name: node.Name})
+			collect(node.Children)
+		}
+	}
+
+	var find func(nodes []CategoryNode) bool
+	find = func(nodes []CategoryNode) bool {
+		for _, node := range nodes {
+			if FoldText(strings.TrimSpace(node.ID)) == key || FoldText(strings.TrimSpace(node.Name)) == key {
+				collect([]CategoryNode{node})
+				return true
+			}
+			if find(node.Children) {
+				return true
+			}
+		}
+		return false
+	}
+	find(t.Categories)
+
+	return subtree
+}
+
+// CategoryTree construye el árbol de la taxonomía con la cantidad de productos de cada
+// subárbol, indicada en counts por el ID de la categoría normalizado con FoldText
+func (t *Taxonomy) CategoryTree(counts map[string]int) []CategoryTreeNode {
+	var build func(nodes []CategoryNode) []CategoryTreeNode
+	build = func(nodes []CategoryNode) []CategoryTreeNode {
+		if len(nodes) == 0 {
+			return nil
+		}
+
+		tree := make([]CategoryTreeNode, len(nodes))
+		for i, node := range nodes {
+			tree[i] = CategoryTreeNode{
+				ID:           node.ID,
+				Name:         node.Name,
+				ProductCount: counts[FoldText(strings.TrimSpace(node.ID))],
+				Children:     build(node.Children),
+			}
+		}
+		return tree
+	}
+
+	return build(t.Categories)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "strings"
+
+// accentFolding mapea caracteres acentuados a su forma sin acento
+var accentFolding = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
+
+// FoldText convierte el texto a minúsculas y elimina los acentos, para comparar texto en
+// español e inglés sin depender de cómo fue escrito ("Audífonos" y "audifonos")
+func FoldText(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for _, r := range strings.ToLower(text) {
+		if folded, ok := accentFolding[r]; ok {
+			r = folded
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
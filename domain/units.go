@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Dimension representa la magnitud física que mide una unidad
+type Dimension string
+
+// Dimensiones soportadas por el registro de unidades
+const (
+	DimensionDataSize   Dimension = "data_size"
+	DimensionLength     Dimension = "length"
+	DimensionMass       Dimension = "mass"
+	DimensionCharge     Dimension = "electric_charge"
+	DimensionEnergy     Dimension = "energy"
+	DimensionTime       Dimension = "time"
+	DimensionResolution Dimension = "resolution"
+	DimensionFrequency  Dimension = "frequency"
+	DimensionPower      Dimension = "power"
+)
+
+// UnitDefinition describe una unidad conocida y su conversión a la unidad canónica de su dimensión
+type UnitDefinition struct {
+	// Símbolo de la unidad ("GB", "in", "mAh")
+	Symbol string
+
+	// Magnitud que mide la unidad
+	Dimension Dimension
+
+	// Factor para convertir un valor en esta unidad a la unidad canónica
+	Factor float64
+
+	// Nombres alternativos aceptados al interpretar datos ("pulgadas", "inches")
+	Aliases []string
+}
+
+// unitRegistry contiene las unidades conocidas. La unidad canónica de cada dimensión
+// es la que tiene factor 1.
+var unitRegistry = []UnitDefinition{
+	{Symbol: "KB", Dimension: DimensionDataSize, Factor: 1.0 / (1024 * 1024), Aliases: []string{"kilobyte", "kilobytes"}},
+	{Symbol: "MB", Dimension: DimensionDataSize, Factor: 1.0 / 1024, Aliases: []string{"megabyte", "megabytes"}},
+	{Symbol: "GB", Dimension: DimensionDataSize, Factor: 1, Aliases: []string{"gigabyte", "gigabytes"}},
+	{Symbol: "TB", Dimension: DimensionDataSize, Factor: 1024, Aliases: []string{"terabyte", "terabytes"}},
+
+	{Symbol: "mm", Dimension: DimensionLength, Factor: 1 / 25.4, Aliases: []string{"milímetros", "milimetros", "millimeters"}},
+	{Symbol: "cm", Dimension: DimensionLength, Factor: 1 / 2.54, Aliases: []string{"centímetros", "centimetros", "centimeters"}},
+	{Symbol: "in", Dimension: DimensionLength, Factor: 1, Aliases: []string{"inch", "inches", "pulgada", "pulgadas", "\""}},
+
+	{Symbol: "g", Dimension: DimensionMass, Factor: 1, Aliases: []string{"gr", "gramos", "grams"}},
+	{Symbol: "kg", Dimension: DimensionMass, Factor: 1000, Aliases: []string{"kilogramos", "kilograms"}},
+	{Symbol: "lb", Dimension: DimensionMass, Factor: 453.59237, Aliases: []string{"lbs", "libras", "pounds"}},
+
+	{Symbol: "mAh", Dimension: DimensionCharge, Factor: 1},
+	{Symbol: "Ah", Dimension: DimensionCharge, Factor: 1000},
+
+	{Symbol: "Wh", Dimension: DimensionEnergy, Factor: 1},
+	{Symbol: "kWh", Dimension: DimensionEnergy, Factor: 1000},
+
+	{Symbol: "s", Dimension: DimensionTime, Factor: 1.0 / 3600, Aliases: []string{"seg", "segundos", "seconds"}},
+	{Symbol: "min", Dimension: DimensionTime, Factor: 1.0 / 60, Aliases: []string{"minutos", "minutes"}},
+	{Symbol: "h", Dimension: DimensionTime, Factor: 1, Aliases: []string{"hr", "hrs", "hora", "horas", "hour", "hours"}},
+
+	{Symbol: "MP", Dimension: DimensionResolution, Factor: 1, Aliases: []string{"megapíxeles", "megapixeles", "megapixels"}},
+
+	{Symbol: "Hz", Dimension: DimensionFrequency, Factor: 1, Aliases: []string{"hertz"}},
+	{Symbol: "kHz", Dimension: DimensionFrequency, Factor: 1e3},
+	{Symbol: "MHz", Dimension: DimensionFrequency, Factor: 1e6},
+	{Symbol: "GHz", Dimension: DimensionFrequency, Factor: 1e9},
+
+	{Symbol: "W", Dimension: DimensionPower, Factor: 1, Aliases: []string{"watts", "vatios"}},
+	{Symbol: "kW", Dimension: DimensionPower, Factor: 1e3},
+}
+
+// unitIndex indexa las unidades por símbolo y alias normalizados
+var unitIndex = buildUnitIndex()
+
+// buildUnitIndex construye el índice de búsqueda de unidades
+func buildUnitIndex() map[string]*UnitDefinition {
+	index := make(map[string]*UnitDefinition)
+
+	for i := range unitRegistry {
+		unit := &unitRegistry[i]
+		index[unit.Symbol] = unit
+		for _, alias := range unit.Aliases {
+			index[strings.ToLower(alias)] = unit
+		}
+	}
+
+	// Los símbolos en minúscula solo se aceptan si no son ambiguos (ej: "mb" y "MB") y tienen
+	// más de una letra: "W" no se acepta como "w"
+	for i := range unitRegistry {
+		unit := &unitRegistry[i]
+		lower := strings.ToLower(unit.Symbol)
+		if _, exists := index[lower]; !exists && utf8.RuneCountInString(lower) > 1 {
+			index[lower] = unit
+		}
+	}
+
+	return index
+}
+
+// LookupUnit busca una unidad por símbolo o alias. Los símbolos se comparan de forma
+// exacta primero y luego sin distinguir mayúsculas, salvo los de una sola letra: "G" no es
+// un gramo, como en "5G".
+func LookupUnit(name string) (*UnitDefinition, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, false
+	}
+
+	if unit, ok := unitIndex[name]; ok {
+		return unit, true
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		return nil, false
+	}
+
+	unit, ok := unitIndex[strings.ToLower(name)]
+	return unit, ok
+}
+
+// CanonicalUnit devuelve la unidad canónica de una dimensión
+func CanonicalUnit(dimension Dimension) *UnitDefinition {
+	for i := range unitRegistry {
+		if unitRegistry[i].Dimension == dimension && unitRegistry[i].Factor == 1 {
+			return &unitRegistry[i]
+		}
+	}
+
+	return nil
+}
+
+// ConvertUnit convierte un valor entre dos unidades compatibles
+func ConvertUnit(value float64, from, to string) (float64, error) {
+	fromUnit, ok := LookupUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit '%s'", from)
+	}
+
+	toUnit, ok := LookupUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit '%s'", to)
+	}
+
+	if fromUnit.Dimension != toUnit.Dimension {
+		return 0, fmt.Errorf("cannot convert '%s' to '%s': incompatible units", fromUnit.Symbol, toUnit.Symbol)
+	}
+
+	return value * fromUnit.Factor / toUnit.Factor, nil
+}
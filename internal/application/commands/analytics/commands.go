@@ -0,0 +1,8 @@
+package analytics
+
+// RecordSearchClickCommand representa un comando para registrar que el usuario abrió un
+// producto desde los resultados de una búsqueda
+type RecordSearchClickCommand struct {
+	SearchID  string `json:"search_id" validate:"required" example:"srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"`
+	ProductID string `json:"product_id" validate:"required" example:"PHONE001"`
+}
@@ -0,0 +1,125 @@
+package product
+
+import "meli-products-api/domain"
+
+// SpecificationInput representa una especificación técnica recibida en un comando de escritura
+type SpecificationInput struct {
+	Name  string `json:"name" example:"RAM"`
+	Value string `json:"value" example:"12"`
+	Unit  string `json:"unit,omitempty" example:"GB"`
+}
+
+// ProductInput agrupa los campos editables de un producto. Los campos derivados del catálogo
+// (migas de pan, completitud y valores tipados de las especificaciones) no se reciben.
+type ProductInput struct {
+	Name           string               `json:"name" example:"Samsung Galaxy S24 Ultra"`
+	ImageURL       string               `json:"image_url" example:"https://images.example.com/samsung-s24.jpg"`
+	Description    string               `json:"description" example:"Latest Samsung flagship smartphone with advanced camera technology"`
+	Price          float64              `json:"price" example:"1299.99"`
+	Rating         float32              `json:"rating" example:"4.5"`
+	Specifications []SpecificationInput `json:"specifications"`
+	Category       string               `json:"category" example:"Smartphones"`
+	Brand          string               `json:"brand" example:"Samsung"`
+	Available      bool                 `json:"available" example:"true"`
+}
+
+// CreateProductCommand representa un comando para agregar un producto al catálogo
+type CreateProductCommand struct {
+	ID string `json:"id" example:"PHONE009"`
+	ProductInput
+}
+
+// UpdateProductCommand representa un comando para reemplazar todos los campos editables de
+// un producto existente. El ID se toma de la ruta. La versión es opcional: si se envía, el
+// reemplazo solo se aplica si sigue siendo la versión actual del producto.
+type UpdateProductCommand struct {
+	ID      string `json:"-"`
+	Version int64  `json:"version,omitempty" example:"3"`
+	ProductInput
+}
+
+// PatchProductCommand representa un comando para modificar algunos campos de un producto
+// existente; los campos ausentes conservan su valor. Las especificaciones, si se envían,
+// reemplazan la lista completa. La versión sobre la que se hizo la modificación es
+// obligatoria, para no pisar cambios de otro editor.
+type PatchProductCommand struct {
+	ID             string                `json:"-"`
+	Version        int64                 `json:"version,omitempty" example:"3"`
+	Name           *string               `json:"name,omitempty" example:"Samsung Galaxy S24 Ultra"`
+	ImageURL       *string               `json:"image_url,omitempty" example:"https://images.example.com/samsung-s24.jpg"`
+	Description    *string               `json:"description,omitempty" example:"Latest Samsung flagship smartphone"`
+	Price          *float64              `json:"price,omitempty" example:"1199.99"`
+	Rating         *float32              `json:"rating,omitempty" example:"4.6"`
+	Specifications *[]SpecificationInput `json:"specifications,omitempty"`
+	Category       *string               `json:"category,omitempty" example:"Smartphones"`
+	Brand          *string               `json:"brand,omitempty" example:"Samsung"`
+	Available      *bool                 `json:"available,omitempty" example:"false"`
+}
+
+// DeleteProductCommand representa un comando para eliminar un producto del catálogo
+type DeleteProductCommand struct {
+	ID string `json:"id"`
+}
+
+// ToProduct construye el producto con el ID indicado y los campos del input
+func (in ProductInput) ToProduct(id string) *domain.Product {
+	return &domain.Product{
+		ID:             id,
+		Name:           in.Name,
+		ImageURL:       in.ImageURL,
+		Description:    in.Description,
+		Price:          in.Price,
+		Rating:         in.Rating,
+		Specifications: toSpecifications(in.Specifications),
+		Category:       in.Category,
+		Brand:          in.Brand,
+		Available:      in.Available,
+	}
+}
+
+// Apply devuelve una copia del producto con los campos presentes en el comando reemplazados
+// y con la versión del comando como versión esperada
+func (c *PatchProductCommand) Apply(product *domain.Product) *domain.Product {
+	patched := *product
+	patched.Version = c.Version
+
+	if c.Name != nil {
+		patched.Name = *c.Name
+	}
+	if c.ImageURL != nil {
+		patched.ImageURL = *c.ImageURL
+	}
+	if c.Description != nil {
+		patched.Description = *c.Description
+	}
+	if c.Price != nil {
+		patched.Price = *c.Price
+	}
+	if c.Rating != nil {
+		patched.Rating = *c.Rating
+	}
+	if c.Specifications != nil {
+		patched.Specifications = toSpecifications(*c.Specifications)
+	}
+	if c.Category != nil {
+		patched.Category = *c.Category
+	}
+	if c.Brand != nil {
+		patched.Brand = *c.Brand
+	}
+	if c.Available != nil {
+		patched.Available = *c.Available
+	}
+
+	return &patched
+}
+
+// toSpecifications convierte las especificaciones recibidas en especificaciones del dominio
+func toSpecifications(inputs []SpecificationInput) []domain.Specification {
+	specs := make([]domain.Specification, len(inputs))
+	for i, input := range inputs {
+		specs[i] = domain.Specification{Name: input.Name, Value: input.Value, Unit: input.Unit}
+	}
+
+	return specs
+}
@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/queries/analytics"
+)
+
+// GetSearchReportHandler maneja las solicitudes GetSearchReportQuery
+type GetSearchReportHandler struct {
+	searchLog domain.SearchLog
+	now       func() time.Time
+}
+
+// NewGetSearchReportHandler crea un nuevo GetSearchReportHandler
+func NewGetSearchReportHandler(searchLog domain.SearchLog) *GetSearchReportHandler {
+	return &GetSearchReportHandler{searchLog: searchLog, now: time.Now}
+}
+
+// Handle procesa GetSearchReportQuery y devuelve el reporte de las búsquedas registradas
+// en el período solicitado
+func (h *GetSearchReportHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	query, ok := request.(*analytics.GetSearchReportQuery)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for GetSearchReportHandler")
+	}
+
+	window := query.Window
+	if window == 0 {
+		window = domain.DefaultReportWindow
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = domain.DefaultReportLimit
+	}
+
+	until := h.now().UTC()
+	since := until.Add(-window)
+
+	events, clicks, err := h.searchLog.Events(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BuildSearchReport(query.Report, since, until, events, clicks, limit)
+}
@@ -0,0 +1,58 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/commands/analytics"
+)
+
+// RecordSearchClickHandler maneja las solicitudes RecordSearchClickCommand
+type RecordSearchClickHandler struct {
+	repo      domain.ProductRepository
+	searchLog domain.SearchLog
+	now       func() time.Time
+}
+
+// NewRecordSearchClickHandler crea un nuevo RecordSearchClickHandler
+func NewRecordSearchClickHandler(repo domain.ProductRepository, searchLog domain.SearchLog) *RecordSearchClickHandler {
+	return &RecordSearchClickHandler{repo: repo, searchLog: searchLog, now: time.Now}
+}
+
+// Handle procesa RecordSearchClickCommand: valida el comando, verifica que el producto
+// exista y registra el clic
+func (h *RecordSearchClickHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	command, ok := request.(*analytics.RecordSearchClickCommand)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for RecordSearchClickHandler")
+	}
+
+	var errs domain.ValidationErrors
+	if strings.TrimSpace(command.SearchID) == "" {
+		errs = append(errs, &domain.ValidationError{Field: "search_id", Message: "is required"})
+	}
+	if strings.TrimSpace(command.ProductID) == "" {
+		errs = append(errs, &domain.ValidationError{Field: "product_id", Message: "is required"})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if _, err := h.repo.GetByID(command.ProductID); err != nil {
+		return nil, err
+	}
+
+	click := domain.SearchClick{
+		SearchID:  command.SearchID,
+		ProductID: command.ProductID,
+		Timestamp: h.now().UTC(),
+	}
+	if err := h.searchLog.RecordClick(click); err != nil {
+		return nil, err
+	}
+
+	return &click, nil
+}
@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/mediator"
+	"meli-products-api/internal/application/queries/product"
+)
+
+// SearchLoggingHandler decora el handler de SearchProductsQuery registrando cada búsqueda
+// resuelta en el registro de búsquedas
+type SearchLoggingHandler struct {
+	next      mediator.Handler
+	searchLog domain.SearchLog
+	now       func() time.Time
+}
+
+// NewSearchLoggingHandler crea un nuevo SearchLoggingHandler que delega la búsqueda en next
+func NewSearchLoggingHandler(next mediator.Handler, searchLog domain.SearchLog) *SearchLoggingHandler {
+	return &SearchLoggingHandler{next: next, searchLog: searchLog, now: time.Now}
+}
+
+// Handle resuelve la búsqueda con el handler decorado y la registra junto con la cantidad de
+// resultados y la latencia bajo un ID nuevo, que se devuelve en el resultado para asociarle
+// los clics. Un error al registrar no hace fallar la búsqueda; solo se informa en el log de la
+// aplicación y el resultado queda sin ID. Las búsquedas que fallan no se registran.
+func (h *SearchLoggingHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	start := h.now()
+	result, err := h.next.Handle(ctx, request)
+	if err != nil {
+		return result, err
+	}
+
+	query, ok := request.(*product.SearchProductsQuery)
+	searchResult, resultOK := result.(*product.SearchProductsResult)
+	if !ok || !resultOK {
+		return result, nil
+	}
+
+	searchID, err := domain.NewSearchID()
+	if err != nil {
+		log.Printf("Failed to record search %q: %v", query.Query, err)
+		return result, nil
+	}
+
+	event := domain.SearchEvent{
+		SearchID:        searchID,
+		Query:           query.Query,
+		NormalizedQuery: domain.NormalizeQuery(query.Query),
+		ResultCount:     searchResult.Count,
+		LatencyMs:       float64(h.now().Sub(start).Microseconds()) / 1000,
+		Timestamp:       start.UTC(),
+	}
+	if err := h.searchLog.RecordSearch(event); err != nil {
+		log.Printf("Failed to record search %q: %v", query.Query, err)
+		return result, nil
+	}
+
+	searchResult.SearchID = searchID
+
+	return result, nil
+}
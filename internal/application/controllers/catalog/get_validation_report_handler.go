@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/queries/catalog"
+)
+
+// GetValidationReportHandler maneja las solicitudes GetValidationReportQuery
+type GetValidationReportHandler struct {
+	repo interface {
+		GetValidationReport() *domain.CatalogValidationReport
+	}
+}
+
+// NewGetValidationReportHandler crea un nuevo GetValidationReportHandler
+func NewGetValidationReportHandler(repo interface {
+	GetValidationReport() *domain.CatalogValidationReport
+}) *GetValidationReportHandler {
+	return &GetValidationReportHandler{repo: repo}
+}
+
+// Handle procesa GetValidationReportQuery y devuelve el modo de validación aplicado y los
+// productos descartados en la última carga del catálogo
+func (h *GetValidationReportHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	_, ok := request.(*catalog.GetValidationReportQuery)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for GetValidationReportHandler")
+	}
+
+	return h.repo.GetValidationReport(), nil
+}
@@ -10,12 +10,14 @@ import (
 
 // CompareProductsHandler maneja las solicitudes CompareProductsQuery
 type CompareProductsHandler struct {
-	repo domain.ProductRepository
+	repo  domain.ProductRepository
+	rules *domain.ComparisonRuleSet
 }
 
-// NewCompareProductsHandler crea un nuevo CompareProductsHandler
-func NewCompareProductsHandler(repo domain.ProductRepository) *CompareProductsHandler {
-	return &CompareProductsHandler{repo: repo}
+// NewCompareProductsHandler crea un nuevo CompareProductsHandler. Las reglas son opcionales;
+// sin ellas la matriz de comparación no indica ganadores.
+func NewCompareProductsHandler(repo domain.ProductRepository, rules *domain.ComparisonRuleSet) *CompareProductsHandler {
+	return &CompareProductsHandler{repo: repo, rules: rules}
 }
 
 // Handle procesa CompareProductsQuery y devuelve productos para comparación
@@ -32,13 +34,15 @@ func (h *CompareProductsHandler) Handle(ctx context.Context, request interface{}
 
 	// Devolver respuesta de comparación con metadatos adicionales
 	result := struct {
-		Products     []*domain.Product `json:"products"`
-		TotalCount   int               `json:"total_count"`
-		RequestedIDs []string          `json:"requested_ids"`
+		Products     []*domain.Product        `json:"products"`
+		TotalCount   int                      `json:"total_count"`
+		RequestedIDs []string                 `json:"requested_ids"`
+		Matrix       *domain.ComparisonMatrix `json:"matrix"`
 	}{
 		Products:     products,
 		TotalCount:   len(products),
 		RequestedIDs: query.ProductIDs,
+		Matrix:       domain.NewComparisonMatrix(products, h.rules),
 	}
 
 	return result, nil
@@ -0,0 +1,30 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/commands/product"
+)
+
+// CreateProductHandler maneja las solicitudes CreateProductCommand
+type CreateProductHandler struct {
+	repo domain.WritableProductRepository
+}
+
+// NewCreateProductHandler crea un nuevo CreateProductHandler
+func NewCreateProductHandler(repo domain.WritableProductRepository) *CreateProductHandler {
+	return &CreateProductHandler{repo: repo}
+}
+
+// Handle procesa CreateProductCommand y devuelve el producto creado. El repositorio valida el
+// producto con sus etiquetas validate, la taxonomía y el esquema de su categoría.
+func (h *CreateProductHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	command, ok := request.(*product.CreateProductCommand)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for CreateProductHandler")
+	}
+
+	return h.repo.Create(command.ToProduct(command.ID))
+}
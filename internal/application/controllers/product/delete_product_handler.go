@@ -0,0 +1,29 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/commands/product"
+)
+
+// DeleteProductHandler maneja las solicitudes DeleteProductCommand
+type DeleteProductHandler struct {
+	repo domain.WritableProductRepository
+}
+
+// NewDeleteProductHandler crea un nuevo DeleteProductHandler
+func NewDeleteProductHandler(repo domain.WritableProductRepository) *DeleteProductHandler {
+	return &DeleteProductHandler{repo: repo}
+}
+
+// Handle procesa DeleteProductCommand y devuelve el producto eliminado
+func (h *DeleteProductHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	command, ok := request.(*product.DeleteProductCommand)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for DeleteProductHandler")
+	}
+
+	return h.repo.Delete(command.ID)
+}
@@ -18,12 +18,35 @@ func NewGetAllProductsHandler(repo domain.ProductRepository) *GetAllProductsHand
 	return &GetAllProductsHandler{repo: repo}
 }
 
-// Handle procesa GetAllProductsQuery y devuelve productos filtrados
+// Handle procesa GetAllProductsQuery y devuelve la página solicitada de productos filtrados
 func (h *GetAllProductsHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
 	query, ok := request.(*product.GetAllProductsQuery)
 	if !ok {
 		return nil, fmt.Errorf("invalid request type for GetAllProductsHandler")
 	}
 
-	return h.repo.GetAll(query.Category, query.MinPrice, query.MaxPrice)
+	filter := domain.ProductFilter{
+		Category:  query.Category,
+		MinPrice:  query.MinPrice,
+		MaxPrice:  query.MaxPrice,
+		Brand:     query.Brand,
+		Available: query.Available,
+		MinRating: query.MinRating,
+		Specs:     query.Specs,
+	}
+
+	products, err := h.repo.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := domain.Paginate(products, query.Sort, query.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las facetas se calculan sobre todos los productos filtrados, no solo sobre la página
+	page.Facets = domain.ComputeFacets(products, query.Facets)
+
+	return page, nil
 }
\ No newline at end of file
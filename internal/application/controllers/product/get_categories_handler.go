@@ -4,29 +4,31 @@ import (
 	"context"
 	"fmt"
 
+	"meli-products-api/domain"
 	"meli-products-api/internal/application/queries/product"
 )
 
 // GetCategoriesHandler maneja las solicitudes GetCategoriesQuery
 type GetCategoriesHandler struct {
 	repo interface {
-		GetCategories() []string
+		GetCategoryTree() []domain.CategoryTreeNode
 	}
 }
 
 // NewGetCategoriesHandler crea un nuevo GetCategoriesHandler
 func NewGetCategoriesHandler(repo interface {
-	GetCategories() []string
+	GetCategoryTree() []domain.CategoryTreeNode
 }) *GetCategoriesHandler {
 	return &GetCategoriesHandler{repo: repo}
 }
 
-// Handle procesa GetCategoriesQuery y devuelve las categorías disponibles
+// Handle procesa GetCategoriesQuery y devuelve el árbol de categorías con la cantidad de
+// productos de cada subárbol
 func (h *GetCategoriesHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
 	_, ok := request.(*product.GetCategoriesQuery)
 	if !ok {
 		return nil, fmt.Errorf("invalid request type for GetCategoriesHandler")
 	}
 
-	return h.repo.GetCategories(), nil
-}
\ No newline at end of file
+	return h.repo.GetCategoryTree(), nil
+}
@@ -0,0 +1,40 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/commands/product"
+)
+
+// PatchProductHandler maneja las solicitudes PatchProductCommand
+type PatchProductHandler struct {
+	repo domain.WritableProductRepository
+}
+
+// NewPatchProductHandler crea un nuevo PatchProductHandler
+func NewPatchProductHandler(repo domain.WritableProductRepository) *PatchProductHandler {
+	return &PatchProductHandler{repo: repo}
+}
+
+// Handle procesa PatchProductCommand: exige la versión sobre la que se hizo la modificación,
+// aplica los campos recibidos sobre el producto actual y devuelve el producto modificado. El
+// repositorio rechaza la modificación si otra se aplicó antes.
+func (h *PatchProductHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	command, ok := request.(*product.PatchProductCommand)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for PatchProductHandler")
+	}
+
+	if command.Version == 0 {
+		return nil, domain.ValidationErrors{{Field: "version", Message: "is required, send the If-Match header or the version field"}}
+	}
+
+	current, err := h.repo.GetByID(command.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.repo.Update(command.Apply(current))
+}
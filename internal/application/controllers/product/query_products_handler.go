@@ -0,0 +1,68 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/queries/product"
+)
+
+// QueryProductsHandler maneja las solicitudes QueryProductsQuery
+type QueryProductsHandler struct {
+	repo domain.ProductRepository
+}
+
+// NewQueryProductsHandler crea un nuevo QueryProductsHandler
+func NewQueryProductsHandler(repo domain.ProductRepository) *QueryProductsHandler {
+	return &QueryProductsHandler{repo: repo}
+}
+
+// Handle procesa QueryProductsQuery: valida el documento completo, informando todos los
+// errores por campo, y devuelve la página solicitada con la proyección de campos indicada
+func (h *QueryProductsHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	query, ok := request.(*product.QueryProductsQuery)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for QueryProductsHandler")
+	}
+
+	var errs domain.ValidationErrors
+	collect := func(err error) {
+		if validation, ok := err.(domain.ValidationErrors); ok {
+			errs = append(errs, validation...)
+		}
+	}
+
+	condition, err := domain.CompileFilter(query.Filter)
+	collect(err)
+	sortKeys, err := domain.ValidateSortKeys(query.Sort)
+	collect(err)
+	collect(domain.ValidateProjection(query.Fields))
+	collect(domain.ValidatePageRequest(query.PageRequest))
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	products, err := h.repo.GetAll(domain.ProductFilter{Condition: condition})
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := domain.Paginate(products, sortKeys, query.PageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sin proyección se devuelven los productos completos
+	items := make([]interface{}, len(page.Items))
+	for i, item := range page.Items {
+		if len(query.Fields) == 0 {
+			items[i] = item
+		} else {
+			items[i] = domain.ProjectProduct(item, query.Fields)
+		}
+	}
+
+	return &product.QueryProductsResult{Items: items, Page: page.PageInfo}, nil
+}
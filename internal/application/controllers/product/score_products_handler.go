@@ -0,0 +1,51 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/queries/product"
+)
+
+// ScoreProductsHandler maneja las solicitudes ScoreProductsQuery
+type ScoreProductsHandler struct {
+	repo  domain.ProductRepository
+	rules *domain.ComparisonRuleSet
+}
+
+// NewScoreProductsHandler crea un nuevo ScoreProductsHandler. Las reglas determinan la
+// dirección de cada criterio; sin ellas se asume que mayor es mejor.
+func NewScoreProductsHandler(repo domain.ProductRepository, rules *domain.ComparisonRuleSet) *ScoreProductsHandler {
+	return &ScoreProductsHandler{repo: repo, rules: rules}
+}
+
+// Handle procesa ScoreProductsQuery y devuelve el ranking ponderado de los productos
+func (h *ScoreProductsHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	query, ok := request.(*product.ScoreProductsQuery)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for ScoreProductsHandler")
+	}
+
+	products, err := h.repo.GetByIDs(query.ProductIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving products for scoring: %w", err)
+	}
+
+	ranking, err := domain.ScoreProducts(products, query.Weights, h.rules)
+	if err != nil {
+		return nil, err
+	}
+
+	result := struct {
+		Ranking      []domain.ProductScore `json:"ranking"`
+		Weights      map[string]float64    `json:"weights"`
+		RequestedIDs []string              `json:"requested_ids"`
+	}{
+		Ranking:      ranking,
+		Weights:      query.Weights,
+		RequestedIDs: query.ProductIDs,
+	}
+
+	return result, nil
+}
@@ -10,35 +10,123 @@ import (
 
 // SearchProductsHandler maneja las solicitudes SearchProductsQuery
 type SearchProductsHandler struct {
-	repo domain.ProductRepository
+	searcher domain.ProductSearcher
 }
 
 // NewSearchProductsHandler crea un nuevo SearchProductsHandler
-func NewSearchProductsHandler(repo domain.ProductRepository) *SearchProductsHandler {
-	return &SearchProductsHandler{repo: repo}
+func NewSearchProductsHandler(searcher domain.ProductSearcher) *SearchProductsHandler {
+	return &SearchProductsHandler{searcher: searcher}
 }
 
-// Handle procesa SearchProductsQuery y devuelve productos coincidentes
+// Handle procesa SearchProductsQuery y devuelve productos coincidentes. La consulta puede
+// combinar texto libre con términos estructurados (ver domain.ParseSearchQuery); si el
+// buscador conoce las especificaciones del catálogo, un campo que no es del producto ni una
+// especificación conocida es un error de sintaxis. Sin criterios de ordenamiento explícitos,
+// los resultados se devuelven por relevancia.
 func (h *SearchProductsHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
 	query, ok := request.(*product.SearchProductsQuery)
 	if !ok {
 		return nil, fmt.Errorf("invalid request type for SearchProductsHandler")
 	}
 
-	products, err := h.repo.Search(query.Query)
+	plan := query.Plan
+	if plan == nil {
+		var err error
+		if plan, err = domain.ParseSearchQuery(query.Query); err != nil {
+			return nil, err
+		}
+	}
+	if catalog, ok := h.searcher.(domain.SpecificationCatalog); ok {
+		if err := plan.CheckSpecNames(catalog); err != nil {
+			return nil, err
+		}
+	}
+
+	hits, err := h.searcher.SearchRanked(domain.SearchRequest{Query: plan.Text, Filter: plan.Filter()})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, len(hits))
+	scores := make(map[string]float64, len(hits))
+	for i, hit := range hits {
+		products[i] = hit.Product
+		scores[hit.ID] = hit.Score
+	}
+
+	page, err := domain.Paginate(products, query.Sort, query.PageRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	result := struct {
-		Products []*domain.Product `json:"products"`
-		Query    string            `json:"query"`
-		Count    int               `json:"count"`
-	}{
-		Products: products,
-		Query:    query.Query,
-		Count:    len(products),
+	items := make([]domain.SearchHit, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = domain.SearchHit{Product: item, Score: scores[item.ID]}
 	}
 
-	return result, nil
-}
\ No newline at end of file
+	if err := h.annotate(query, plan.Text, items); err != nil {
+		return nil, err
+	}
+
+	suggestions, err := h.suggest(plan.Text, len(hits))
+	if err != nil {
+		return nil, err
+	}
+
+	return &product.SearchProductsResult{
+		Products:    items,
+		Query:       query.Query,
+		Count:       page.TotalCount,
+		Suggestions: suggestions,
+		Page:        page.PageInfo,
+		Facets:      domain.ComputeFacets(products, query.Facets),
+	}, nil
+}
+
+// annotate agrega a los resultados de la página los resaltados y la explicación del puntaje
+// solicitados, si el buscador lo soporta y la búsqueda tiene texto libre
+func (h *SearchProductsHandler) annotate(query *product.SearchProductsQuery, text string, items []domain.SearchHit) error {
+	annotator, ok := h.searcher.(domain.SearchAnnotator)
+	if !ok || text == "" || (!query.Highlight && !query.Explain) {
+		return nil
+	}
+
+	for i := range items {
+		if query.Highlight {
+			highlights, err := annotator.Highlight(text, items[i].Product)
+			if err != nil {
+				return err
+			}
+			items[i].Highlights = highlights
+		}
+
+		if query.Explain {
+			explanation, err := annotator.Explain(text, items[i].Product)
+			if err != nil {
+				return err
+			}
+			items[i].Explanation = explanation
+		}
+	}
+
+	return nil
+}
+
+// suggest propone consultas corregidas para el texto libre cuando la búsqueda no devolvió
+// resultados o devolvió muy pocos, si el buscador lo soporta
+func (h *SearchProductsHandler) suggest(query string, results int) ([]string, error) {
+	suggester, ok := h.searcher.(domain.QuerySuggester)
+	if !ok || query == "" || results >= domain.ScarceResultsThreshold {
+		return []string{}, nil
+	}
+
+	suggestions, err := suggester.SuggestQueries(query, domain.MaxSearchSuggestions)
+	if err != nil {
+		return nil, err
+	}
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,45 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/queries/product"
+)
+
+// SuggestProductsHandler maneja las solicitudes SuggestProductsQuery
+type SuggestProductsHandler struct {
+	completer domain.ProductAutocompleter
+}
+
+// NewSuggestProductsHandler crea un nuevo SuggestProductsHandler
+func NewSuggestProductsHandler(completer domain.ProductAutocompleter) *SuggestProductsHandler {
+	return &SuggestProductsHandler{completer: completer}
+}
+
+// Handle procesa SuggestProductsQuery y devuelve las sugerencias de autocompletado
+func (h *SuggestProductsHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	query, ok := request.(*product.SuggestProductsQuery)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for SuggestProductsHandler")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = domain.DefaultSuggestionLimit
+	}
+	if limit > domain.MaxSuggestionLimit {
+		limit = domain.MaxSuggestionLimit
+	}
+
+	suggestions, err := h.completer.Autocomplete(query.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product.SuggestProductsResult{
+		Query:       query.Query,
+		Suggestions: suggestions,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/commands/product"
+)
+
+// UpdateProductHandler maneja las solicitudes UpdateProductCommand
+type UpdateProductHandler struct {
+	repo domain.WritableProductRepository
+}
+
+// NewUpdateProductHandler crea un nuevo UpdateProductHandler
+func NewUpdateProductHandler(repo domain.WritableProductRepository) *UpdateProductHandler {
+	return &UpdateProductHandler{repo: repo}
+}
+
+// Handle procesa UpdateProductCommand y devuelve el producto reemplazado. Si el comando trae
+// versión, el repositorio rechaza el reemplazo cuando ya no es la actual.
+func (h *UpdateProductHandler) Handle(ctx context.Context, request interface{}) (interface{}, error) {
+	command, ok := request.(*product.UpdateProductCommand)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for UpdateProductHandler")
+	}
+
+	product := command.ToProduct(command.ID)
+	product.Version = command.Version
+
+	return h.repo.Update(product)
+}
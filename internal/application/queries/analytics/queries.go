@@ -0,0 +1,15 @@
+package analytics
+
+import (
+	"time"
+
+	"meli-products-api/domain"
+)
+
+// GetSearchReportQuery representa una consulta para obtener un reporte de búsquedas sobre
+// el período que termina en el momento de la consulta
+type GetSearchReportQuery struct {
+	Report domain.SearchReportKind `json:"report" validate:"required" example:"top_queries"`
+	Window time.Duration           `json:"window" swaggertype:"string" example:"24h"`
+	Limit  int                     `json:"limit,omitempty" example:"20"`
+}
@@ -0,0 +1,5 @@
+package catalog
+
+// GetValidationReportQuery representa una consulta para obtener el resultado de la validación
+// de los productos en la última carga del catálogo
+type GetValidationReportQuery struct{}
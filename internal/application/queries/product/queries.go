@@ -1,5 +1,7 @@
 package product
 
+import "meli-products-api/domain"
+
 // GetProductQuery representa una consulta para obtener un producto por ID
 type GetProductQuery struct {
 	ID string `json:"id" validate:"required" example:"PHONE001"`
@@ -7,9 +9,25 @@ type GetProductQuery struct {
 
 // GetAllProductsQuery representa una consulta para obtener todos los productos con filtros opcionales
 type GetAllProductsQuery struct {
-	Category string  `json:"category,omitempty" example:"Smartphones"`
-	MinPrice float64 `json:"min_price,omitempty" example:"0"`
-	MaxPrice float64 `json:"max_price,omitempty" example:"2000"`
+	Category  string                 `json:"category,omitempty" example:"Smartphones"`
+	MinPrice  float64                `json:"min_price,omitempty" example:"0"`
+	MaxPrice  float64                `json:"max_price,omitempty" example:"2000"`
+	Brand     string                 `json:"brand,omitempty" example:"Samsung"`
+	Available *bool                  `json:"available,omitempty" example:"true"`
+	MinRating float64                `json:"min_rating,omitempty" example:"4.5"`
+	Specs     []domain.SpecPredicate `json:"specs,omitempty"`
+	Sort      []domain.SortKey       `json:"sort,omitempty"`
+	Facets    *domain.FacetRequest   `json:"facets,omitempty"`
+	domain.PageRequest
+}
+
+// QueryProductsQuery representa una consulta de productos expresada como documento JSON,
+// con un árbol de filtros, criterios de ordenamiento, paginación y proyección de campos
+type QueryProductsQuery struct {
+	Filter *domain.FilterNode `json:"filter,omitempty"`
+	Sort   []domain.SortKey   `json:"sort,omitempty"`
+	Fields []string           `json:"fields,omitempty" example:"id,name,price"`
+	domain.PageRequest
 }
 
 // CompareProductsQuery representa una consulta para comparar múltiples productos
@@ -17,9 +35,33 @@ type CompareProductsQuery struct {
 	ProductIDs []string `json:"product_ids" validate:"required,min=2" example:"PHONE001,PHONE002"`
 }
 
+// ScoreProductsQuery representa una consulta para puntuar productos comparados según pesos por criterio
+type ScoreProductsQuery struct {
+	ProductIDs []string           `json:"product_ids" validate:"required,min=2" example:"PHONE001,PHONE002"`
+	Weights    map[string]float64 `json:"weights" validate:"required"`
+}
+
 // SearchProductsQuery representa una consulta para buscar productos
 type SearchProductsQuery struct {
-	Query string `json:"query" validate:"required" example:"Samsung Galaxy"`
+	Query  string               `json:"query" validate:"required" example:"Samsung Galaxy"`
+	Sort   []domain.SortKey     `json:"sort,omitempty"`
+	Facets *domain.FacetRequest `json:"facets,omitempty"`
+	domain.PageRequest
+
+	// Highlight marca en cada resultado los términos coincidentes del nombre y la descripción
+	Highlight bool `json:"highlight,omitempty" example:"true"`
+
+	// Explain agrega a cada resultado el detalle de las coincidencias y de su puntuación
+	Explain bool `json:"explain,omitempty" example:"false"`
+
+	// Plan ya interpretado de Query; si es nil, el handler interpreta Query
+	Plan *domain.SearchPlan `json:"-"`
+}
+
+// SuggestProductsQuery representa una consulta de autocompletado a partir de un prefijo
+type SuggestProductsQuery struct {
+	Query string `json:"query" validate:"required" example:"gal"`
+	Limit int    `json:"limit,omitempty" example:"5"`
 }
 
 // GetCategoriesQuery representa una consulta para obtener todas las categorías disponibles
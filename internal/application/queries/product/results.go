@@ -0,0 +1,46 @@
+package product
+
+import "meli-products-api/domain"
+
+// SearchProductsResult representa el resultado de SearchProductsQuery
+type SearchProductsResult struct {
+	// Productos de la página solicitada con su puntuación de relevancia
+	Products []domain.SearchHit `json:"products"`
+
+	// Consulta de búsqueda utilizada
+	Query string `json:"query"`
+
+	// Total de productos que coinciden con la búsqueda
+	Count int `json:"count"`
+
+	// Consultas corregidas propuestas cuando hay pocos o ningún resultado
+	Suggestions []string `json:"suggestions"`
+
+	// ID con el que se registró la búsqueda, que se envía al registrar un clic sobre sus
+	// resultados; vacío si la búsqueda no se registró
+	SearchID string `json:"search_id,omitempty" example:"srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"`
+
+	// Información de paginación, expuesta en los metadatos de la respuesta
+	Page domain.PageInfo `json:"-"`
+
+	// Facetas calculadas sobre todos los resultados, expuestas en los metadatos de la respuesta
+	Facets []domain.Facet `json:"-"`
+}
+
+// SuggestProductsResult representa el resultado de SuggestProductsQuery
+type SuggestProductsResult struct {
+	// Prefijo consultado
+	Query string `json:"query"`
+
+	// Sugerencias de más a menos popular
+	Suggestions []domain.Suggestion `json:"suggestions"`
+}
+
+// QueryProductsResult representa el resultado de QueryProductsQuery
+type QueryProductsResult struct {
+	// Productos de la página, completos o con solo los campos solicitados
+	Items []interface{} `json:"items"`
+
+	// Información de paginación, expuesta en los metadatos de la respuesta
+	Page domain.PageInfo `json:"-"`
+}
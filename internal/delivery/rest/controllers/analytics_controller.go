@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"meli-products-api/domain"
+	analyticsCommands "meli-products-api/internal/application/commands/analytics"
+	"meli-products-api/internal/application/mediator"
+	analyticsQueries "meli-products-api/internal/application/queries/analytics"
+	"meli-products-api/pkg/response"
+)
+
+// AnalyticsController maneja las solicitudes HTTP del registro y los reportes de búsquedas
+type AnalyticsController struct {
+	mediator mediator.Mediator
+}
+
+// NewAnalyticsController crea un nuevo AnalyticsController
+func NewAnalyticsController(mediator mediator.Mediator) *AnalyticsController {
+	return &AnalyticsController{
+		mediator: mediator,
+	}
+}
+
+// RecordSearchClick godoc
+// @Summary Record a search result click
+// @Description Record that the user opened a product from the results of a search, identified by the search_id returned by /products/search
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param request body analyticsCommands.RecordSearchClickCommand true "Search ID and opened product ID"
+// @Success 201 {object} response.APIResponse{data=domain.SearchClick} "Search click recorded successfully"
+// @Failure 400 {object} response.APIResponse "Malformed request body"
+// @Failure 404 {object} response.APIResponse "Product not found"
+// @Failure 422 {object} response.APIResponse "Missing search or product ID, listed in error.fields"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/search/clicks [post]
+func (ac *AnalyticsController) RecordSearchClick(c *gin.Context) {
+	var command analyticsCommands.RecordSearchClickCommand
+
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&command); err != nil {
+		response.BadRequest(c.Writer, "INVALID_REQUEST_BODY", "Invalid request body", fmt.Sprintf("Please provide a valid JSON click document: %v", err))
+		return
+	}
+
+	result, err := ac.mediator.Send(c.Request.Context(), &command)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	response.Created(c.Writer, result, "Search click recorded successfully")
+}
+
+// GetTopQueries godoc
+// @Summary Top search queries
+// @Description Report the most searched normalized queries in the time window ending now, with result counts, latency and click-through rate
+// @Tags analytics
+// @Produce json
+// @Param window query string false "Time window ending now, e.g. 1h, 24h or 7d (default 24h, max 90d)" example("7d")
+// @Param limit query int false "Maximum number of queries (1-100, default 20)" example(20)
+// @Success 200 {object} response.APIResponse{data=domain.SearchReport} "Search report retrieved successfully"
+// @Failure 400 {object} response.APIResponse "Invalid window or limit"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /admin/search/top-queries [get]
+func (ac *AnalyticsController) GetTopQueries(c *gin.Context) {
+	ac.searchReport(c, domain.ReportTopQueries)
+}
+
+// GetZeroResultQueries godoc
+// @Summary Zero-result search queries
+// @Description Report the normalized queries that returned no results in the time window ending now, ordered by failed searches
+// @Tags analytics
+// @Produce json
+// @Param window query string false "Time window ending now, e.g. 1h, 24h or 7d (default 24h, max 90d)" example("7d")
+// @Param limit query int false "Maximum number of queries (1-100, default 20)" example(20)
+// @Success 200 {object} response.APIResponse{data=domain.SearchReport} "Search report retrieved successfully"
+// @Failure 400 {object} response.APIResponse "Invalid window or limit"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /admin/search/zero-result-queries [get]
+func (ac *AnalyticsController) GetZeroResultQueries(c *gin.Context) {
+	ac.searchReport(c, domain.ReportZeroResultQueries)
+}
+
+// GetClicklessQueries godoc
+// @Summary Click-less search queries
+// @Description Report the normalized queries that returned results but none of whose searches led to a recorded click in the time window ending now
+// @Tags analytics
+// @Produce json
+// @Param window query string false "Time window ending now, e.g. 1h, 24h or 7d (default 24h, max 90d)" example("7d")
+// @Param limit query int false "Maximum number of queries (1-100, default 20)" example(20)
+// @Success 200 {object} response.APIResponse{data=domain.SearchReport} "Search report retrieved successfully"
+// @Failure 400 {object} response.APIResponse "Invalid window or limit"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /admin/search/clickless-queries [get]
+func (ac *AnalyticsController) GetClicklessQueries(c *gin.Context) {
+	ac.searchReport(c, domain.ReportClicklessQueries)
+}
+
+// searchReport interpreta los parámetros window y limit y responde con el reporte indicado
+func (ac *AnalyticsController) searchReport(c *gin.Context, report domain.SearchReportKind) {
+	window := domain.DefaultReportWindow
+	if windowStr := c.Query("window"); windowStr != "" {
+		value, err := domain.ParseReportWindow(windowStr)
+		if err != nil {
+			response.BadRequest(c.Writer, "INVALID_WINDOW", "Invalid report window", err.Error())
+			return
+		}
+		window = value
+	}
+
+	limit := domain.DefaultReportLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil || value < 1 || value > domain.MaxReportLimit {
+			response.BadRequest(c.Writer, "INVALID_LIMIT", "Invalid limit", fmt.Sprintf("Limit must be an integer between 1 and %d", domain.MaxReportLimit))
+			return
+		}
+		limit = value
+	}
+
+	query := &analyticsQueries.GetSearchReportQuery{Report: report, Window: window, Limit: limit}
+	result, err := ac.mediator.Send(c.Request.Context(), query)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result, "Search report retrieved successfully")
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"meli-products-api/internal/application/mediator"
+	catalogQueries "meli-products-api/internal/application/queries/catalog"
+	"meli-products-api/pkg/response"
+)
+
+// CatalogController maneja las solicitudes HTTP de administración del catálogo
+type CatalogController struct {
+	mediator mediator.Mediator
+}
+
+// NewCatalogController crea un nuevo CatalogController
+func NewCatalogController(mediator mediator.Mediator) *CatalogController {
+	return &CatalogController{
+		mediator: mediator,
+	}
+}
+
+// GetValidationReport godoc
+// @Summary Catalog validation report
+// @Description Report the validation mode applied at the last catalog load and, in lenient mode, the products that were skipped with the fields that failed validation
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=domain.CatalogValidationReport} "Validation report retrieved successfully"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /admin/catalog/validation [get]
+func (cc *CatalogController) GetValidationReport(c *gin.Context) {
+	result, err := cc.mediator.Send(c.Request.Context(), &catalogQueries.GetValidationReportQuery{})
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result, "Validation report retrieved successfully")
+}
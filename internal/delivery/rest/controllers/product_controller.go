@@ -1,18 +1,25 @@
 package controllers
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
-	// "meli-products-api/domain"
+	"meli-products-api/domain"
+	productCommands "meli-products-api/internal/application/commands/product"
 	"meli-products-api/internal/application/mediator"
 	"meli-products-api/internal/application/queries/product"
 	"meli-products-api/pkg/response"
 )
 
+// specFilterPrefix es el prefijo de los parámetros de filtrado por especificación
+const specFilterPrefix = "spec."
+
 // ProductController maneja las solicitudes HTTP para operaciones de productos
 type ProductController struct {
 	mediator mediator.Mediator
@@ -33,6 +40,7 @@ func NewProductController(mediator mediator.Mediator) *ProductController {
 // @Produce json
 // @Param id path string true "Product ID" example("PHONE001")
 // @Success 200 {object} response.APIResponse{data=domain.Product} "Product retrieved successfully"
+// @Header 200 {string} ETag "Product version, to send in If-Match when modifying the product"
 // @Failure 400 {object} response.APIResponse "Invalid product ID"
 // @Failure 404 {object} response.APIResponse "Product not found"
 // @Failure 500 {object} response.APIResponse "Internal server error"
@@ -53,19 +61,202 @@ func (pc *ProductController) GetProduct(c *gin.Context) {
 		return
 	}
 
+	setProductETag(c, result)
 	response.Success(c.Writer, result, "Product retrieved successfully")
 }
 
+// CreateProduct godoc
+// @Summary Create a product
+// @Description Add a product to the catalog and persist it to the data file. The product is validated with the same rules as the catalog load: required fields, taxonomy category and specification schema
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body productCommands.CreateProductCommand true "Product to create"
+// @Success 201 {object} response.APIResponse{data=domain.Product} "Product created successfully"
+// @Header 201 {string} ETag "Product version"
+// @Failure 400 {object} response.APIResponse "Malformed request body"
+// @Failure 409 {object} response.APIResponse "A product with the same ID already exists"
+// @Failure 422 {object} response.APIResponse "Invalid product fields, listed in error.fields"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products [post]
+func (pc *ProductController) CreateProduct(c *gin.Context) {
+	var command productCommands.CreateProductCommand
+	if !decodeProductBody(c, &command) {
+		return
+	}
+
+	result, err := pc.mediator.Send(c.Request.Context(), &command)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	setProductETag(c, result)
+	response.Created(c.Writer, result, "Product created successfully")
+}
+
+// UpdateProduct godoc
+// @Summary Replace a product
+// @Description Replace every editable field of an existing product and persist the change to the data file. Fields missing from the body are cleared.
+// @Description The expected version is optional: when sent in If-Match or in the version field, the product is only replaced if it is still at that version
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID" example("PHONE001")
+// @Param If-Match header string false "ETag of the version the change is based on, or * to replace any version" example("3")
+// @Param request body productCommands.UpdateProductCommand true "New product fields"
+// @Success 200 {object} response.APIResponse{data=domain.Product} "Product updated successfully"
+// @Header 200 {string} ETag "New product version"
+// @Failure 400 {object} response.APIResponse "Invalid product ID, If-Match header or request body"
+// @Failure 404 {object} response.APIResponse "Product not found"
+// @Failure 409 {object} response.APIResponse "The product is no longer at the expected version; error.details has the current version"
+// @Failure 422 {object} response.APIResponse "Invalid product fields, listed in error.fields"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/{id} [put]
+func (pc *ProductController) UpdateProduct(c *gin.Context) {
+	command := productCommands.UpdateProductCommand{ID: c.Param("id")}
+	if !decodeProductBody(c, &command) || !applyIfMatch(c, &command.Version, true) {
+		return
+	}
+
+	result, err := pc.mediator.Send(c.Request.Context(), &command)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	setProductETag(c, result)
+	response.Success(c.Writer, result, "Product updated successfully")
+}
+
+// PatchProduct godoc
+// @Summary Modify a product
+// @Description Modify some fields of an existing product and persist the change to the data file. Fields missing from the body keep their value; specifications, when present, replace the whole list.
+// @Description The version the change is based on is required, in the If-Match header (the ETag of the product) or in the version field; if another change was applied first the request fails with 409
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID" example("PHONE001")
+// @Param If-Match header string false "ETag of the version the change is based on; required unless the body has a version, * is not accepted" example("3")
+// @Param request body productCommands.PatchProductCommand true "Product fields to modify"
+// @Success 200 {object} response.APIResponse{data=domain.Product} "Product updated successfully"
+// @Header 200 {string} ETag "New product version"
+// @Failure 400 {object} response.APIResponse "Invalid product ID, If-Match header or request body"
+// @Failure 404 {object} response.APIResponse "Product not found"
+// @Failure 409 {object} response.APIResponse "The product is no longer at the expected version; error.details has the current version"
+// @Failure 422 {object} response.APIResponse "Missing version or invalid product fields, listed in error.fields"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/{id} [patch]
+func (pc *ProductController) PatchProduct(c *gin.Context) {
+	command := productCommands.PatchProductCommand{ID: c.Param("id")}
+	if !decodeProductBody(c, &command) || !applyIfMatch(c, &command.Version, false) {
+		return
+	}
+
+	result, err := pc.mediator.Send(c.Request.Context(), &command)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	setProductETag(c, result)
+	response.Success(c.Writer, result, "Product updated successfully")
+}
+
+// DeleteProduct godoc
+// @Summary Delete a product
+// @Description Remove a product from the catalog and the data file, returning the deleted product
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID" example("PHONE001")
+// @Success 200 {object} response.APIResponse{data=domain.Product} "Product deleted successfully"
+// @Failure 400 {object} response.APIResponse "Invalid product ID"
+// @Failure 404 {object} response.APIResponse "Product not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/{id} [delete]
+func (pc *ProductController) DeleteProduct(c *gin.Context) {
+	command := &productCommands.DeleteProductCommand{ID: c.Param("id")}
+	result, err := pc.mediator.Send(c.Request.Context(), command)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result, "Product deleted successfully")
+}
+
+// applyIfMatch toma la versión esperada del encabezado If-Match, si se envía. Con anyVersion,
+// "If-Match: *" acepta cualquier versión del producto y no fija una esperada. Si el encabezado
+// es inválido o no coincide con la versión del cuerpo responde 400 y devuelve false.
+func applyIfMatch(c *gin.Context, version *int64, anyVersion bool) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return true
+	}
+
+	if header == "*" {
+		if anyVersion {
+			return true
+		}
+		response.BadRequest(c.Writer, "INVALID_IF_MATCH", "Invalid If-Match header", `If-Match: * is not accepted here, send the ETag of the version the change is based on, such as "3"`)
+		return false
+	}
+
+	expected, err := domain.ParseProductETag(header)
+	if err != nil {
+		response.BadRequest(c.Writer, "INVALID_IF_MATCH", "Invalid If-Match header", err.Error())
+		return false
+	}
+
+	if *version != 0 && *version != expected {
+		response.BadRequest(c.Writer, "VERSION_MISMATCH", "The If-Match header and the version field differ", fmt.Sprintf("If-Match has version %d and the body has version %d; send only one of them", expected, *version))
+		return false
+	}
+
+	*version = expected
+	return true
+}
+
+// setProductETag informa la versión del producto devuelto en el encabezado ETag
+func setProductETag(c *gin.Context, result interface{}) {
+	if product, ok := result.(*domain.Product); ok {
+		c.Header("ETag", domain.ProductETag(product.Version))
+	}
+}
+
+// decodeProductBody decodifica el cuerpo de un comando de escritura rechazando campos
+// desconocidos; si el cuerpo es inválido responde 400 y devuelve false
+func decodeProductBody(c *gin.Context, command interface{}) bool {
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(command); err != nil {
+		response.BadRequest(c.Writer, "INVALID_REQUEST_BODY", "Invalid request body", fmt.Sprintf("Please provide a valid JSON product document: %v", err))
+		return false
+	}
+
+	return true
+}
+
 // GetAllProducts godoc
 // @Summary Get all products
-// @Description Retrieve all products with optional filtering by category and price range
+// @Description Retrieve all products with optional filtering by category, price range, brand, availability, rating and specifications.
+// @Description Specification predicates are passed as raw query parameters of the form spec.<name><op><value>, where op is one of =, !=, >, >=, <, <= or ~ (contains), e.g. spec.RAM>=12 or spec.Almacenamiento>=1TB
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param category query string false "Filter by category" example("Smartphones")
 // @Param min_price query number false "Minimum price filter" example(100.00)
 // @Param max_price query number false "Maximum price filter" example(2000.00)
-// @Success 200 {object} response.APIResponse{data=[]domain.Product} "Products retrieved successfully"
+// @Param brand query string false "Filter by brand" example("Samsung")
+// @Param available query boolean false "Filter by availability" example(true)
+// @Param min_rating query number false "Minimum rating filter (0-5)" example(4.5)
+// @Param page query int false "Page number (starting at 1)" example(1)
+// @Param page_size query int false "Page size (1-100, default 20)" example(20)
+// @Param sort query string false "Comma-separated sort keys: price, rating, name or completeness, with optional '-' prefix or ':asc'/':desc' suffix" example("-rating,price")
+// @Param cursor query string false "Opaque cursor from meta.next_cursor, alternative to page"
+// @Param facets query string false "Comma-separated facets computed over all matching products and returned in meta.facets: brand, category, price, rating or spec.<name>" example("brand,price,spec.RAM")
+// @Param price_buckets query string false "Ascending comma-separated price bucket limits for the price facet (default 250,500,1000,2000)" example("500,1000,2000")
+// @Success 200 {object} response.APIResponse{data=[]domain.Product,meta=response.Meta} "Products retrieved successfully"
 // @Failure 400 {object} response.APIResponse "Invalid query parameters"
 // @Failure 500 {object} response.APIResponse "Internal server error"
 // @Router /products [get]
@@ -101,10 +292,52 @@ func (pc *ProductController) GetAllProducts(c *gin.Context) {
 		return
 	}
 
+	var available *bool
+	if availableStr := c.Query("available"); availableStr != "" {
+		value, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			response.BadRequest(c.Writer, "INVALID_AVAILABLE", "Invalid availability filter", "Availability must be 'true' or 'false'")
+			return
+		}
+		available = &value
+	}
+
+	var minRating float64
+	if minRatingStr := c.Query("min_rating"); minRatingStr != "" {
+		minRating, err = strconv.ParseFloat(minRatingStr, 64)
+		if err != nil || minRating < 0 || minRating > 5 {
+			response.BadRequest(c.Writer, "INVALID_MIN_RATING", "Invalid minimum rating", "Minimum rating must be a number between 0 and 5")
+			return
+		}
+	}
+
+	specs, err := parseSpecPredicates(c.Request.URL.RawQuery)
+	if err != nil {
+		response.BadRequest(c.Writer, "INVALID_SPEC_FILTER", "Invalid specification filter", err.Error())
+		return
+	}
+
+	sortKeys, pageRequest, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	facets, ok := parseFacets(c)
+	if !ok {
+		return
+	}
+
 	query := &product.GetAllProductsQuery{
-		Category: category,
-		MinPrice: minPrice,
-		MaxPrice: maxPrice,
+		Category:    category,
+		MinPrice:    minPrice,
+		MaxPrice:    maxPrice,
+		Brand:       strings.TrimSpace(c.Query("brand")),
+		Available:   available,
+		MinRating:   minRating,
+		Specs:       specs,
+		Sort:        sortKeys,
+		Facets:      facets,
+		PageRequest: pageRequest,
 	}
 
 	result, err := pc.mediator.Send(c.Request.Context(), query)
@@ -113,17 +346,188 @@ func (pc *ProductController) GetAllProducts(c *gin.Context) {
 		return
 	}
 
-	response.Success(c.Writer, result, "Products retrieved successfully")
+	page, ok := result.(*domain.ProductPage)
+	if !ok {
+		response.InternalServerError(c.Writer, "INTERNAL_ERROR", "An unexpected error occurred", "Unexpected result type for products listing")
+		return
+	}
+
+	response.SuccessWithMeta(c.Writer, page.Items, "Products retrieved successfully", withFacets(pageMeta(c, page.PageInfo), page.Facets))
+}
+
+// parsePagination interpreta los parámetros page, page_size, sort y cursor.
+// Si algún parámetro es inválido escribe la respuesta de error y devuelve false.
+func parsePagination(c *gin.Context) ([]domain.SortKey, domain.PageRequest, bool) {
+	var request domain.PageRequest
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			response.BadRequest(c.Writer, "INVALID_PAGE", "Invalid page", "Page must be a positive integer")
+			return nil, request, false
+		}
+		request.Page = page
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		pageSize, err := strconv.Atoi(pageSizeStr)
+		if err != nil || pageSize < 1 || pageSize > domain.MaxPageSize {
+			response.BadRequest(c.Writer, "INVALID_PAGE_SIZE", "Invalid page size", fmt.Sprintf("Page size must be an integer between 1 and %d", domain.MaxPageSize))
+			return nil, request, false
+		}
+		request.PageSize = pageSize
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		if request.Page > 0 {
+			response.BadRequest(c.Writer, "INVALID_PAGINATION", "Conflicting pagination parameters", "Use either 'page' or 'cursor', not both")
+			return nil, request, false
+		}
+		if err := domain.ValidateCursor(cursor); err != nil {
+			response.BadRequest(c.Writer, "INVALID_CURSOR", "Invalid cursor", "Please use the 'next_cursor' value returned in the previous response")
+			return nil, request, false
+		}
+		request.Cursor = cursor
+	}
+
+	sortKeys, err := domain.ParseSortKeys(c.Query("sort"))
+	if err != nil {
+		response.BadRequest(c.Writer, "INVALID_SORT", "Invalid sort parameter", err.Error())
+		return nil, request, false
+	}
+
+	return sortKeys, request, true
+}
+
+// pageMeta construye los metadatos de respuesta a partir de la información de paginación
+func pageMeta(c *gin.Context, page domain.PageInfo) *response.Meta {
+	return &response.Meta{
+		Timestamp:  time.Now().Format("2006-01-02T15:04:05Z"),
+		RequestID:  c.GetString("request_id"),
+		Version:    "v1",
+		TotalCount: page.TotalCount,
+		Page:       page.Page,
+		PageSize:   page.PageSize,
+		TotalPages: page.TotalPages,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// parseFacets interpreta los parámetros facets y price_buckets. Si algún parámetro es
+// inválido escribe la respuesta de error y devuelve false.
+func parseFacets(c *gin.Context) (*domain.FacetRequest, bool) {
+	facets := c.Query("facets")
+	priceBuckets := c.Query("price_buckets")
+	if facets == "" && priceBuckets == "" {
+		return nil, true
+	}
+
+	request, err := domain.ParseFacetRequest(facets, priceBuckets)
+	if err != nil {
+		response.BadRequest(c.Writer, "INVALID_FACETS", "Invalid facets", err.Error())
+		return nil, false
+	}
+
+	return request, true
+}
+
+// parseBoolFlag interpreta un parámetro booleano opcional, que por defecto es false. Si el
+// valor es inválido escribe la respuesta de error y devuelve false como segundo valor.
+func parseBoolFlag(c *gin.Context, name string) (bool, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return false, true
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		response.BadRequest(c.Writer, "INVALID_"+strings.ToUpper(name), fmt.Sprintf("Invalid %s parameter", name), fmt.Sprintf("'%s' must be 'true' or 'false'", name))
+		return false, false
+	}
+
+	return value, true
+}
+
+// withFacets agrega las facetas calculadas a los metadatos de la respuesta
+func withFacets(meta *response.Meta, facets []domain.Facet) *response.Meta {
+	if facets != nil {
+		meta.Facets = facets
+	}
+
+	return meta
+}
+
+// parseSpecPredicates extrae los predicados spec.<nombre><op><valor> de la query string.
+// Se lee la query cruda porque operadores como ">=" no sobreviven al parseo clave=valor.
+func parseSpecPredicates(rawQuery string) ([]domain.SpecPredicate, error) {
+	var predicates []domain.SpecPredicate
+
+	for _, part := range strings.Split(rawQuery, "&") {
+		decoded, err := url.QueryUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("malformed query parameter '%s'", part)
+		}
+
+		if !strings.HasPrefix(decoded, specFilterPrefix) {
+			continue
+		}
+
+		predicate, err := domain.ParseSpecPredicate(strings.TrimPrefix(decoded, specFilterPrefix))
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	return predicates, nil
+}
+
+// QueryProducts godoc
+// @Summary Query products with a JSON document
+// @Description Retrieve products matching a boolean filter tree with sorting, pagination and field projection.
+// @Description Each filter node has exactly one of: and/or (list of nodes), not (node), field+op+value over id, name, description, brand, category, price, rating or available, spec+op+value over a specification, or text. Operators are =, !=, >, >=, <, <= and ~ (contains)
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body product.QueryProductsQuery true "Filter tree, sort keys, pagination and projected fields"
+// @Success 200 {object} response.APIResponse{data=[]domain.Product,meta=response.Meta} "Products retrieved successfully"
+// @Failure 400 {object} response.APIResponse "Malformed request body"
+// @Failure 422 {object} response.APIResponse "Invalid fields in the query document, listed in error.fields"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/query [post]
+func (pc *ProductController) QueryProducts(c *gin.Context) {
+	var query product.QueryProductsQuery
+
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&query); err != nil {
+		response.BadRequest(c.Writer, "INVALID_REQUEST_BODY", "Invalid request body", fmt.Sprintf("Please provide a valid JSON query document: %v", err))
+		return
+	}
+
+	result, err := pc.mediator.Send(c.Request.Context(), &query)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	queryResult, ok := result.(*product.QueryProductsResult)
+	if !ok {
+		response.InternalServerError(c.Writer, "INTERNAL_ERROR", "An unexpected error occurred", "Unexpected result type for products query")
+		return
+	}
+
+	response.SuccessWithMeta(c.Writer, queryResult.Items, "Products retrieved successfully", pageMeta(c, queryResult.Page))
 }
 
 // CompareProducts godoc
 // @Summary Compare multiple products
-// @Description Retrieve and compare multiple products by their IDs for feature comparison
+// @Description Retrieve and compare multiple products by their IDs, including a specification matrix aligned by name
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param ids query string true "Comma-separated product IDs" example("PHONE001,PHONE002,PHONE003")
-// @Success 200 {object} response.APIResponse "Products comparison retrieved successfully"
+// @Success 200 {object} response.APIResponse{data=ProductComparisonResponse} "Products comparison retrieved successfully"
 // @Failure 400 {object} response.APIResponse "Invalid product IDs or insufficient products for comparison"
 // @Failure 404 {object} response.APIResponse "One or more products not found"
 // @Failure 500 {object} response.APIResponse "Internal server error"
@@ -137,7 +541,64 @@ func (pc *ProductController) CompareProducts(c *gin.Context) {
 	}
 
 	// Parse and validate product IDs
-	ids := strings.Split(idsParam, ",")
+	cleanIDs, ok := validateComparisonIDs(c, strings.Split(idsParam, ","))
+	if !ok {
+		return
+	}
+
+	query := &product.CompareProductsQuery{ProductIDs: cleanIDs}
+	result, err := pc.mediator.Send(c.Request.Context(), query)
+
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result, "Products comparison retrieved successfully")
+}
+
+// ScoreProducts godoc
+// @Summary Score compared products with weighted preferences
+// @Description Rank the compared products by a weighted score. Each numeric criterion (price, rating or a specification name) is normalized across the compared set and weighted as requested
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body product.ScoreProductsQuery true "Product IDs and weights per criterion"
+// @Success 200 {object} response.APIResponse{data=ProductScoreResponse} "Products scored successfully"
+// @Failure 400 {object} response.APIResponse "Invalid request body or product IDs"
+// @Failure 422 {object} response.APIResponse "Invalid weights or criteria"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/compare/score [post]
+func (pc *ProductController) ScoreProducts(c *gin.Context) {
+	var query product.ScoreProductsQuery
+	if err := c.ShouldBindJSON(&query); err != nil {
+		response.BadRequest(c.Writer, "INVALID_REQUEST_BODY", "Invalid request body", "Please provide a JSON body with 'product_ids' and 'weights'")
+		return
+	}
+
+	cleanIDs, ok := validateComparisonIDs(c, query.ProductIDs)
+	if !ok {
+		return
+	}
+	query.ProductIDs = cleanIDs
+
+	if len(query.Weights) == 0 {
+		response.BadRequest(c.Writer, "MISSING_WEIGHTS", "Weights are required", "Please provide at least one weighted criterion in 'weights'")
+		return
+	}
+
+	result, err := pc.mediator.Send(c.Request.Context(), &query)
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, result, "Products scored successfully")
+}
+
+// validateComparisonIDs limpia los IDs de productos y valida que haya entre 2 y 10.
+// Si la validación falla escribe la respuesta de error y devuelve false.
+func validateComparisonIDs(c *gin.Context, ids []string) ([]string, bool) {
 	var cleanIDs []string
 
 	for _, id := range ids {
@@ -149,34 +610,35 @@ func (pc *ProductController) CompareProducts(c *gin.Context) {
 
 	if len(cleanIDs) < 2 {
 		response.BadRequest(c.Writer, "INSUFFICIENT_PRODUCTS", "At least 2 products required for comparison", "Please provide at least 2 valid product IDs separated by commas")
-		return
+		return nil, false
 	}
 
 	if len(cleanIDs) > 10 {
 		response.BadRequest(c.Writer, "TOO_MANY_PRODUCTS", "Too many products for comparison", "Please provide at most 10 products for comparison")
-		return
+		return nil, false
 	}
 
-	query := &product.CompareProductsQuery{ProductIDs: cleanIDs}
-	result, err := pc.mediator.Send(c.Request.Context(), query)
-
-	if err != nil {
-		response.HandleError(c.Writer, err)
-		return
-	}
-
-	response.Success(c.Writer, result, "Products comparison retrieved successfully")
+	return cleanIDs, true
 }
 
 // SearchProducts godoc
 // @Summary Search products
-// @Description Search for products by name, description, brand, or category
+// @Description Search for products by name, description, brand, or category.
+// @Description The query may combine free text with structured terms: field terms <field><op><value> over id, name, description, brand, category, price, rating, available, a specification declared by some product, or any specification with the spec. prefix (op is :, =, !=, >, >=, <, <= or ~); other field names fail with 400, quoted phrases, negation with - or NOT, and OR groups in parentheses, e.g. brand:Samsung price<1000 ram>=8 "S Pen"
 // @Tags products
 // @Accept json
 // @Produce json
-// @Param q query string true "Search query" example("Samsung Galaxy")
-// @Success 200 {object} response.APIResponse "Products search completed successfully"
-// @Failure 400 {object} response.APIResponse "Invalid or missing search query"
+// @Param q query string true "Search query, optionally with structured terms" example("brand:Samsung price<1000 \"S Pen\"")
+// @Param page query int false "Page number (starting at 1)" example(1)
+// @Param page_size query int false "Page size (1-100, default 20)" example(20)
+// @Param sort query string false "Comma-separated sort keys: price, rating, name or completeness, with optional '-' prefix or ':asc'/':desc' suffix" example("price:asc")
+// @Param cursor query string false "Opaque cursor from meta.next_cursor, alternative to page"
+// @Param facets query string false "Comma-separated facets computed over all matching products and returned in meta.facets: brand, category, price, rating or spec.<name>" example("brand,price,spec.RAM")
+// @Param price_buckets query string false "Ascending comma-separated price bucket limits for the price facet (default 250,500,1000,2000)" example("500,1000,2000")
+// @Param highlight query bool false "Return name and description snippets with the matched terms wrapped in <em>" example(true)
+// @Param explain query bool false "Return the matched terms per field and the BM25 score components of each hit" example(false)
+// @Success 200 {object} response.APIResponse{data=ProductSearchResponse,meta=response.Meta} "Products search completed successfully"
+// @Failure 400 {object} response.APIResponse "Invalid or missing search query, or syntax error in a structured query"
 // @Failure 500 {object} response.APIResponse "Internal server error"
 // @Router /products/search [get]
 func (pc *ProductController) SearchProducts(c *gin.Context) {
@@ -192,7 +654,91 @@ func (pc *ProductController) SearchProducts(c *gin.Context) {
 		return
 	}
 
-	query := &product.SearchProductsQuery{Query: searchQuery}
+	plan, err := domain.ParseSearchQuery(searchQuery)
+	if err != nil {
+		response.BadRequest(c.Writer, "INVALID_QUERY_SYNTAX", "Invalid search query syntax", err.Error())
+		return
+	}
+
+	sortKeys, pageRequest, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	facets, ok := parseFacets(c)
+	if !ok {
+		return
+	}
+
+	highlight, ok := parseBoolFlag(c, "highlight")
+	if !ok {
+		return
+	}
+
+	explain, ok := parseBoolFlag(c, "explain")
+	if !ok {
+		return
+	}
+
+	query := &product.SearchProductsQuery{
+		Query:       searchQuery,
+		Sort:        sortKeys,
+		Facets:      facets,
+		PageRequest: pageRequest,
+		Highlight:   highlight,
+		Explain:     explain,
+		Plan:        plan,
+	}
+	result, err := pc.mediator.Send(c.Request.Context(), query)
+
+	if err != nil {
+		response.HandleError(c.Writer, err)
+		return
+	}
+
+	searchResult, ok := result.(*product.SearchProductsResult)
+	if !ok {
+		response.InternalServerError(c.Writer, "INTERNAL_ERROR", "An unexpected error occurred", "Unexpected result type for products search")
+		return
+	}
+
+	response.SuccessWithMeta(c.Writer, searchResult, "Products search completed successfully", withFacets(pageMeta(c, searchResult.Page), searchResult.Facets))
+}
+
+// SuggestProducts godoc
+// @Summary Autocomplete search queries
+// @Description Suggest product names, brands and categories with a word starting with the typed prefix, ranked by popularity
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param q query string true "Prefix typed by the user (at least 1 character)" example("gal")
+// @Param limit query int false "Maximum number of suggestions (1-10, default 5)" example(5)
+// @Success 200 {object} response.APIResponse{data=ProductSuggestResponse} "Suggestions retrieved successfully"
+// @Failure 400 {object} response.APIResponse "Invalid or missing prefix or limit"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /products/suggest [get]
+func (pc *ProductController) SuggestProducts(c *gin.Context) {
+	// A diferencia de la búsqueda, el autocompletado acepta un único carácter
+	prefix := strings.TrimSpace(c.Query("q"))
+	if prefix == "" {
+		response.BadRequest(c.Writer, "MISSING_SUGGEST_QUERY", "Suggest query is required", "Please provide a prefix in the 'q' parameter")
+		return
+	}
+
+	limit := domain.DefaultSuggestionLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil || value < 1 || value > domain.MaxSuggestionLimit {
+			response.BadRequest(c.Writer, "INVALID_LIMIT", "Invalid limit", fmt.Sprintf("Limit must be an integer between 1 and %d", domain.MaxSuggestionLimit))
+			return
+		}
+		limit = value
+	}
+
+	query := &product.SuggestProductsQuery{
+		Query: prefix,
+		Limit: limit,
+	}
 	result, err := pc.mediator.Send(c.Request.Context(), query)
 
 	if err != nil {
@@ -200,16 +746,16 @@ func (pc *ProductController) SearchProducts(c *gin.Context) {
 		return
 	}
 
-	response.Success(c.Writer, result, "Products search completed successfully")
+	response.Success(c.Writer, result, "Suggestions retrieved successfully")
 }
 
 // GetCategories godoc
-// @Summary Get all available categories
-// @Description Retrieve a list of all available product categories
+// @Summary Get the category tree
+// @Description Retrieve the category tree defined by the taxonomy, with the number of products in each subtree. Products belong to leaf categories; filtering by any category includes its subcategories
 // @Tags metadata
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.APIResponse "Categories retrieved successfully"
+// @Success 200 {object} response.APIResponse{data=CategoriesResponse} "Categories retrieved successfully"
 // @Failure 500 {object} response.APIResponse "Internal server error"
 // @Router /categories [get]
 func (pc *ProductController) GetCategories(c *gin.Context) {
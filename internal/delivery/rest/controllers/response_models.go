@@ -13,25 +13,56 @@ type ProductComparisonResponse struct {
 	
 	// Lista de IDs de productos solicitados
 	RequestedIDs []string `json:"requested_ids"`
+
+	// Matriz de especificaciones alineadas por nombre
+	Matrix *domain.ComparisonMatrix `json:"matrix"`
+}
+
+// ProductScoreResponse representa la respuesta para la API de puntuación ponderada
+// @Description Response model for weighted product scoring
+type ProductScoreResponse struct {
+	// Productos ordenados de mejor a peor puntuación
+	Ranking []domain.ProductScore `json:"ranking"`
+
+	// Pesos utilizados por criterio
+	Weights map[string]float64 `json:"weights"`
+
+	// Lista de IDs de productos solicitados
+	RequestedIDs []string `json:"requested_ids"`
 }
 
 // ProductSearchResponse representa la respuesta para la API de búsqueda de productos
 // @Description Response model for product search
 type ProductSearchResponse struct {
-	// Lista de productos que coinciden con la búsqueda
-	Products []*domain.Product `json:"products"`
+	// Lista de productos que coinciden con la búsqueda, con su puntuación de relevancia
+	Products []domain.SearchHit `json:"products"`
 	
 	// Consulta de búsqueda utilizada
 	Query string `json:"query" example:"Samsung Galaxy"`
 	
-	// Número de productos encontrados
+	// Número total de productos encontrados (todas las páginas)
 	Count int `json:"count" example:"2"`
+	
+	// Consultas corregidas propuestas cuando hay pocos o ningún resultado
+	Suggestions []string `json:"suggestions" example:"samsung galaxy"`
+	
+	// ID de la búsqueda registrada, que se envía al registrar un clic sobre sus resultados
+	SearchID string `json:"search_id,omitempty" example:"srch-4f9c2a7e1b3d5f60a8c4e2d1b7f3a9c5"`
+}
+
+// ProductSuggestResponse representa la respuesta para la API de autocompletado
+// @Description Response model for search autocomplete
+type ProductSuggestResponse struct {
+	// Prefijo consultado
+	Query string `json:"query" example:"gal"`
+	
+	// Sugerencias de más a menos popular
+	Suggestions []domain.Suggestion `json:"suggestions"`
 }
 
-// CategoriesResponse representa la respuesta para la API de categorías
-// @Description Response model for categories
-// @Example ["Smartphones", "Laptops", "Audífonos"]
-type CategoriesResponse []string
+// CategoriesResponse representa la respuesta para la API de categorías: las raíces del árbol
+// @Description Response model for the category tree
+type CategoriesResponse []domain.CategoryTreeNode
 
 // BrandsResponse representa la respuesta para la API de marcas  
 // @Description Response model for brands
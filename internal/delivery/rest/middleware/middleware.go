@@ -16,6 +16,8 @@ Middlewares implementados:
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net/http"
@@ -31,7 +33,8 @@ func CORSMiddleware() gin.HandlerFunc {
 		// En producción, especificar orígenes permitidos explícitamente
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match")
+		c.Header("Access-Control-Expose-Headers", "ETag, X-Request-ID")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		// Manejar requests preflight
@@ -65,7 +68,9 @@ func LoggerMiddleware() gin.HandlerFunc {
 	})
 }
 
-// RequestIDMiddleware agrega un ID único de request a cada solicitud
+// RequestIDMiddleware agrega un ID único de request a cada solicitud. El cliente puede
+// enviar el suyo en el header X-Request-ID, por lo que solo sirve para trazabilidad: los clics
+// de búsqueda se asocian al search_id que genera el servidor.
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
@@ -109,7 +114,12 @@ func SecurityHeadersMiddleware() gin.HandlerFunc {
 	}
 }
 
-// generateRequestID genera un ID de request simple (en producción, usar UUID)
+// generateRequestID genera un ID de request aleatorio; si no hay entropía disponible, recurre
+// al instante actual
 func generateRequestID() string {
-	return fmt.Sprintf("req-%d", time.Now().UnixNano())
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("req-%d", time.Now().UnixNano())
+	}
+	return "req-" + hex.EncodeToString(buf[:])
 }
@@ -0,0 +1,217 @@
+package json
+
+import (
+	"sort"
+	"strings"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/search"
+)
+
+// catalog agrupa los productos cargados junto con los índices construidos sobre ellos.
+// Es inmutable una vez construido, por lo que puede consultarse concurrentemente.
+type catalog struct {
+	// Productos en el orden del archivo
+	products []*domain.Product
+
+	// Índice de productos por ID
+	byID map[string]*domain.Product
+
+	// Posiciones de los productos por categoría, en orden ascendente. Cada producto se indexa
+	// bajo el ID y el nombre normalizados de su categoría y de todas sus categorías ancestro.
+	byCategory map[string][]int
+
+	// Posiciones de los productos por marca (en minúsculas), en orden ascendente
+	byBrand map[string][]int
+
+	// Posiciones de los productos ordenadas por precio ascendente
+	byPrice []int
+
+	// Árbol de categorías con la cantidad de productos de cada subárbol
+	categoryTree []domain.CategoryTreeNode
+
+	// Marcas únicas en orden de aparición
+	brands []string
+
+	// Nombres de las especificaciones de los productos, en minúsculas
+	specNames map[string]bool
+
+	// Índice invertido para la búsqueda por texto
+	text *search.Index
+
+	// Árbol de prefijos para el autocompletado
+	completer *search.Completer
+
+	// Productos tal como están en el archivo, incluidos los descartados por inválidos
+	source []*domain.Product
+
+	// Resultado de la validación de los productos al cargar el archivo
+	validation *domain.CatalogValidationReport
+}
+
+// newCatalog construye los índices del catálogo a partir de productos ya preparados con
+// validation.PrepareCatalog. Sin taxonomía, cada categoría es una raíz sin subcategorías.
+func newCatalog(products []*domain.Product, taxonomy *domain.Taxonomy) *catalog {
+	c := &catalog{
+		products:   products,
+		byID:       make(map[string]*domain.Product, len(products)),
+		byCategory: make(map[string][]int),
+		byBrand:    make(map[string][]int),
+		byPrice:    make([]int, len(products)),
+		specNames:  make(map[string]bool),
+	}
+
+	for i, product := range products {
+		// Ante IDs duplicados prevalece el primero, igual que en una búsqueda secuencial
+		if _, exists := c.byID[product.ID]; !exists {
+			c.byID[product.ID] = product
+		}
+
+		seen := make(map[string]bool, 2*len(product.Breadcrumbs))
+		for _, ref := range product.Breadcrumbs {
+			for _, key := range []string{categoryKey(ref.ID), categoryKey(ref.Name)} {
+				if !seen[key] {
+					seen[key] = true
+					c.byCategory[key] = append(c.byCategory[key], i)
+				}
+			}
+		}
+
+		brand := strings.ToLower(product.Brand)
+		if _, exists := c.byBrand[brand]; !exists {
+			c.brands = append(c.brands, product.Brand)
+		}
+		c.byBrand[brand] = append(c.byBrand[brand], i)
+
+		for _, spec := range product.Specifications {
+			c.specNames[specNameKey(spec.Name)] = true
+		}
+
+		c.byPrice[i] = i
+	}
+
+	sort.SliceStable(c.byPrice, func(a, b int) bool {
+		return products[c.byPrice[a]].Price < products[c.byPrice[b]].Price
+	})
+
+	c.categoryTree = buildCategoryTree(products, taxonomy)
+	c.text = search.NewIndex(products)
+	c.completer = search.NewCompleter(products)
+
+	return c
+}
+
+// categoryKey normaliza el ID o el nombre de una categoría para indexarla
+func categoryKey(category string) string {
+	return domain.FoldText(strings.TrimSpace(category))
+}
+
+// buildCategoryTree construye el árbol de categorías con la cantidad de productos de cada
+// subárbol. Sin taxonomía, cada categoría de los productos es una raíz, en orden de aparición.
+func buildCategoryTree(products []*domain.Product, taxonomy *domain.Taxonomy) []domain.CategoryTreeNode {
+	counts := make(map[string]int)
+	for _, product := range products {
+		for _, ref := range product.Breadcrumbs {
+			counts[categoryKey(ref.ID)]++
+		}
+	}
+
+	if taxonomy == nil {
+		var roots []domain.CategoryTreeNode
+		for _, product := range products {
+			id := product.Breadcrumbs[0].ID
+			if count, pending := counts[categoryKey(id)]; pending {
+				roots = append(roots, domain.CategoryTreeNode{ID: id, Name: product.Category, ProductCount: count})
+				delete(counts, categoryKey(id))
+			}
+		}
+		return roots
+	}
+
+	return taxonomy.CategoryTree(counts)
+}
+
+// candidates devuelve las posiciones de los productos que pueden cumplir el filtro según
+// los índices de categoría, marca y precio, en orden ascendente. El resultado es un
+// superconjunto de los productos que cumplen el filtro; all indica que no se pudo acotar.
+func (c *catalog) candidates(filter domain.ProductFilter) (positions []int, all bool) {
+	all = true
+
+	if filter.Category != "" {
+		positions, all = c.byCategory[categoryKey(filter.Category)], false
+	}
+
+	if filter.Brand != "" {
+		brand := c.byBrand[strings.ToLower(filter.Brand)]
+		if all {
+			positions, all = brand, false
+		} else {
+			positions = intersectSorted(positions, brand)
+		}
+	}
+
+	if filter.MinPrice > 0 || filter.MaxPrice > 0 {
+		priced := c.priceRange(filter.MinPrice, filter.MaxPrice)
+
+		// Si el rango de precios es más selectivo se parte de él
+		if all || len(priced) < len(positions) {
+			sort.Ints(priced)
+			if all {
+				positions, all = priced, false
+			} else {
+				positions = intersectSorted(positions, priced)
+			}
+		}
+	}
+
+	return positions, all
+}
+
+// priceRange devuelve las posiciones de los productos con precio dentro del rango indicado.
+// Un límite en 0 no acota el rango. El resultado es una copia ordenada por precio.
+func (c *catalog) priceRange(min, max float64) []int {
+	start := 0
+	if min > 0 {
+		start = sort.Search(len(c.byPrice), func(i int) bool {
+			return c.products[c.byPrice[i]].Price >= min
+		})
+	}
+
+	end := len(c.byPrice)
+	if max > 0 {
+		end = sort.Search(len(c.byPrice), func(i int) bool {
+			return c.products[c.byPrice[i]].Price > max
+		})
+	}
+
+	if start >= end {
+		return nil
+	}
+
+	return append([]int(nil), c.byPrice[start:end]...)
+}
+
+// intersectSorted devuelve la intersección de dos listas ordenadas de posiciones
+func intersectSorted(a, b []int) []int {
+	var result []int
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+// specNameKey normaliza el nombre de una especificación igual que los filtros de especificación
+func specNameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,28 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"meli-products-api/domain"
+)
+
+// LoadComparisonRules carga y valida el conjunto de reglas de comparación desde un archivo JSON
+func LoadComparisonRules(filePath string) (*domain.ComparisonRuleSet, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comparison rules file: %w", err)
+	}
+
+	var rules domain.ComparisonRuleSet
+	if err := json.Unmarshal(bytes, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse comparison rules JSON: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid comparison rules: %w", err)
+	}
+
+	return &rules, nil
+}
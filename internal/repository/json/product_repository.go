@@ -6,12 +6,20 @@ Este repositorio proporciona una implementación completa de domain.ProductRepos
 que carga y mantiene los productos en memoria para un acceso rápido. Es ideal para 
 desarrollo, demos y aplicaciones que no requieren persistencia compleja.
 
+Al cargar el archivo se construyen índices en memoria (por ID, categoría, marca,
+precio, texto y prefijos) para que las consultas no recorran el catálogo completo.
+
 Características:
-- Carga de datos desde archivos JSON al inicializar
-- Operaciones de búsqueda y filtrado en memoria
+- Carga de datos desde archivos JSON al inicializar y recarga sin reiniciar
+- Operaciones de búsqueda y filtrado en memoria apoyadas en índices
 - Manejo de errores específicos del dominio
-- Soporte para búsqueda por texto en múltiples campos
-- Extracción de metadatos (categorías y marcas únicas)
+- Búsqueda por relevancia sobre un índice invertido (ver internal/search)
+- Diccionario de sinónimos para la búsqueda, recargable sin reiniciar
+- Extracción de metadatos (árbol de categorías y marcas únicas)
+- Taxonomía de categorías opcional con migas de pan y filtrado por subárbol
+- Esquemas de especificaciones por categoría con validación y puntaje de completitud
+- Validación de las etiquetas validate de los productos en modo estricto o permisivo
+- Interpretación de especificaciones tipadas (número con unidad, booleano o texto)
 */
 package json
 
@@ -20,15 +28,41 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
+	"sync"
+	"sync/atomic"
 
 	"meli-products-api/domain"
+	"meli-products-api/internal/search"
+	"meli-products-api/internal/validation"
 )
 
 // ProductRepository implementa domain.ProductRepository utilizando archivos JSON
 type ProductRepository struct {
 	filePath string
-	products []*domain.Product
+
+	// Catálogo vigente. Se reemplaza completo de forma atómica, por lo que cada operación lo
+	// lee una sola vez y trabaja sobre un catálogo consistente aunque se recargue en paralelo.
+	catalog atomic.Pointer[catalog]
+
+	// Serializa las recargas y los cambios de configuración que reconstruyen el catálogo
+	mu sync.Mutex
+
+	// Taxonomía de categorías; nil si las categorías son planas
+	taxonomy *domain.Taxonomy
+
+	// Esquemas de especificaciones por categoría; nil si no se validan las especificaciones
+	schemas *domain.SpecSchemaSet
+
+	// Modo de validación de los productos al cargar el archivo
+	validationMode domain.CatalogValidationMode
+
+	// Diccionario de sinónimos aplicado al índice de cada catálogo construido
+	synonyms *search.Synonyms
+
+	// Última versión de cada producto eliminado, para que uno nuevo con el mismo ID continúe
+	// su numeración. El archivo de datos no guarda las eliminaciones, por lo que se conserva
+	// mientras el proceso está en ejecución.
+	deleted map[string]int64
 }
 
 // NewProductRepository crea un nuevo repositorio de productos basado en JSON
@@ -45,60 +79,117 @@ func NewProductRepository(filePath string) (*ProductRepository, error) {
 	return repo, nil
 }
 
-// loadProducts carga los productos desde el archivo JSON a memoria
-func (r *ProductRepository) loadProducts() error {
-	file, err := os.Open(r.filePath)
+// LoadProducts lee los productos de un archivo JSON, con sus especificaciones interpretadas.
+// Los productos sin versión empiezan en la versión inicial. No valida los productos.
+func LoadProducts(filePath string) ([]*domain.Product, error) {
+	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open products file: %w", err)
+		return nil, fmt.Errorf("failed to open products file: %w", err)
 	}
 	defer file.Close()
 
 	bytes, err := io.ReadAll(file)
 	if err != nil {
-		return fmt.Errorf("failed to read products file: %w", err)
+		return nil, fmt.Errorf("failed to read products file: %w", err)
+	}
+
+	var products []*domain.Product
+	if err := json.Unmarshal(bytes, &products); err != nil {
+		return nil, fmt.Errorf("failed to parse products JSON: %w", err)
+	}
+
+	for _, product := range products {
+		product.ParseSpecifications()
+		if product.Version < domain.InitialProductVersion {
+			product.Version = domain.InitialProductVersion
+		}
+	}
+
+	return products, nil
+}
+
+// loadProducts carga los productos desde el archivo JSON a memoria
+func (r *ProductRepository) loadProducts() error {
+	products, err := LoadProducts(r.filePath)
+	if err != nil {
+		return err
 	}
 
-	if err := json.Unmarshal(bytes, &r.products); err != nil {
-		return fmt.Errorf("failed to parse products JSON: %w", err)
+	// Construir el catálogo aparte y reemplazar el vigente solo si es válido
+	catalog, err := r.prepare(products, r.validationMode, r.taxonomy, r.schemas)
+	if err != nil {
+		return fmt.Errorf("invalid products: %w", err)
 	}
+	r.catalog.Store(catalog)
 
 	return nil
 }
 
+// Reload vuelve a leer el archivo de productos, lo valida y construye el nuevo catálogo
+// aparte, aplicando la taxonomía, los esquemas, los sinónimos y el modo de validación
+// vigentes. El reemplazo es atómico: cada solicitud usa el catálogo anterior o el nuevo,
+// nunca uno a medio cargar. Si el archivo es inválido devuelve un error y conserva el
+// catálogo anterior.
+func (r *ProductRepository) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.loadProducts()
+}
+
+// prepare valida los productos del archivo según el modo indicado y construye con los
+// válidos un catálogo con la configuración indicada y el diccionario de sinónimos vigente.
+// Un producto que no cumple la taxonomía o el esquema de especificaciones de su categoría
+// hace fallar la carga, salvo en modo permisivo, que lo descarta e informa en el reporte
+// igual que a los que no cumplen sus etiquetas validate. Las especificaciones de los
+// productos ya deben estar interpretadas. Debe llamarse con r.mu tomado.
+func (r *ProductRepository) prepare(source []*domain.Product, mode domain.CatalogValidationMode, taxonomy *domain.Taxonomy, schemas *domain.SpecSchemaSet) (*catalog, error) {
+	products, report, err := validation.PrepareCatalog(source, mode, validation.NewCatalogRules(taxonomy, schemas))
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := newCatalog(products, taxonomy)
+	catalog.source = source
+	catalog.validation = report
+	catalog.text.SetSynonyms(r.synonyms)
+
+	return catalog, nil
+}
+
 // GetByID obtiene un producto por su ID
 func (r *ProductRepository) GetByID(id string) (*domain.Product, error) {
 	if id == "" {
 		return nil, &domain.InvalidProductIDError{ID: id}
 	}
 
-	for _, product := range r.products {
-		if product.ID == id {
-			return product, nil
-		}
+	if product, ok := r.catalog.Load().byID[id]; ok {
+		return product, nil
 	}
 
 	return nil, &domain.ProductNotFoundError{ID: id}
 }
 
-// GetAll obtiene todos los productos con filtrado opcional
-func (r *ProductRepository) GetAll(category string, minPrice, maxPrice float64) ([]*domain.Product, error) {
+// GetAll obtiene todos los productos que cumplen el filtro
+func (r *ProductRepository) GetAll(filter domain.ProductFilter) ([]*domain.Product, error) {
 	var filteredProducts []*domain.Product
 
-	for _, product := range r.products {
-		// Filtrar por categoría si está especificada
-		if category != "" && !strings.EqualFold(product.Category, category) {
-			continue
+	// Los índices acotan los candidatos; el filtro completo se evalúa sobre ellos
+	catalog := r.catalog.Load()
+	positions, all := catalog.candidates(filter)
+	if all {
+		for _, product := range catalog.products {
+			if filter.Matches(product) {
+				filteredProducts = append(filteredProducts, product)
+			}
 		}
+		return filteredProducts, nil
+	}
 
-		// Filtrar por rango de precio si está especificado
-		if minPrice > 0 && product.Price < minPrice {
-			continue
-		}
-		if maxPrice > 0 && product.Price > maxPrice {
-			continue
+	for _, position := range positions {
+		if product := catalog.products[position]; filter.Matches(product) {
+			filteredProducts = append(filteredProducts, product)
 		}
-
-		filteredProducts = append(filteredProducts, product)
 	}
 
 	return filteredProducts, nil
@@ -133,59 +224,77 @@ func (r *ProductRepository) GetByIDs(ids []string) ([]*domain.Product, error) {
 	return products, nil
 }
 
-// Search busca productos por nombre o descripción
+// Search busca productos por nombre, marca, categoría o descripción y los devuelve
+// ordenados por relevancia
 func (r *ProductRepository) Search(query string) ([]*domain.Product, error) {
 	if query == "" {
-		return r.GetAll("", 0, 0)
+		return r.GetAll(domain.ProductFilter{})
+	}
+
+	hits, err := r.SearchRanked(domain.SearchRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, len(hits))
+	for i, hit := range hits {
+		products[i] = hit.Product
 	}
 
-	var matchingProducts []*domain.Product
-	queryLower := strings.ToLower(query)
+	return products, nil
+}
 
-	for _, product := range r.products {
-		// Buscar en nombre, descripción, marca y categoría
-		if strings.Contains(strings.ToLower(product.Name), queryLower) ||
-			strings.Contains(strings.ToLower(product.Description), queryLower) ||
-			strings.Contains(strings.ToLower(product.Brand), queryLower) ||
-			strings.Contains(strings.ToLower(product.Category), queryLower) {
-			matchingProducts = append(matchingProducts, product)
+// SearchRanked busca productos que cumplen el filtro de la búsqueda y devuelve cada
+// coincidencia con su puntuación de relevancia
+func (r *ProductRepository) SearchRanked(request domain.SearchRequest) ([]domain.SearchHit, error) {
+	hits := r.catalog.Load().text.Search(request.Query)
+
+	filtered := hits[:0]
+	for _, hit := range hits {
+		if request.Filter.Matches(hit.Product) {
+			filtered = append(filtered, hit)
 		}
 	}
 
-	return matchingProducts, nil
+	return filtered, nil
 }
 
-// GetProductCount devuelve el número total de productos
-func (r *ProductRepository) GetProductCount() int {
-	return len(r.products)
+// Highlight devuelve el nombre y la descripción del producto con los términos de la consulta marcados
+func (r *ProductRepository) Highlight(query string, product *domain.Product) (map[string]string, error) {
+	return r.catalog.Load().text.Highlight(query, product), nil
 }
 
-// GetCategories devuelve todas las categorías únicas
-func (r *ProductRepository) GetCategories() []string {
-	categoryMap := make(map[string]bool)
-	var categories []string
+// Explain devuelve las coincidencias del producto con la consulta y los componentes de su puntuación
+func (r *ProductRepository) Explain(query string, product *domain.Product) (*domain.SearchExplanation, error) {
+	return r.catalog.Load().text.Explain(query, product), nil
+}
 
-	for _, product := range r.products {
-		if !categoryMap[product.Category] {
-			categoryMap[product.Category] = true
-			categories = append(categories, product.Category)
-		}
-	}
+// SuggestQueries propone consultas corregidas a partir del vocabulario del catálogo
+func (r *ProductRepository) SuggestQueries(query string, limit int) ([]string, error) {
+	return r.catalog.Load().text.Suggest(query, limit), nil
+}
 
-	return categories
+// Autocomplete sugiere productos, marcas y categorías que comienzan con el prefijo
+func (r *ProductRepository) Autocomplete(prefix string, limit int) ([]domain.Suggestion, error) {
+	return r.catalog.Load().completer.Complete(prefix, limit), nil
 }
 
-// GetBrands devuelve todas las marcas únicas
-func (r *ProductRepository) GetBrands() []string {
-	brandMap := make(map[string]bool)
-	var brands []string
+// GetProductCount devuelve el número total de productos
+func (r *ProductRepository) GetProductCount() int {
+	return len(r.catalog.Load().products)
+}
 
-	for _, product := range r.products {
-		if !brandMap[product.Brand] {
-			brandMap[product.Brand] = true
-			brands = append(brands, product.Brand)
-		}
-	}
+// GetCategoryTree devuelve el árbol de categorías con la cantidad de productos de cada subárbol
+func (r *ProductRepository) GetCategoryTree() []domain.CategoryTreeNode {
+	return r.catalog.Load().categoryTree
+}
 
-	return brands
+// GetBrands devuelve todas las marcas únicas
+func (r *ProductRepository) GetBrands() []string {
+	return append([]string(nil), r.catalog.Load().brands...)
 }
+
+// HasSpecification indica si algún producto del catálogo declara la especificación
+func (r *ProductRepository) HasSpecification(name string) (bool, error) {
+	return r.catalog.Load().specNames[specNameKey(name)], nil
+}
\ No newline at end of file
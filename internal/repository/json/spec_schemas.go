@@ -0,0 +1,47 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"meli-products-api/domain"
+)
+
+// LoadSpecSchemas carga y valida los esquemas de especificaciones por categoría desde un archivo JSON
+func LoadSpecSchemas(filePath string) (*domain.SpecSchemaSet, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec schemas file: %w", err)
+	}
+
+	var schemas domain.SpecSchemaSet
+	if err := json.Unmarshal(bytes, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to parse spec schemas JSON: %w", err)
+	}
+
+	if err := schemas.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec schemas: %w", err)
+	}
+
+	return &schemas, nil
+}
+
+// SetSpecSchemas valida las especificaciones de los productos contra el esquema de su
+// categoría y les asigna su puntaje de completitud. Devuelve un error, y conserva el catálogo
+// anterior, si algún producto no informa una especificación obligatoria o la informa con otro
+// tipo o unidad. Los esquemas se aplican también a las recargas posteriores del archivo de
+// productos.
+func (r *ProductRepository) SetSpecSchemas(schemas *domain.SpecSchemaSet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	catalog, err := r.prepare(r.catalog.Load().source, r.validationMode, r.taxonomy, schemas)
+	if err != nil {
+		return fmt.Errorf("products do not match the spec schemas: %w", err)
+	}
+
+	r.schemas = schemas
+	r.catalog.Store(catalog)
+	return nil
+}
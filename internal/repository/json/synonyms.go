@@ -0,0 +1,40 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/search"
+)
+
+// LoadSynonyms carga y valida el diccionario de sinónimos desde un archivo JSON
+func LoadSynonyms(filePath string) (*domain.SynonymSet, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synonyms file: %w", err)
+	}
+
+	var synonyms domain.SynonymSet
+	if err := json.Unmarshal(bytes, &synonyms); err != nil {
+		return nil, fmt.Errorf("failed to parse synonyms JSON: %w", err)
+	}
+
+	if err := synonyms.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid synonyms: %w", err)
+	}
+
+	return &synonyms, nil
+}
+
+// SetSynonyms reemplaza el diccionario de sinónimos aplicado al expandir las búsquedas.
+// El reemplazo es atómico: cada búsqueda usa el diccionario anterior o el nuevo, nunca una mezcla.
+// El diccionario se aplica también a los catálogos que se construyan al recargar los productos.
+func (r *ProductRepository) SetSynonyms(synonyms *domain.SynonymSet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.synonyms = search.NewSynonyms(synonyms)
+	r.catalog.Load().text.SetSynonyms(r.synonyms)
+}
@@ -0,0 +1,46 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"meli-products-api/domain"
+)
+
+// LoadTaxonomy carga y valida la taxonomía de categorías desde un archivo JSON
+func LoadTaxonomy(filePath string) (*domain.Taxonomy, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read taxonomy file: %w", err)
+	}
+
+	var taxonomy domain.Taxonomy
+	if err := json.Unmarshal(bytes, &taxonomy); err != nil {
+		return nil, fmt.Errorf("failed to parse taxonomy JSON: %w", err)
+	}
+
+	if err := taxonomy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid taxonomy: %w", err)
+	}
+
+	return &taxonomy, nil
+}
+
+// SetTaxonomy organiza las categorías del catálogo según la taxonomía: cada producto recibe
+// sus migas de pan y los filtros por categoría abarcan todas las subcategorías. Devuelve un
+// error, y conserva el catálogo anterior, si algún producto no pertenece a una hoja del árbol.
+// La taxonomía se aplica también a las recargas posteriores del archivo de productos.
+func (r *ProductRepository) SetTaxonomy(taxonomy *domain.Taxonomy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	catalog, err := r.prepare(r.catalog.Load().source, r.validationMode, taxonomy, r.schemas)
+	if err != nil {
+		return fmt.Errorf("products do not match the taxonomy: %w", err)
+	}
+
+	r.taxonomy = taxonomy
+	r.catalog.Store(catalog)
+	return nil
+}
@@ -0,0 +1,30 @@
+package json
+
+import (
+	"fmt"
+
+	"meli-products-api/domain"
+)
+
+// SetValidationMode valida los productos del archivo según el modo indicado. En modo
+// estricto devuelve un error, y conserva el catálogo anterior, si algún producto es inválido;
+// en modo permisivo descarta los productos inválidos y los informa en GetValidationReport.
+// El modo se aplica también a las recargas y escrituras posteriores.
+func (r *ProductRepository) SetValidationMode(mode domain.CatalogValidationMode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	catalog, err := r.prepare(r.catalog.Load().source, mode, r.taxonomy, r.schemas)
+	if err != nil {
+		return fmt.Errorf("invalid products: %w", err)
+	}
+
+	r.validationMode = mode
+	r.catalog.Store(catalog)
+	return nil
+}
+
+// GetValidationReport devuelve el resultado de la validación de la última carga del catálogo
+func (r *ProductRepository) GetValidationReport() *domain.CatalogValidationReport {
+	return r.catalog.Load().validation
+}
@@ -0,0 +1,35 @@
+package json
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// WatchFile consulta cada interval la fecha de modificación y el tamaño del archivo y llama
+// a onChange cuando alguno cambia, hasta que se cancela el contexto. Un archivo que
+// desaparece no dispara onChange; se vuelve a detectar cuando reaparece con otro contenido.
+func WatchFile(ctx context.Context, filePath string, interval time.Duration, onChange func()) {
+	last, _ := os.Stat(filePath)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			continue
+		}
+
+		if last == nil || !info.ModTime().Equal(last.ModTime()) || info.Size() != last.Size() {
+			last = info
+			onChange()
+		}
+	}
+}
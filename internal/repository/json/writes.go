@@ -0,0 +1,268 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/validation"
+)
+
+// storedProduct es la representación de un producto en el archivo de datos. Omite los campos
+// que se derivan al cargar el catálogo: migas de pan y completitud.
+type storedProduct struct {
+	*domain.Product
+
+	Specifications []storedSpecification    `json:"specifications"`
+	Breadcrumbs    []domain.CategoryRef     `json:"breadcrumbs,omitempty"`
+	Completeness   *domain.SpecCompleteness `json:"completeness,omitempty"`
+}
+
+// storedSpecification es la representación de una especificación en el archivo de datos;
+// la unidad vacía se escribe igual que en el archivo original
+type storedSpecification struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Unit  string `json:"unit"`
+}
+
+// Create agrega un producto nuevo al catálogo y al archivo de datos, con la versión inicial o,
+// si el ID perteneció a un producto eliminado, con la siguiente a la de ese producto.
+// Devuelve ProductAlreadyExistsError si el ID ya existe y ValidationErrors si el producto no
+// cumple sus etiquetas validate, la taxonomía o el esquema de especificaciones de su categoría.
+func (r *ProductRepository) Create(product *domain.Product) (*domain.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.catalog.Load()
+	if product.ID != "" && indexOf(current.source, product.ID) >= 0 {
+		return nil, &domain.ProductAlreadyExistsError{ID: product.ID}
+	}
+
+	created := writable(product)
+	created.Version = domain.RecreatedProductVersion(r.deleted[product.ID])
+
+	source := make([]*domain.Product, len(current.source), len(current.source)+1)
+	copy(source, current.source)
+	source = append(source, created)
+
+	return r.write(source, len(source)-1)
+}
+
+// Update reemplaza el producto con el mismo ID en el catálogo y en el archivo de datos, con
+// las mismas validaciones que Create, e incrementa su versión. Devuelve ProductNotFoundError
+// si el ID no existe y ProductVersionConflictError si product.Version no es cero ni la
+// versión actual.
+func (r *ProductRepository) Update(product *domain.Product) (*domain.Product, error) {
+	if product.ID == "" {
+		return nil, &domain.InvalidProductIDError{ID: product.ID}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.catalog.Load()
+	index := indexOf(current.source, product.ID)
+	if index < 0 {
+		return nil, &domain.ProductNotFoundError{ID: product.ID}
+	}
+
+	// La versión recibida, si la hay, debe ser la vigente: así dos ediciones simultáneas del
+	// mismo producto no se pisan
+	version := current.source[index].Version
+	if product.Version != 0 && product.Version != version {
+		return nil, &domain.ProductVersionConflictError{ID: product.ID, Expected: product.Version, Current: version}
+	}
+
+	updated := writable(product)
+	updated.Version = version + 1
+
+	source := append([]*domain.Product(nil), current.source...)
+	source[index] = updated
+
+	return r.write(source, index)
+}
+
+// Delete elimina el producto del catálogo y del archivo de datos y lo devuelve; su versión se
+// conserva para que un producto nuevo con el mismo ID no la repita. Devuelve
+// ProductNotFoundError si el ID no existe.
+func (r *ProductRepository) Delete(id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, &domain.InvalidProductIDError{ID: id}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.catalog.Load()
+	index := indexOf(current.source, id)
+	if index < 0 {
+		return nil, &domain.ProductNotFoundError{ID: id}
+	}
+
+	source := make([]*domain.Product, 0, len(current.source)-1)
+	source = append(source, current.source[:index]...)
+	source = append(source, current.source[index+1:]...)
+
+	catalog, err := r.prepare(source, r.validationMode, r.taxonomy, r.schemas)
+	if err != nil {
+		return nil, fmt.Errorf("invalid products: %w", err)
+	}
+	if err := r.persist(source); err != nil {
+		return nil, err
+	}
+	r.catalog.Store(catalog)
+
+	if r.deleted == nil {
+		r.deleted = make(map[string]int64)
+	}
+	r.deleted[id] = current.source[index].Version
+
+	// Se devuelve el producto tal como estaba en el catálogo, si no había sido descartado
+	if deleted, ok := current.byID[id]; ok {
+		return deleted, nil
+	}
+	return current.source[index], nil
+}
+
+// write valida el producto escrito en la posición indicada, construye el nuevo catálogo,
+// persiste el archivo y recién entonces reemplaza el catálogo vigente. Debe llamarse con
+// r.mu tomado.
+func (r *ProductRepository) write(source []*domain.Product, index int) (*domain.Product, error) {
+	product := source[index]
+
+	// Los productos escritos se validan siempre, sin importar el modo de validación de la carga
+	if errs := validation.ValidateProduct(product, ""); len(errs) > 0 {
+		return nil, errs
+	}
+
+	catalog, err := r.prepare(source, r.validationMode, r.taxonomy, r.schemas)
+	if err != nil {
+		return nil, relativeTo(err, fmt.Sprintf("products[%d]", index))
+	}
+
+	// En modo permisivo el catálogo se construye igual sin el producto si no cumple la
+	// taxonomía o el esquema de su categoría, pero la escritura se rechaza
+	for _, rejected := range catalog.validation.Rejected {
+		if rejected.Index == index {
+			return nil, relativeTo(domain.ValidationErrors(rejected.Errors), fmt.Sprintf("products[%d]", index))
+		}
+	}
+
+	if err := r.persist(source); err != nil {
+		return nil, err
+	}
+	r.catalog.Store(catalog)
+
+	return catalog.byID[product.ID], nil
+}
+
+// writable devuelve una copia del producto sin los campos derivados y con sus
+// especificaciones interpretadas, lista para agregarse al archivo de datos
+func writable(product *domain.Product) *domain.Product {
+	copied := *product
+	copied.Breadcrumbs = nil
+	copied.Completeness = nil
+	copied.Specifications = append([]domain.Specification(nil), product.Specifications...)
+	copied.ParseSpecifications()
+
+	return &copied
+}
+
+// indexOf devuelve la posición del producto con el ID indicado, o -1 si no existe
+func indexOf(products []*domain.Product, id string) int {
+	for i, product := range products {
+		if product.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// relativeTo quita el prefijo del producto escrito ("products[3].") de los campos de los
+// errores de validación, para informarlos como campos del producto recibido
+func relativeTo(err error, prefix string) error {
+	var errs domain.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	relative := make(domain.ValidationErrors, len(errs))
+	for i, e := range errs {
+		relative[i] = &domain.ValidationError{Field: strings.TrimPrefix(e.Field, prefix+"."), Message: e.Message}
+	}
+
+	return relative
+}
+
+// persist escribe los productos en el archivo de datos de forma atómica
+func (r *ProductRepository) persist(products []*domain.Product) error {
+	stored := make([]storedProduct, len(products))
+	for i, product := range products {
+		specs := make([]storedSpecification, len(product.Specifications))
+		for j, spec := range product.Specifications {
+			specs[j] = storedSpecification{Name: spec.Name, Value: spec.Value, Unit: spec.Unit}
+		}
+		stored[i] = storedProduct{Product: product, Specifications: specs}
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(stored); err != nil {
+		return fmt.Errorf("failed to encode products: %w", err)
+	}
+
+	return writeFileAtomic(r.filePath, buffer.Bytes())
+}
+
+// writeFileAtomic escribe el contenido en un archivo temporal del mismo directorio y lo
+// renombra sobre el destino, de modo que una interrupción deja el archivo anterior o el
+// nuevo completo, nunca uno a medio escribir. Conserva los permisos del archivo anterior.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary products file: %w", err)
+	}
+	// Si algo falla se elimina el temporal; después del rename ya no existe
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary products file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temporary products file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary products file: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to set products file permissions: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace products file: %w", err)
+	}
+
+	// Sincronizar el directorio para que el rename sobreviva a una caída del sistema
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	return nil
+}
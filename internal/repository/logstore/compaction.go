@@ -0,0 +1,150 @@
+package logstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultCompactionRatio es la proporción de registros obsoletos a partir de la cual
+// RunCompaction compacta el registro
+const DefaultCompactionRatio = 0.5
+
+// LogStats describe el contenido del registro
+type LogStats struct {
+	// Cantidad de registros del archivo
+	Records int
+
+	// Cantidad de productos vigentes
+	LiveProducts int
+
+	// Cantidad de registros que ya no aportan al estado: versiones reemplazadas y
+	// eliminaciones de productos que volvieron a crearse o que ya se habían eliminado
+	StaleRecords int
+
+	// Tamaño del archivo en bytes
+	SizeBytes int64
+}
+
+// Stats devuelve la cantidad de registros, vigentes y obsoletos, y el tamaño del registro
+func (r *ProductRepository) Stats() LogStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	live := len(r.state.Load().products)
+	return LogStats{Records: r.records, LiveProducts: live, StaleRecords: r.records - live - len(r.deleted), SizeBytes: r.size}
+}
+
+// compactionPath devuelve la ruta del archivo temporal de la compactación
+func compactionPath(path string) string {
+	return path + ".compact"
+}
+
+// Compact reescribe el registro con un único registro por producto vigente, en orden de
+// creación, seguidos de la eliminación de cada producto eliminado que no volvió a crearse,
+// con su versión. El registro nuevo se escribe en un archivo temporal que reemplaza al actual con
+// un rename, de modo que una interrupción deja el registro anterior o el nuevo completo.
+func (r *ProductRepository) Compact() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.broken != nil {
+		return fmt.Errorf("product log is unavailable after a failed write: %w", r.broken)
+	}
+	if r.file == nil {
+		return fmt.Errorf("product log is closed")
+	}
+
+	products := r.state.Load().products
+	records := make([]record, 0, len(products)+len(r.deleted))
+	for _, product := range products {
+		records = append(records, record{Op: opPut, Product: toStored(product)})
+	}
+
+	deletedIDs := make([]string, 0, len(r.deleted))
+	for id := range r.deleted {
+		deletedIDs = append(deletedIDs, id)
+	}
+	sort.Strings(deletedIDs)
+	for _, id := range deletedIDs {
+		records = append(records, record{Op: opDelete, ID: id, Version: r.deleted[id]})
+	}
+
+	data, err := encodeRecords(records...)
+	if err != nil {
+		return err
+	}
+	if err := writeCompacted(compactionPath(r.path), append([]byte(fileMagic), data...)); err != nil {
+		return err
+	}
+
+	if err := os.Rename(compactionPath(r.path), r.path); err != nil {
+		os.Remove(compactionPath(r.path))
+		return fmt.Errorf("failed to replace product log: %w", err)
+	}
+
+	// Sincronizar el directorio para que el rename sobreviva a una caída del sistema
+	if d, err := os.Open(filepath.Dir(r.path)); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	// El archivo abierto apunta al registro anterior; las escrituras siguientes van al nuevo
+	r.file.Close()
+	r.file = nil
+	if err := r.openAppend(int64(len(fileMagic)+len(data)), len(records)); err != nil {
+		r.broken = err
+		return err
+	}
+
+	return nil
+}
+
+// writeCompacted escribe y sincroniza el registro compactado
+func writeCompacted(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create compacted product log: %w", err)
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write compacted product log: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to sync compacted product log: %w", err)
+	}
+
+	return file.Close()
+}
+
+// RunCompaction compacta el registro cada intervalo si la proporción de registros obsoletos
+// alcanza ratio, hasta que se cancele el contexto. onCompact recibe el resultado de cada
+// compactación realizada.
+func (r *ProductRepository) RunCompaction(ctx context.Context, interval time.Duration, ratio float64, onCompact func(before LogStats, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := r.Stats()
+			if stats.StaleRecords == 0 || float64(stats.StaleRecords) < ratio*float64(stats.Records) {
+				continue
+			}
+
+			err := r.Compact()
+			if onCompact != nil {
+				onCompact(stats, err)
+			}
+		}
+	}
+}
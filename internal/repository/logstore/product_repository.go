@@ -0,0 +1,458 @@
+/*
+Package logstore implementa el repositorio de productos sobre un registro append-only en un
+único archivo, para despliegues de un solo nodo sin base de datos.
+
+Cada escritura agrega al final del archivo un registro con la versión completa del producto,
+o con su eliminación, protegido por un checksum CRC-32C, y sincroniza el archivo antes de
+aplicar el cambio en memoria. Así el costo de una escritura no depende del tamaño del
+catálogo, a diferencia del repositorio JSON, que reescribe el archivo completo.
+
+Características:
+- Registro append-only con checksum por registro
+- Índice en memoria (ID → producto) reconstruido al abrir leyendo el registro completo
+- Recuperación ante caídas: truncado de la última escritura si quedó incompleta o dañada
+- Compactación periódica que reescribe solo la última versión de cada producto
+- Búsqueda por relevancia y autocompletado con los mismos índices del repositorio JSON
+- Escrituras con control de versión optimista
+
+Como en los repositorios JSON y SQLite, la taxonomía y los esquemas de especificaciones se
+aplican al escribir y al configurarlos con SetTaxonomy y SetSpecSchemas. Los sinónimos de
+búsqueda siguen siendo propios del repositorio JSON.
+*/
+package logstore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/search"
+	"meli-products-api/internal/validation"
+)
+
+// File es el archivo del registro abierto para agregar registros
+type File interface {
+	io.Writer
+
+	// Sync fuerza la escritura en disco de lo escrito
+	Sync() error
+
+	// Truncate recorta el archivo al tamaño indicado
+	Truncate(size int64) error
+
+	Close() error
+}
+
+// Options configura la apertura del registro
+type Options struct {
+	// WrapFile envuelve el archivo abierto para agregar registros; nil lo usa tal cual.
+	// Permite inyectar fallas de escritura en los tests.
+	WrapFile func(File) File
+}
+
+// ProductRepository implementa domain.WritableProductRepository sobre un registro append-only
+type ProductRepository struct {
+	path    string
+	options Options
+
+	// Estado vigente de los productos. Se reemplaza completo de forma atómica después de
+	// cada escritura, por lo que las lecturas no toman el lock.
+	state atomic.Pointer[state]
+
+	// Taxonomía y esquemas de especificaciones con que se validan las escrituras; nil si no
+	// se configuraron
+	rules atomic.Pointer[validation.CatalogRules]
+
+	// Serializa las escrituras, la compactación y los cambios de reglas
+	mu sync.Mutex
+
+	// Archivo abierto para agregar registros, su tamaño y su cantidad de registros
+	file    File
+	size    int64
+	records int
+
+	// Error que impide seguir escribiendo: una escritura fallida que no pudo deshacerse.
+	// Al volver a abrir el registro la recuperación descarta el registro incompleto.
+	broken error
+
+	// Bytes descartados al abrir por una escritura interrumpida
+	recovered int64
+
+	// Última versión de cada producto eliminado que no volvió a crearse
+	deleted map[string]int64
+
+	// Reporte de validación de la última importación; nil si no se importaron productos
+	validation atomic.Pointer[domain.CatalogValidationReport]
+}
+
+// state es una vista inmutable de los productos en orden de creación, con los índices de
+// búsqueda construidos la primera vez que se necesitan
+type state struct {
+	products []*domain.Product
+	byID     map[string]int
+
+	searchOnce sync.Once
+	text       *search.Index
+	completer  *search.Completer
+}
+
+// newState construye el estado e indexa los productos por ID
+func newState(products []*domain.Product) *state {
+	s := &state{products: products, byID: make(map[string]int, len(products))}
+	for i, product := range products {
+		s.byID[product.ID] = i
+	}
+
+	return s
+}
+
+// search devuelve los índices de búsqueda y de autocompletado del estado
+func (s *state) search() (*search.Index, *search.Completer) {
+	s.searchOnce.Do(func() {
+		s.text = search.NewIndex(s.products)
+		s.completer = search.NewCompleter(s.products)
+	})
+
+	return s.text, s.completer
+}
+
+// Open abre el registro indicado, creándolo si no existe, y reconstruye los productos
+func Open(path string) (*ProductRepository, error) {
+	return OpenWithOptions(path, Options{})
+}
+
+// OpenWithOptions abre el registro indicado con las opciones dadas. Lee el registro
+// completo, trunca un último registro incompleto o dañado y devuelve ErrCorrupted si hay un
+// registro dañado antes del final.
+func OpenWithOptions(path string, options Options) (*ProductRepository, error) {
+	// Una compactación interrumpida deja el archivo temporal; el registro sigue intacto
+	if err := os.Remove(compactionPath(path)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove interrupted compaction: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open product log: %w", err)
+	}
+	file.Close()
+
+	scan, err := scanFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	products, deleted := replay(scan.records)
+
+	r := &ProductRepository{path: path, options: options, recovered: scan.fileSize - scan.validSize, deleted: deleted}
+	if err := r.openAppend(scan.validSize, scan.count); err != nil {
+		return nil, err
+	}
+	r.state.Store(newState(products))
+
+	return r, nil
+}
+
+// replay aplica los registros en orden: un producto nuevo se agrega al final, una versión
+// posterior reemplaza a la anterior en su lugar y una eliminación lo quita. Devuelve también
+// la última versión de cada producto eliminado que no volvió a crearse.
+func replay(records []record) ([]*domain.Product, map[string]int64) {
+	var products []*domain.Product
+	positions := make(map[string]int)
+	deleted := make(map[string]int64)
+
+	for _, r := range records {
+		switch r.Op {
+		case opPut:
+			product := r.Product.toProduct()
+			if position, ok := positions[product.ID]; ok {
+				products[position] = product
+			} else {
+				positions[product.ID] = len(products)
+				products = append(products, product)
+			}
+			delete(deleted, product.ID)
+
+		case opDelete:
+			// Se deja el lugar vacío para no desplazar las posiciones de los demás
+			if position, ok := positions[r.ID]; ok {
+				// Los registros anteriores a guardar la versión usan la del producto eliminado
+				deleted[r.ID] = max(r.Version, products[position].Version)
+				products[position] = nil
+				delete(positions, r.ID)
+			} else if r.Version > 0 {
+				// Eliminación conservada por una compactación
+				deleted[r.ID] = r.Version
+			}
+		}
+	}
+
+	live := make([]*domain.Product, 0, len(positions))
+	for _, product := range products {
+		if product != nil {
+			live = append(live, product)
+		}
+	}
+
+	return live, deleted
+}
+
+// openAppend abre el archivo para agregar registros. Si el archivo tiene datos después del
+// último registro válido los trunca; si no tiene cabecera la escribe.
+func (r *ProductRepository) openAppend(validSize int64, records int) error {
+	osFile, err := os.OpenFile(r.path, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open product log: %w", err)
+	}
+
+	info, err := osFile.Stat()
+	if err != nil {
+		osFile.Close()
+		return fmt.Errorf("failed to stat product log: %w", err)
+	}
+	if info.Size() != validSize {
+		if err := osFile.Truncate(validSize); err != nil {
+			osFile.Close()
+			return fmt.Errorf("failed to truncate interrupted write: %w", err)
+		}
+	}
+	if validSize == 0 {
+		if _, err := osFile.Write([]byte(fileMagic)); err != nil {
+			osFile.Close()
+			return fmt.Errorf("failed to write product log header: %w", err)
+		}
+		validSize = int64(len(fileMagic))
+	}
+	if err := osFile.Sync(); err != nil {
+		osFile.Close()
+		return fmt.Errorf("failed to sync product log: %w", err)
+	}
+
+	var file File = osFile
+	if r.options.WrapFile != nil {
+		file = r.options.WrapFile(file)
+	}
+
+	r.file, r.size, r.records = file, validSize, records
+	return nil
+}
+
+// Close cierra el archivo del registro
+func (r *ProductRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// RecoveredBytes devuelve la cantidad de bytes de una escritura interrumpida descartados al
+// abrir el registro
+func (r *ProductRepository) RecoveredBytes() int64 {
+	return r.recovered
+}
+
+// GetByID obtiene un producto por su ID
+func (r *ProductRepository) GetByID(id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, &domain.InvalidProductIDError{ID: id}
+	}
+
+	s := r.state.Load()
+	if position, ok := s.byID[id]; ok {
+		return s.products[position], nil
+	}
+
+	return nil, &domain.ProductNotFoundError{ID: id}
+}
+
+// GetAll obtiene todos los productos que cumplen el filtro, en orden de creación
+func (r *ProductRepository) GetAll(filter domain.ProductFilter) ([]*domain.Product, error) {
+	var products []*domain.Product
+	for _, product := range r.state.Load().products {
+		if filter.Matches(product) {
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+// GetByIDs obtiene múltiples productos por sus IDs para comparación
+func (r *ProductRepository) GetByIDs(ids []string) ([]*domain.Product, error) {
+	if len(ids) == 0 {
+		return []*domain.Product{}, nil
+	}
+
+	s := r.state.Load()
+
+	var products []*domain.Product
+	var notFoundIDs []string
+	for _, id := range ids {
+		if id == "" {
+			return nil, &domain.InvalidProductIDError{ID: id}
+		}
+		if position, ok := s.byID[id]; ok {
+			products = append(products, s.products[position])
+		} else {
+			notFoundIDs = append(notFoundIDs, id)
+		}
+	}
+
+	if len(notFoundIDs) > 0 {
+		return products, fmt.Errorf("products not found: %v", notFoundIDs)
+	}
+
+	return products, nil
+}
+
+// Search busca productos por nombre, marca, categoría o descripción y los devuelve
+// ordenados por relevancia
+func (r *ProductRepository) Search(query string) ([]*domain.Product, error) {
+	if query == "" {
+		return r.GetAll(domain.ProductFilter{})
+	}
+
+	hits, err := r.SearchRanked(domain.SearchRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, len(hits))
+	for i, hit := range hits {
+		products[i] = hit.Product
+	}
+
+	return products, nil
+}
+
+// SearchRanked busca productos que cumplen el filtro de la búsqueda y devuelve cada
+// coincidencia con su puntuación de relevancia
+func (r *ProductRepository) SearchRanked(request domain.SearchRequest) ([]domain.SearchHit, error) {
+	text, _ := r.state.Load().search()
+	hits := text.Search(request.Query)
+
+	filtered := hits[:0]
+	for _, hit := range hits {
+		if request.Filter.Matches(hit.Product) {
+			filtered = append(filtered, hit)
+		}
+	}
+
+	return filtered, nil
+}
+
+// Highlight devuelve el nombre y la descripción del producto con los términos de la consulta marcados
+func (r *ProductRepository) Highlight(query string, product *domain.Product) (map[string]string, error) {
+	text, _ := r.state.Load().search()
+	return text.Highlight(query, product), nil
+}
+
+// Explain devuelve las coincidencias del producto con la consulta y los componentes de su puntuación
+func (r *ProductRepository) Explain(query string, product *domain.Product) (*domain.SearchExplanation, error) {
+	text, _ := r.state.Load().search()
+	return text.Explain(query, product), nil
+}
+
+// SuggestQueries propone consultas corregidas a partir del vocabulario del catálogo
+func (r *ProductRepository) SuggestQueries(query string, limit int) ([]string, error) {
+	text, _ := r.state.Load().search()
+	return text.Suggest(query, limit), nil
+}
+
+// Autocomplete sugiere productos, marcas y categorías que comienzan con el prefijo
+func (r *ProductRepository) Autocomplete(prefix string, limit int) ([]domain.Suggestion, error) {
+	_, completer := r.state.Load().search()
+	return completer.Complete(prefix, limit), nil
+}
+
+// GetProductCount devuelve el número total de productos
+func (r *ProductRepository) GetProductCount() int {
+	return len(r.state.Load().products)
+}
+
+// GetCategoryTree devuelve las categorías con su cantidad de productos. Con una taxonomía
+// devuelve su árbol con la cantidad de productos de cada subárbol; sin ella, las categorías
+// forman un solo nivel en el orden en que aparecen por primera vez.
+func (r *ProductRepository) GetCategoryTree() []domain.CategoryTreeNode {
+	products := r.state.Load().products
+
+	if taxonomy := r.rules.Load().Taxonomy(); taxonomy != nil {
+		// Cada producto suma a todas las categorías de sus migas de pan
+		counts := make(map[string]int)
+		for _, product := range products {
+			for _, ref := range product.Breadcrumbs {
+				counts[domain.FoldText(strings.TrimSpace(ref.ID))]++
+			}
+		}
+		return taxonomy.CategoryTree(counts)
+	}
+
+	tree := []domain.CategoryTreeNode{}
+	positions := make(map[string]int)
+
+	for _, product := range products {
+		id := domain.CategorySlug(product.Category)
+		if position, ok := positions[id]; ok {
+			tree[position].ProductCount++
+			continue
+		}
+		positions[id] = len(tree)
+		tree = append(tree, domain.CategoryTreeNode{ID: id, Name: product.Category, ProductCount: 1})
+	}
+
+	return tree
+}
+
+// HasSpecification indica si algún producto declara la especificación
+func (r *ProductRepository) HasSpecification(name string) (bool, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, product := range r.state.Load().products {
+		for _, spec := range product.Specifications {
+			if strings.ToLower(strings.TrimSpace(spec.Name)) == key {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// GetBrands devuelve todas las marcas únicas, en el orden en que aparecen por primera vez
+func (r *ProductRepository) GetBrands() []string {
+	brands := []string{}
+	seen := make(map[string]bool)
+
+	for _, product := range r.state.Load().products {
+		if key := strings.ToLower(product.Brand); !seen[key] {
+			seen[key] = true
+			brands = append(brands, product.Brand)
+		}
+	}
+
+	return brands
+}
+
+// GetValidationReport devuelve el resultado de la validación de la última importación, o un
+// reporte sin validación con la cantidad actual de productos si no se importaron productos
+func (r *ProductRepository) GetValidationReport() *domain.CatalogValidationReport {
+	if report := r.validation.Load(); report != nil {
+		return report
+	}
+
+	count := r.GetProductCount()
+	return &domain.CatalogValidationReport{
+		Mode:           domain.ValidationDisabled,
+		TotalProducts:  count,
+		LoadedProducts: count,
+		Rejected:       []domain.RejectedProduct{},
+	}
+}
@@ -0,0 +1,367 @@
+package logstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"meli-products-api/domain"
+)
+
+// Formato del archivo: una cabecera fija seguida de registros. Cada registro tiene una
+// cabecera de 8 bytes (largo del contenido y CRC-32C del contenido, ambos uint32 big endian)
+// y el contenido en JSON. Los registros escritos entre una marca de inicio y una de fin de
+// lote se aplican todos o ninguno.
+const (
+	// fileMagic identifica un registro de productos y la versión de su formato
+	fileMagic = "PRODLOG\x01"
+
+	// recordHeaderSize es el tamaño de la cabecera de cada registro
+	recordHeaderSize = 8
+
+	// maxRecordSize es el tamaño máximo del contenido de un registro; un largo mayor solo
+	// puede provenir de una cabecera dañada
+	maxRecordSize = 16 << 20
+)
+
+// Operaciones de un registro
+const (
+	opPut    = "put"
+	opDelete = "delete"
+
+	// Marcas de inicio y fin de un lote, como el de una importación
+	opBegin  = "begin"
+	opCommit = "commit"
+)
+
+// castagnoli es la tabla del CRC-32C usado como checksum de los registros
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorrupted indica que el registro tiene un registro dañado que no es el último, por lo
+// que no puede deberse a una escritura interrumpida y no se descarta automáticamente
+var ErrCorrupted = errors.New("product log is corrupted")
+
+// record es una entrada del registro: la versión completa de un producto, su eliminación o
+// una marca de lote. Una eliminación guarda la versión del producto eliminado, para que uno
+// nuevo con el mismo ID continúe su numeración.
+type record struct {
+	Op      string         `json:"op"`
+	Product *storedProduct `json:"product,omitempty"`
+	ID      string         `json:"id,omitempty"`
+	Version int64          `json:"version,omitempty"`
+}
+
+// storedProduct es la representación de un producto en el registro. Omite los campos que
+// se derivan al leerlo: migas de pan, completitud y valores tipados.
+type storedProduct struct {
+	ID             string                `json:"id"`
+	Name           string                `json:"name"`
+	ImageURL       string                `json:"image_url"`
+	Description    string                `json:"description"`
+	Price          float64               `json:"price"`
+	Rating         float32               `json:"rating"`
+	Specifications []storedSpecification `json:"specifications"`
+	Category       string                `json:"category"`
+	Brand          string                `json:"brand"`
+	Available      bool                  `json:"available"`
+	Version        int64                 `json:"version"`
+}
+
+// storedSpecification es la representación de una especificación en el registro
+type storedSpecification struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Unit  string `json:"unit"`
+}
+
+// toStored convierte el producto a su representación en el registro
+func toStored(product *domain.Product) *storedProduct {
+	specs := make([]storedSpecification, len(product.Specifications))
+	for i, spec := range product.Specifications {
+		specs[i] = storedSpecification{Name: spec.Name, Value: spec.Value, Unit: spec.Unit}
+	}
+
+	return &storedProduct{
+		ID:             product.ID,
+		Name:           product.Name,
+		ImageURL:       product.ImageURL,
+		Description:    product.Description,
+		Price:          product.Price,
+		Rating:         product.Rating,
+		Specifications: specs,
+		Category:       product.Category,
+		Brand:          product.Brand,
+		Available:      product.Available,
+		Version:        product.Version,
+	}
+}
+
+// toProduct construye el producto con sus campos derivados: las especificaciones tipadas y
+// las migas de pan de un único nivel, que SetTaxonomy reemplaza por el camino en la taxonomía
+func (s *storedProduct) toProduct() *domain.Product {
+	specs := make([]domain.Specification, len(s.Specifications))
+	for i, spec := range s.Specifications {
+		specs[i] = domain.Specification{Name: spec.Name, Value: spec.Value, Unit: spec.Unit}
+	}
+
+	product := &domain.Product{
+		ID:             s.ID,
+		Name:           s.Name,
+		ImageURL:       s.ImageURL,
+		Description:    s.Description,
+		Price:          s.Price,
+		Rating:         s.Rating,
+		Specifications: specs,
+		Category:       s.Category,
+		Breadcrumbs:    []domain.CategoryRef{{ID: domain.CategorySlug(s.Category), Name: s.Category}},
+		Brand:          s.Brand,
+		Available:      s.Available,
+		Version:        s.Version,
+	}
+	product.ParseSpecifications()
+
+	return product
+}
+
+// encodeRecords codifica los registros uno detrás de otro, cada uno con su cabecera
+func encodeRecords(records ...record) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	for _, r := range records {
+		payload, err := json.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode product log record: %w", err)
+		}
+
+		var header [recordHeaderSize]byte
+		binary.BigEndian.PutUint32(header[0:4], uint32(len(payload)))
+		binary.BigEndian.PutUint32(header[4:8], crc32.Checksum(payload, castagnoli))
+
+		buffer.Write(header[:])
+		buffer.Write(payload)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// scanResult es el resultado de leer el archivo del registro
+type scanResult struct {
+	// Registros válidos de productos, en orden de escritura
+	records []record
+
+	// Cantidad de registros válidos del archivo, incluidas las marcas de lote
+	count int
+
+	// Tamaño del archivo hasta el final del último registro válido
+	validSize int64
+
+	// Tamaño del archivo leído
+	fileSize int64
+}
+
+// scanFile lee todos los registros del archivo. Una cabecera o un registro incompletos se
+// consideran una escritura interrumpida (ver tornTail), al igual que un registro con checksum o contenido
+// inválidos que es el último o al que solo siguen ceros, como los que deja el sistema de
+// archivos al extender el archivo sin llegar a escribir los datos: la lectura termina ahí y
+// validSize indica dónde truncar. Un lote sin marca de fin también se descarta completo. Un
+// registro inválido seguido de otros devuelve ErrCorrupted.
+func scanFile(path string) (*scanResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open product log: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat product log: %w", err)
+	}
+
+	result := &scanResult{fileSize: info.Size()}
+	reader := bufio.NewReader(file)
+
+	// Un archivo vacío o con la cabecera a medio escribir todavía no tiene registros
+	magic := make([]byte, len(fileMagic))
+	n, err := io.ReadFull(reader, magic)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read product log: %w", err)
+	}
+	if string(magic[:n]) != fileMagic[:n] {
+		return nil, fmt.Errorf("%s is not a product log", path)
+	}
+	if n < len(fileMagic) {
+		return result, nil
+	}
+
+	offset := int64(len(fileMagic))
+	result.validSize = offset
+
+	// Registros del lote en curso; validSize no avanza hasta leer su marca de fin
+	var batch []record
+	inBatch := false
+
+	for offset < result.fileSize {
+		remaining := result.fileSize - offset
+		if remaining < recordHeaderSize {
+			break
+		}
+
+		var header [recordHeaderSize]byte
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return nil, fmt.Errorf("failed to read product log: %w", err)
+		}
+		length := int64(binary.BigEndian.Uint32(header[0:4]))
+		checksum := binary.BigEndian.Uint32(header[4:8])
+
+		if length > maxRecordSize {
+			return nil, fmt.Errorf("%w: record at offset %d has invalid length %d", ErrCorrupted, offset, length)
+		}
+
+		end := offset + recordHeaderSize + length
+		if end > result.fileSize {
+			torn, err := tornTail(file, offset+recordHeaderSize, result.fileSize, checksum)
+			if err != nil {
+				return nil, err
+			}
+			if torn {
+				// El registro no terminó de escribirse
+				break
+			}
+			return nil, fmt.Errorf("%w: record at offset %d has invalid length %d", ErrCorrupted, offset, length)
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return nil, fmt.Errorf("failed to read product log: %w", err)
+		}
+
+		r, problem := decodeRecord(payload, checksum)
+		if problem != "" {
+			torn, err := zeroFilled(file, end, result.fileSize)
+			if err != nil {
+				return nil, err
+			}
+			if torn {
+				// El último registro quedó a medio escribir en disco
+				break
+			}
+			return nil, fmt.Errorf("%w: %s in record at offset %d", ErrCorrupted, problem, offset)
+		}
+
+		switch r.Op {
+		case opBegin:
+			if inBatch {
+				return nil, fmt.Errorf("%w: nested batch at offset %d", ErrCorrupted, offset)
+			}
+			batch, inBatch = nil, true
+		case opCommit:
+			if !inBatch {
+				return nil, fmt.Errorf("%w: batch end without start at offset %d", ErrCorrupted, offset)
+			}
+			result.records = append(result.records, batch...)
+			result.count += len(batch) + 2
+			result.validSize = end
+			batch, inBatch = nil, false
+		default:
+			if inBatch {
+				batch = append(batch, r)
+			} else {
+				result.records = append(result.records, r)
+				result.count++
+				result.validSize = end
+			}
+		}
+		offset = end
+	}
+
+	return result, nil
+}
+
+// decodeRecord verifica el checksum del contenido y lo decodifica. Si el registro es
+// inválido devuelve la descripción del problema.
+func decodeRecord(payload []byte, checksum uint32) (record, string) {
+	var r record
+	if crc32.Checksum(payload, castagnoli) != checksum {
+		return r, "checksum mismatch"
+	}
+	if err := json.Unmarshal(payload, &r); err != nil {
+		return r, fmt.Sprintf("invalid content (%v)", err)
+	}
+	switch r.Op {
+	case opPut:
+		if r.Product == nil {
+			return r, "put without product"
+		}
+	case opDelete:
+		if r.ID == "" {
+			return r, "delete without ID"
+		}
+	case opBegin, opCommit:
+	default:
+		return r, fmt.Sprintf("invalid operation %q", r.Op)
+	}
+
+	return r, ""
+}
+
+// tornTail indica si el contenido de un registro que excede el final del archivo, desde from
+// hasta size, es una escritura interrumpida y no un largo dañado. Lo es si quedan menos bytes
+// que una cabecera o solo ceros, o si ningún prefijo de esos bytes coincide con el checksum de
+// la cabecera: cuando coincide, el contenido está completo y lo dañado es su largo, y lo que
+// sigue son registros confirmados que no pueden truncarse.
+func tornTail(file *os.File, from, size int64, checksum uint32) (bool, error) {
+	if size-from < recordHeaderSize {
+		return true, nil
+	}
+
+	zeros, err := zeroFilled(file, from, size)
+	if err != nil || zeros {
+		return zeros, err
+	}
+
+	buffer := make([]byte, 32<<10)
+	crc := uint32(0)
+
+	for from < size {
+		chunk := buffer[:min(int64(len(buffer)), size-from)]
+		if _, err := file.ReadAt(chunk, from); err != nil {
+			return false, fmt.Errorf("failed to read product log: %w", err)
+		}
+		for i := range chunk {
+			crc = crc32.Update(crc, castagnoli, chunk[i:i+1])
+			if crc == checksum {
+				return false, nil
+			}
+		}
+		from += int64(len(chunk))
+	}
+
+	return true, nil
+}
+
+// zeroFilled indica si el archivo solo tiene ceros desde from hasta size; es verdadero si
+// no queda nada por leer
+func zeroFilled(file *os.File, from, size int64) (bool, error) {
+	buffer := make([]byte, 32<<10)
+
+	for from < size {
+		chunk := buffer[:min(int64(len(buffer)), size-from)]
+		if _, err := file.ReadAt(chunk, from); err != nil {
+			return false, fmt.Errorf("failed to read product log: %w", err)
+		}
+		for _, b := range chunk {
+			if b != 0 {
+				return false, nil
+			}
+		}
+		from += int64(len(chunk))
+	}
+
+	return true, nil
+}
@@ -0,0 +1,54 @@
+package logstore
+
+import (
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/validation"
+)
+
+// SetTaxonomy organiza las categorías según la taxonomía: cada producto recibe sus migas de
+// pan, los filtros por categoría abarcan todas las subcategorías y las escrituras deben usar
+// una categoría hoja. Devuelve un error, y conserva las reglas anteriores, si algún producto
+// del registro no pertenece a una hoja del árbol.
+func (r *ProductRepository) SetTaxonomy(taxonomy *domain.Taxonomy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := validation.NewCatalogRules(taxonomy, r.rules.Load().Schemas())
+	if err := r.applyRules(rules); err != nil {
+		return fmt.Errorf("products do not match the taxonomy: %w", err)
+	}
+
+	return nil
+}
+
+// SetSpecSchemas valida las especificaciones de los productos contra el esquema de su
+// categoría y les asigna su puntaje de completitud. Devuelve un error, y conserva las reglas
+// anteriores, si algún producto del registro no informa una especificación obligatoria o la
+// informa con otro tipo o unidad. Las escrituras posteriores se validan con los esquemas.
+func (r *ProductRepository) SetSpecSchemas(schemas *domain.SpecSchemaSet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := validation.NewCatalogRules(r.rules.Load().Taxonomy(), schemas)
+	if err := r.applyRules(rules); err != nil {
+		return fmt.Errorf("products do not match the spec schemas: %w", err)
+	}
+
+	return nil
+}
+
+// applyRules verifica que todos los productos cumplan las reglas indicadas y, si las cumplen,
+// reemplaza el estado por copias con sus migas de pan y su completitud; sus etiquetas
+// validate ya se verificaron al escribirlos. Debe llamarse con r.mu tomado.
+func (r *ProductRepository) applyRules(rules *validation.CatalogRules) error {
+	prepared, _, err := validation.PrepareCatalog(r.state.Load().products, domain.ValidationDisabled, rules)
+	if err != nil {
+		return err
+	}
+
+	r.rules.Store(rules)
+	r.state.Store(newState(prepared))
+	return nil
+}
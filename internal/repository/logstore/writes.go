@@ -0,0 +1,202 @@
+package logstore
+
+import (
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/validation"
+)
+
+// Create agrega un producto nuevo con la versión inicial o, si el ID perteneció a un producto
+// eliminado, con la siguiente a la de ese producto. Devuelve ProductAlreadyExistsError si el
+// ID ya existe y ValidationErrors si el producto no cumple sus etiquetas validate, la
+// taxonomía o el esquema de especificaciones de su categoría.
+func (r *ProductRepository) Create(product *domain.Product) (*domain.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.state.Load()
+	if _, exists := current.byID[product.ID]; product.ID != "" && exists {
+		return nil, &domain.ProductAlreadyExistsError{ID: product.ID}
+	}
+
+	created, errs := validation.PrepareProduct(product, r.rules.Load())
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	created.Version = domain.RecreatedProductVersion(r.deleted[product.ID])
+	if err := r.append(record{Op: opPut, Product: toStored(created)}); err != nil {
+		return nil, err
+	}
+	delete(r.deleted, product.ID)
+
+	products := make([]*domain.Product, len(current.products), len(current.products)+1)
+	copy(products, current.products)
+	r.state.Store(newState(append(products, created)))
+
+	return created, nil
+}
+
+// Update reemplaza el producto con el mismo ID, con las mismas validaciones que Create, e
+// incrementa su versión. Devuelve ProductNotFoundError si el ID no existe y
+// ProductVersionConflictError si product.Version no es cero ni la versión actual.
+func (r *ProductRepository) Update(product *domain.Product) (*domain.Product, error) {
+	if product.ID == "" {
+		return nil, &domain.InvalidProductIDError{ID: product.ID}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.state.Load()
+	position, ok := current.byID[product.ID]
+	if !ok {
+		return nil, &domain.ProductNotFoundError{ID: product.ID}
+	}
+
+	version := current.products[position].Version
+	if product.Version != 0 && product.Version != version {
+		return nil, &domain.ProductVersionConflictError{ID: product.ID, Expected: product.Version, Current: version}
+	}
+
+	updated, errs := validation.PrepareProduct(product, r.rules.Load())
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	updated.Version = version + 1
+	if err := r.append(record{Op: opPut, Product: toStored(updated)}); err != nil {
+		return nil, err
+	}
+
+	products := append([]*domain.Product(nil), current.products...)
+	products[position] = updated
+	r.state.Store(newState(products))
+
+	return updated, nil
+}
+
+// Delete elimina el producto y lo devuelve; su versión se conserva para que un producto nuevo
+// con el mismo ID no la repita. Devuelve ProductNotFoundError si el ID no existe.
+func (r *ProductRepository) Delete(id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, &domain.InvalidProductIDError{ID: id}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.state.Load()
+	position, ok := current.byID[id]
+	if !ok {
+		return nil, &domain.ProductNotFoundError{ID: id}
+	}
+
+	version := current.products[position].Version
+	if err := r.append(record{Op: opDelete, ID: id, Version: version}); err != nil {
+		return nil, err
+	}
+	r.deleted[id] = version
+
+	products := make([]*domain.Product, 0, len(current.products)-1)
+	products = append(products, current.products[:position]...)
+	products = append(products, current.products[position+1:]...)
+	r.state.Store(newState(products))
+
+	return current.products[position], nil
+}
+
+// Import agrega los productos en un único lote y con una sola sincronización del archivo,
+// validados según el modo indicado contra sus etiquetas validate, la taxonomía y los esquemas
+// de especificaciones: en modo estricto no agrega ninguno si alguno es inválido, en modo
+// permisivo descarta los inválidos y los informa en GetValidationReport. Si la escritura se
+// interrumpe, al abrir se descarta el lote completo. Los productos sin versión empiezan en la
+// versión inicial, y los de un ID eliminado continúan su numeración. Devuelve
+// ProductAlreadyExistsError si algún ID ya existe.
+func (r *ProductRepository) Import(products []*domain.Product, mode domain.CatalogValidationMode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parsed := make([]*domain.Product, len(products))
+	for i, product := range products {
+		copied := *product
+		copied.Specifications = append([]domain.Specification(nil), product.Specifications...)
+		copied.ParseSpecifications()
+		parsed[i] = &copied
+	}
+
+	valid, report, err := validation.PrepareCatalog(parsed, mode, r.rules.Load())
+	if err != nil {
+		return fmt.Errorf("invalid products: %w", err)
+	}
+
+	current := r.state.Load()
+	imported := append([]*domain.Product(nil), current.products...)
+	seen := make(map[string]bool, len(valid))
+	records := make([]record, len(valid))
+
+	for i, product := range valid {
+		if _, exists := current.byID[product.ID]; exists || seen[product.ID] {
+			return &domain.ProductAlreadyExistsError{ID: product.ID}
+		}
+		seen[product.ID] = true
+
+		product.Version = max(product.Version, domain.RecreatedProductVersion(r.deleted[product.ID]))
+		records[i] = record{Op: opPut, Product: toStored(product)}
+		imported = append(imported, product)
+	}
+
+	if len(records) > 0 {
+		batch := make([]record, 0, len(records)+2)
+		batch = append(batch, record{Op: opBegin})
+		batch = append(batch, records...)
+		batch = append(batch, record{Op: opCommit})
+		if err := r.append(batch...); err != nil {
+			return err
+		}
+	}
+
+	for id := range seen {
+		delete(r.deleted, id)
+	}
+	r.state.Store(newState(imported))
+	r.validation.Store(report)
+	return nil
+}
+
+// append agrega los registros al final del archivo y lo sincroniza. Si la escritura falla
+// recorta lo que se haya escrito, para que el registro siguiente no quede detrás de uno
+// incompleto; si tampoco puede recortarse, el repositorio deja de aceptar escrituras hasta
+// que se vuelva a abrir. Debe llamarse con r.mu tomado.
+func (r *ProductRepository) append(records ...record) error {
+	if r.broken != nil {
+		return fmt.Errorf("product log is unavailable after a failed write: %w", r.broken)
+	}
+	if r.file == nil {
+		return fmt.Errorf("product log is closed")
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	data, err := encodeRecords(records...)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.file.Write(data)
+	if err == nil {
+		err = r.file.Sync()
+	}
+	if err != nil {
+		if truncateErr := r.file.Truncate(r.size); truncateErr != nil {
+			r.broken = truncateErr
+		}
+		return fmt.Errorf("failed to write product log: %w", err)
+	}
+
+	r.size += int64(len(data))
+	r.records += len(records)
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+Package searchlog implementa el registro de búsquedas y clics utilizado por los
+reportes de búsquedas.
+
+Cada evento se agrega como una línea JSON al archivo activo del directorio del
+registro. Cuando el archivo supera el tamaño máximo se rota: el activo pasa a ser
+el respaldo .1, el .1 pasa a ser el .2, y así hasta la cantidad de respaldos
+configurada, descartando el más antiguo. Los reportes leen todos los archivos, de
+modo que sobreviven a reinicios de la API.
+
+Características:
+- Escritura append-only de una línea JSON por evento
+- Rotación por tamaño con una cantidad fija de respaldos
+- Lectura filtrada por período, tolerante a líneas truncadas o inválidas
+- Seguro para uso concurrente dentro de un mismo proceso
+*/
+package searchlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"meli-products-api/domain"
+)
+
+// Valores por defecto de la rotación
+const (
+	// DefaultMaxBytes es el tamaño a partir del cual se rota el archivo activo
+	DefaultMaxBytes = 10 << 20
+
+	// DefaultMaxBackups es la cantidad de archivos rotados que se conservan
+	DefaultMaxBackups = 5
+
+	// logFileName es el nombre del archivo activo dentro del directorio del registro
+	logFileName = "searches.log"
+)
+
+// record es una línea del registro: una búsqueda o un clic
+type record struct {
+	Search *domain.SearchEvent `json:"search,omitempty"`
+	Click  *domain.SearchClick `json:"click,omitempty"`
+}
+
+// FileLog implementa domain.SearchLog sobre archivos JSON Lines con rotación por tamaño
+type FileLog struct {
+	dir        string
+	maxBytes   int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+
+	// Indica que el archivo activo termina en una línea incompleta que hay que cerrar
+	// antes de escribir
+	torn bool
+}
+
+// Open abre, o crea, el registro de búsquedas en el directorio indicado. Los valores
+// menores o iguales a cero de maxBytes y maxBackups se reemplazan por los valores por defecto.
+func Open(dir string, maxBytes int64, maxBackups int) (*FileLog, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if maxBackups <= 0 {
+		maxBackups = DefaultMaxBackups
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create search log directory: %w", err)
+	}
+
+	l := &FileLog{dir: dir, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := l.openActive(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// openActive abre el archivo activo para agregar líneas y registra su tamaño actual
+func (l *FileLog) openActive() error {
+	file, err := os.OpenFile(l.path(0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open search log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat search log: %w", err)
+	}
+
+	l.file, l.size, l.torn = file, info.Size(), false
+	if l.size > 0 {
+		last := make([]byte, 1)
+		if reader, err := os.Open(l.path(0)); err == nil {
+			if _, err := reader.ReadAt(last, l.size-1); err == nil {
+				l.torn = last[0] != '\n'
+			}
+			reader.Close()
+		}
+	}
+
+	return nil
+}
+
+// path devuelve la ruta del archivo activo (n = 0) o del respaldo n
+func (l *FileLog) path(n int) string {
+	if n == 0 {
+		return filepath.Join(l.dir, logFileName)
+	}
+
+	return filepath.Join(l.dir, fmt.Sprintf("%s.%d", logFileName, n))
+}
+
+// RecordSearch agrega una búsqueda al registro
+func (l *FileLog) RecordSearch(event domain.SearchEvent) error {
+	return l.append(record{Search: &event})
+}
+
+// RecordClick agrega un clic al registro
+func (l *FileLog) RecordClick(click domain.SearchClick) error {
+	return l.append(record{Click: &click})
+}
+
+// append escribe el registro como una línea, rotando antes si el archivo activo se llenó
+func (l *FileLog) append(r record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode search log record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("search log is closed")
+	}
+
+	if l.size > 0 && l.size+int64(len(line)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	if l.torn {
+		line = append([]byte{'\n'}, line...)
+		l.torn = false
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write search log: %w", err)
+	}
+
+	return nil
+}
+
+// rotate cierra el archivo activo, desplaza los respaldos y abre un archivo activo vacío.
+// Si el desplazamiento falla se vuelve a abrir el archivo activo para no perder las
+// escrituras siguientes.
+func (l *FileLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close search log: %w", err)
+	}
+	l.file = nil
+
+	var rotateErr error
+	if err := os.Remove(l.path(l.maxBackups)); err != nil && !os.IsNotExist(err) {
+		rotateErr = fmt.Errorf("failed to remove oldest search log: %w", err)
+	}
+	for n := l.maxBackups - 1; n >= 0 && rotateErr == nil; n-- {
+		if err := os.Rename(l.path(n), l.path(n+1)); err != nil && !os.IsNotExist(err) {
+			rotateErr = fmt.Errorf("failed to rotate search log: %w", err)
+		}
+	}
+
+	if err := l.openActive(); err != nil {
+		return err
+	}
+
+	return rotateErr
+}
+
+// Events devuelve las búsquedas y los clics registrados en el período [since, until), en
+// orden cronológico de escritura. Las líneas que no pueden interpretarse (por ejemplo, una
+// escritura interrumpida) se ignoran. Los archivos se leen sin bloquear las escrituras.
+func (l *FileLog) Events(since, until time.Time) ([]domain.SearchEvent, []domain.SearchClick, error) {
+	files, err := l.snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		for _, f := range files {
+			f.file.Close()
+		}
+	}()
+
+	var events []domain.SearchEvent
+	var clicks []domain.SearchClick
+
+	inWindow := func(t time.Time) bool {
+		return !t.Before(since) && t.Before(until)
+	}
+
+	for _, f := range files {
+		scanner := bufio.NewScanner(io.LimitReader(f.file, f.size))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+		for scanner.Scan() {
+			var r record
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				continue
+			}
+
+			switch {
+			case r.Search != nil && inWindow(r.Search.Timestamp):
+				events = append(events, *r.Search)
+			case r.Click != nil && inWindow(r.Click.Timestamp):
+				clicks = append(clicks, *r.Click)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf("failed to read search log: %w", err)
+		}
+	}
+
+	return events, clicks, nil
+}
+
+// logFile es un archivo del registro abierto para lectura junto con los bytes a leer
+type logFile struct {
+	file *os.File
+	size int64
+}
+
+// snapshot abre, del respaldo más antiguo al archivo activo, los archivos del registro y
+// toma su tamaño bajo el lock. Un archivo abierto sigue siendo legible aunque una rotación
+// posterior lo renombre o lo elimine, y limitar la lectura al tamaño tomado deja afuera las
+// líneas que se agreguen mientras tanto.
+func (l *FileLog) snapshot() ([]logFile, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var files []logFile
+	fail := func(err error) ([]logFile, error) {
+		for _, f := range files {
+			f.file.Close()
+		}
+		return nil, err
+	}
+
+	for n := l.maxBackups; n >= 0; n-- {
+		file, err := os.Open(l.path(n))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fail(fmt.Errorf("failed to open search log: %w", err))
+		}
+
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return fail(fmt.Errorf("failed to stat search log: %w", err))
+		}
+
+		files = append(files, logFile{file: file, size: info.Size()})
+	}
+
+	return files, nil
+}
+
+// Close cierra el archivo activo; las escrituras posteriores fallan
+func (l *FileLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
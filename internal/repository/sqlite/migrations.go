@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migrations son las migraciones del esquema, en orden: la migración i lleva el esquema a la
+// versión i+1. Cada una se aplica una sola vez y en su propia transacción. Las migraciones
+// publicadas no se modifican; los cambios de esquema se agregan al final.
+var migrations = []string{
+	// 1: productos y especificaciones normalizadas, con índices para los filtros de GetAll.
+	// Las columnas *_key, *_slug y *_words guardan el texto normalizado en Go (sin mayúsculas
+	// ni acentos), ya que SQLite no sabe plegar acentos.
+	`
+	CREATE TABLE products (
+		seq            INTEGER PRIMARY KEY,
+		id             TEXT    NOT NULL UNIQUE,
+		name           TEXT    NOT NULL,
+		image_url      TEXT    NOT NULL,
+		description    TEXT    NOT NULL,
+		price          REAL    NOT NULL,
+		rating         REAL    NOT NULL,
+		category       TEXT    NOT NULL,
+		brand          TEXT    NOT NULL,
+		available      INTEGER NOT NULL,
+		version        INTEGER NOT NULL,
+		category_key   TEXT    NOT NULL,
+		category_slug  TEXT    NOT NULL,
+		brand_key      TEXT    NOT NULL,
+		name_words     TEXT    NOT NULL,
+		brand_words    TEXT    NOT NULL,
+		category_words TEXT    NOT NULL
+	);
+
+	CREATE INDEX idx_products_category_key ON products (category_key);
+	CREATE INDEX idx_products_category_slug ON products (category_slug);
+	CREATE INDEX idx_products_brand_key ON products (brand_key);
+	CREATE INDEX idx_products_price ON products (price);
+
+	CREATE TABLE specifications (
+		product_seq INTEGER NOT NULL REFERENCES products (seq) ON DELETE CASCADE,
+		position    INTEGER NOT NULL,
+		name        TEXT    NOT NULL,
+		value       TEXT    NOT NULL,
+		unit        TEXT    NOT NULL,
+		PRIMARY KEY (product_seq, position)
+	);
+	`,
+
+	// 2: índice de texto completo para Search. Cada fila usa como rowid el seq del producto.
+	`
+	CREATE VIRTUAL TABLE products_fts USING fts5 (
+		name, brand, category, description,
+		tokenize = 'unicode61 remove_diacritics 2'
+	);
+
+	INSERT INTO products_fts (rowid, name, brand, category, description)
+	SELECT seq, name, brand, category, description FROM products;
+	`,
+
+	// 3: última versión de cada producto eliminado, para que uno nuevo con el mismo ID
+	// continúe su numeración
+	`
+	CREATE TABLE deleted_products (
+		id      TEXT    PRIMARY KEY,
+		version INTEGER NOT NULL
+	);
+	`,
+}
+
+// migrate crea la tabla de control de versiones y aplica las migraciones pendientes. Devuelve
+// un error si la base tiene un esquema más nuevo que el que conoce este binario.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT    NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	for i, migration := range migrations {
+		if err := applyMigration(db, i+1, migration); err != nil {
+			return err
+		}
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if current > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than the supported version %d", current, len(migrations))
+	}
+
+	return nil
+}
+
+// applyMigration aplica la migración si la base todavía no tiene esa versión. La versión se
+// vuelve a leer dentro de la transacción para que dos procesos que abren la misma base a la
+// vez no apliquen la misma migración dos veces.
+func applyMigration(db *sql.DB, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	var applied bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = ?)`, version).Scan(&applied); err != nil {
+		return fmt.Errorf("failed to check migration %d: %w", version, err)
+	}
+	if applied {
+		return nil
+	}
+
+	if _, err := tx.Exec(migration); err != nil {
+		return fmt.Errorf("failed to apply migration %d: %w", version, err)
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d: %w", version, err)
+	}
+
+	return nil
+}
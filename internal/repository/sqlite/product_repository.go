@@ -0,0 +1,315 @@
+/*
+Package sqlite implementa el repositorio de productos sobre una base SQLite, con el driver
+modernc.org/sqlite escrito en Go puro (no requiere cgo).
+
+A diferencia del repositorio JSON, los productos no se mantienen en memoria: cada operación
+consulta la base. El esquema se crea y actualiza con migraciones numeradas al abrir la base.
+
+Características:
+- Tablas normalizadas de productos y especificaciones
+- Índices por categoría, marca y precio para los filtros de GetAll
+- Búsqueda de texto completo con FTS5, ordenada por relevancia (BM25)
+- Autocompletado de productos, marcas y categorías por prefijo de palabra
+- Escrituras transaccionales con control de versión optimista
+- Importación inicial de productos con validación estricta o permisiva
+- Taxonomía de categorías y esquemas de especificaciones opcionales (validation.CatalogRules)
+
+Los sinónimos de búsqueda y la recarga en caliente son propios del repositorio JSON: con
+SQLite la base es la fuente de datos y la búsqueda no expande sinónimos.
+*/
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+
+	// Driver SQLite en Go puro, registrado como "sqlite"
+	_ "modernc.org/sqlite"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/validation"
+)
+
+// ProductRepository implementa domain.WritableProductRepository sobre una base SQLite
+type ProductRepository struct {
+	db *sql.DB
+
+	// Taxonomía y esquemas de especificaciones aplicados a los productos. Se reemplazan
+	// completos de forma atómica; nil si las categorías son planas y sin esquemas.
+	rules atomic.Pointer[validation.CatalogRules]
+
+	// Serializa las escrituras con los cambios de taxonomía y de esquemas, para que ninguna
+	// escritura se valide con reglas ya reemplazadas
+	mu sync.Mutex
+
+	// Reporte de validación de la última importación; nil si no se importaron productos
+	validation atomic.Pointer[domain.CatalogValidationReport]
+}
+
+// Open abre la base SQLite indicada, creándola si no existe, y aplica las migraciones
+// pendientes del esquema
+func Open(path string) (*ProductRepository, error) {
+	db, err := sql.Open("sqlite", dataSourceName(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &ProductRepository{db: db}, nil
+}
+
+// dataSourceName arma la cadena de conexión: claves foráneas activas (para borrar las
+// especificaciones en cascada), WAL para que las lecturas no esperen a las escrituras, espera
+// ante bloqueos y transacciones IMMEDIATE, que toman el bloqueo de escritura al comenzar y
+// evitan que dos escrituras concurrentes fallen al intentar promoverlo
+func dataSourceName(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_txlock", "immediate")
+
+	return "file:" + path + "?" + params.Encode()
+}
+
+// Close cierra la conexión con la base
+func (r *ProductRepository) Close() error {
+	return r.db.Close()
+}
+
+// querier es la parte común de *sql.DB y *sql.Tx usada por las consultas de lectura
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// selectProducts es la consulta base de productos con sus especificaciones. Cada producto
+// aparece en tantas filas como especificaciones tenga (o en una sola si no tiene), de modo
+// que una única sentencia devuelve una vista consistente aunque haya escrituras en paralelo.
+const selectProducts = `
+	SELECT p.seq, p.id, p.name, p.image_url, p.description, p.price, p.rating, p.category,
+	       p.brand, p.available, p.version, s.name, s.value, s.unit, %s
+	FROM products p
+	LEFT JOIN specifications s ON s.product_seq = p.seq
+	%s`
+
+// queryHits ejecuta la consulta base con la puntuación y el resto de la consulta (joins,
+// condiciones y orden) indicados, y agrupa las filas por producto en el orden en que
+// aparecen. Los campos derivados se completan según las reglas indicadas.
+func queryHits(q querier, rules *validation.CatalogRules, score, tail string, args ...interface{}) ([]domain.SearchHit, error) {
+	rows, err := q.Query(fmt.Sprintf(selectProducts, score, tail), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []domain.SearchHit
+	var last int64 = -1
+
+	for rows.Next() {
+		var (
+			seq                   int64
+			product               domain.Product
+			rating                float64
+			specName, value, unit sql.NullString
+			hitScore              float64
+		)
+
+		if err := rows.Scan(&seq, &product.ID, &product.Name, &product.ImageURL, &product.Description, &product.Price,
+			&rating, &product.Category, &product.Brand, &product.Available, &product.Version,
+			&specName, &value, &unit, &hitScore); err != nil {
+			return nil, fmt.Errorf("failed to read product: %w", err)
+		}
+
+		if seq != last {
+			product.Rating = float32(rating)
+			product.Specifications = []domain.Specification{}
+			hits = append(hits, domain.SearchHit{Product: &product, Score: hitScore})
+			last = seq
+		}
+
+		if specName.Valid {
+			current := hits[len(hits)-1].Product
+			current.Specifications = append(current.Specifications, domain.Specification{Name: specName.String, Value: value.String, Unit: unit.String})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read products: %w", err)
+	}
+
+	for _, hit := range hits {
+		withDerivedFields(hit.Product, rules)
+	}
+
+	return hits, nil
+}
+
+// queryProducts ejecuta la consulta base sin puntuación y devuelve los productos
+func queryProducts(q querier, rules *validation.CatalogRules, tail string, args ...interface{}) ([]*domain.Product, error) {
+	hits, err := queryHits(q, rules, "0", tail, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, len(hits))
+	for i, hit := range hits {
+		products[i] = hit.Product
+	}
+
+	return products, nil
+}
+
+// withDerivedFields completa los campos que no se guardan en la base: los valores tipados de
+// las especificaciones, las migas de pan de la categoría, que sin taxonomía tienen un único
+// nivel igual que en el repositorio JSON, y la completitud según el esquema de su categoría.
+// Los productos guardados ya cumplen las reglas, por lo que no se revalidan.
+func withDerivedFields(product *domain.Product, rules *validation.CatalogRules) {
+	product.ParseSpecifications()
+	rules.Apply(product, "")
+}
+
+// GetByID obtiene un producto por su ID
+func (r *ProductRepository) GetByID(id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, &domain.InvalidProductIDError{ID: id}
+	}
+
+	products, err := queryProducts(r.db, r.rules.Load(), `WHERE p.id = ? ORDER BY s.position`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, &domain.ProductNotFoundError{ID: id}
+	}
+
+	return products[0], nil
+}
+
+// GetAll obtiene todos los productos que cumplen el filtro, en orden de inserción. La
+// categoría, la marca, el precio, la disponibilidad y la calificación se resuelven en SQL con
+// los índices; el filtro completo se evalúa después sobre esos candidatos.
+func (r *ProductRepository) GetAll(filter domain.ProductFilter) ([]*domain.Product, error) {
+	rules := r.rules.Load()
+	where, args := filterClause(filter, rules)
+
+	candidates, err := queryProducts(r.db, rules, where+` ORDER BY p.seq, s.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var products []*domain.Product
+	for _, product := range candidates {
+		if filter.Matches(product) {
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+// filterClause traduce a SQL los criterios del filtro que tienen columna propia. Con una
+// taxonomía, la categoría abarca todas sus subcategorías.
+func filterClause(filter domain.ProductFilter, rules *validation.CatalogRules) (string, []interface{}) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if filter.Category != "" {
+		keys := []string{domain.FoldText(strings.TrimSpace(filter.Category))}
+		if taxonomy := rules.Taxonomy(); taxonomy != nil {
+			for _, ref := range taxonomy.Subtree(filter.Category) {
+				keys = append(keys, domain.FoldText(strings.TrimSpace(ref.ID)), domain.FoldText(strings.TrimSpace(ref.Name)), domain.CategorySlug(ref.Name))
+			}
+		}
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+		conditions = append(conditions, "(p.category_key IN ("+placeholders+") OR p.category_slug IN ("+placeholders+"))")
+		keyArgs := make([]interface{}, len(keys))
+		for i, key := range keys {
+			keyArgs[i] = key
+		}
+		args = append(append(args, keyArgs...), keyArgs...)
+	}
+	if filter.Brand != "" {
+		conditions = append(conditions, "p.brand_key = ?")
+		args = append(args, strings.ToLower(filter.Brand))
+	}
+	if filter.MinPrice > 0 {
+		conditions = append(conditions, "p.price >= ?")
+		args = append(args, filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		conditions = append(conditions, "p.price <= ?")
+		args = append(args, filter.MaxPrice)
+	}
+	if filter.Available != nil {
+		conditions = append(conditions, "p.available = ?")
+		args = append(args, *filter.Available)
+	}
+	if filter.MinRating > 0 {
+		// La calificación se guarda como float32; se compara con el mismo redondeo
+		conditions = append(conditions, "p.rating >= ?")
+		args = append(args, float64(float32(filter.MinRating)))
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// GetByIDs obtiene múltiples productos por sus IDs para comparación, en el orden pedido. Si
+// algún ID no existe devuelve los encontrados junto con un error que lista los faltantes.
+func (r *ProductRepository) GetByIDs(ids []string) ([]*domain.Product, error) {
+	if len(ids) == 0 {
+		return []*domain.Product{}, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			return nil, &domain.InvalidProductIDError{ID: id}
+		}
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	found, err := queryProducts(r.db, r.rules.Load(), `WHERE p.id IN (`+strings.Join(placeholders, ", ")+`) ORDER BY p.seq, s.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.Product, len(found))
+	for _, product := range found {
+		byID[product.ID] = product
+	}
+
+	var products []*domain.Product
+	var notFoundIDs []string
+	for _, id := range ids {
+		if product, ok := byID[id]; ok {
+			products = append(products, product)
+		} else {
+			notFoundIDs = append(notFoundIDs, id)
+		}
+	}
+
+	if len(notFoundIDs) > 0 {
+		return products, fmt.Errorf("products not found: %v", notFoundIDs)
+	}
+
+	return products, nil
+}
+
+// normalizeWords normaliza mayúsculas y acentos y reemplaza los separadores por un único
+// espacio, igual que el autocompletado del repositorio JSON
+func normalizeWords(text string) string {
+	return strings.Join(strings.FieldsFunc(domain.FoldText(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
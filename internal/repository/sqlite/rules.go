@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/validation"
+)
+
+// SetTaxonomy organiza las categorías según la taxonomía: cada producto recibe sus migas de
+// pan, los filtros por categoría abarcan todas las subcategorías y las escrituras deben usar
+// una categoría hoja. Devuelve un error, y conserva las reglas anteriores, si algún producto
+// guardado no pertenece a una hoja del árbol.
+func (r *ProductRepository) SetTaxonomy(taxonomy *domain.Taxonomy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := validation.NewCatalogRules(taxonomy, r.rules.Load().Schemas())
+	if err := r.checkRules(rules); err != nil {
+		return fmt.Errorf("products do not match the taxonomy: %w", err)
+	}
+
+	r.rules.Store(rules)
+	return nil
+}
+
+// SetSpecSchemas valida las especificaciones de los productos contra el esquema de su
+// categoría y les asigna su puntaje de completitud. Devuelve un error, y conserva las reglas
+// anteriores, si algún producto guardado no informa una especificación obligatoria o la
+// informa con otro tipo o unidad. Las escrituras posteriores se validan con los esquemas.
+func (r *ProductRepository) SetSpecSchemas(schemas *domain.SpecSchemaSet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := validation.NewCatalogRules(r.rules.Load().Taxonomy(), schemas)
+	if err := r.checkRules(rules); err != nil {
+		return fmt.Errorf("products do not match the spec schemas: %w", err)
+	}
+
+	r.rules.Store(rules)
+	return nil
+}
+
+// checkRules verifica que todos los productos guardados cumplan las reglas indicadas; sus
+// etiquetas validate ya se verificaron al escribirlos. Debe llamarse con r.mu tomado.
+func (r *ProductRepository) checkRules(rules *validation.CatalogRules) error {
+	products, err := queryProducts(r.db, nil, `ORDER BY p.seq, s.position`)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = validation.PrepareCatalog(products, domain.ValidationDisabled, rules)
+	return err
+}
@@ -0,0 +1,312 @@
+package sqlite
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/search"
+	"meli-products-api/internal/validation"
+)
+
+// Search busca productos por nombre, marca, categoría o descripción y los devuelve
+// ordenados por relevancia
+func (r *ProductRepository) Search(query string) ([]*domain.Product, error) {
+	if query == "" {
+		return r.GetAll(domain.ProductFilter{})
+	}
+
+	hits, err := r.SearchRanked(domain.SearchRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, len(hits))
+	for i, hit := range hits {
+		products[i] = hit.Product
+	}
+
+	return products, nil
+}
+
+// SearchRanked busca productos que cumplen el filtro de la búsqueda y devuelve cada
+// coincidencia con su puntuación de relevancia. Como el índice en memoria, analiza la consulta
+// con el analizador de internal/search y exige que cada término coincida como palabra o, si
+// tiene al menos 3 caracteres, como prefijo de palabra; el puntaje es BM25 con los mismos
+// pesos por campo. A diferencia del índice en memoria, no aplica sinónimos ni coincidencia
+// aproximada y no reduce el peso de las coincidencias por prefijo.
+func (r *ProductRepository) SearchRanked(request domain.SearchRequest) ([]domain.SearchHit, error) {
+	var hits []domain.SearchHit
+	var err error
+
+	rules := r.rules.Load()
+	if strings.TrimSpace(request.Query) == "" {
+		hits, err = queryHits(r.db, rules, "0", `ORDER BY p.seq, s.position`)
+	} else {
+		match := matchExpression(request.Query)
+		if match == "" {
+			return []domain.SearchHit{}, nil
+		}
+
+		hits, err = queryHits(r.db, rules, "-"+bm25Rank,
+			`JOIN products_fts ON products_fts.rowid = p.seq
+			WHERE products_fts MATCH ?
+			ORDER BY `+bm25Rank+`, p.seq, s.position`, match)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]domain.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		if request.Filter.Matches(hit.Product) {
+			hit.Score = math.Round(hit.Score*10000) / 10000
+			filtered = append(filtered, hit)
+		}
+	}
+
+	return filtered, nil
+}
+
+// bm25Rank es el puntaje BM25 de FTS5 con los pesos por campo del índice en memoria (nombre,
+// marca, categoría y descripción). FTS5 lo devuelve negativo: cuanto menor, más relevante.
+const bm25Rank = "bm25(products_fts, 3.0, 2.0, 1.5, 1.0)"
+
+// minPrefixLength es la longitud mínima de un término para buscarlo por prefijo, igual que en
+// el índice en memoria
+const minPrefixLength = 3
+
+// matchExpression convierte la consulta en una expresión MATCH de FTS5. Los términos salen del
+// analizador del índice en memoria, que descarta stop words y reduce plurales; como la columna
+// indexada conserva el plural, el término reducido se busca como prefijo. Cada término va entre
+// comillas para que no se interprete como operador.
+func matchExpression(query string) string {
+	words := search.Terms(query)
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"`
+		if len(word) >= minPrefixLength {
+			terms[i] += "*"
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// Autocomplete sugiere productos, marcas y categorías con alguna palabra que comienza con el
+// prefijo, con la misma ponderación que el autocompletado en memoria: los productos por
+// calificación (los no disponibles valen la mitad) y las marcas y categorías por la suma de
+// sus productos
+func (r *ProductRepository) Autocomplete(prefix string, limit int) ([]domain.Suggestion, error) {
+	suggestions := []domain.Suggestion{}
+
+	key := normalizeWords(prefix)
+	if key == "" || limit <= 0 {
+		return suggestions, nil
+	}
+
+	// Las marcas y categorías se agrupan por su texto normalizado; con MIN(seq) SQLite toma
+	// el texto del primer producto del grupo
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(key)
+	start, inner := escaped+"%", "% "+escaped+"%"
+
+	rows, err := r.db.Query(`
+		WITH weighted AS (
+			SELECT *, rating * CASE WHEN available THEN 1.0 ELSE 0.5 END AS weight FROM products
+		)
+		SELECT 'product', name, id, 0, weight, seq FROM weighted
+		WHERE name_words LIKE ? ESCAPE '\' OR name_words LIKE ? ESCAPE '\'
+		UNION ALL
+		SELECT 'brand', brand, '', COUNT(*), SUM(weight), MIN(seq) FROM weighted
+		WHERE brand_words LIKE ? ESCAPE '\' OR brand_words LIKE ? ESCAPE '\'
+		GROUP BY brand_words
+		UNION ALL
+		SELECT 'category', category, '', COUNT(*), SUM(weight), MIN(seq) FROM weighted
+		WHERE category_words LIKE ? ESCAPE '\' OR category_words LIKE ? ESCAPE '\'
+		GROUP BY category_words`,
+		start, inner, start, inner, start, inner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	type entry struct {
+		suggestion domain.Suggestion
+		weight     float64
+	}
+
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		var seq int64
+		if err := rows.Scan(&e.suggestion.Type, &e.suggestion.Text, &e.suggestion.ProductID, &e.suggestion.ProductCount, &e.weight, &seq); err != nil {
+			return nil, fmt.Errorf("failed to read suggestion: %w", err)
+		}
+		e.suggestion.Score = math.Round(e.weight*100) / 100
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read suggestions: %w", err)
+	}
+
+	// Por popularidad, luego por tipo y finalmente por texto
+	typeOrder := map[domain.SuggestionType]int{
+		domain.SuggestionCategory: 0,
+		domain.SuggestionBrand:    1,
+		domain.SuggestionProduct:  2,
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		if typeOrder[a.suggestion.Type] != typeOrder[b.suggestion.Type] {
+			return typeOrder[a.suggestion.Type] < typeOrder[b.suggestion.Type]
+		}
+		return a.suggestion.Text < b.suggestion.Text
+	})
+
+	for _, e := range entries {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestions = append(suggestions, e.suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// GetProductCount devuelve el número total de productos
+func (r *ProductRepository) GetProductCount() int {
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM products`).Scan(&count); err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// GetCategoryTree devuelve las categorías con su cantidad de productos. Con una taxonomía
+// devuelve su árbol con la cantidad de productos de cada subárbol; sin ella, las categorías
+// forman un solo nivel en el orden en que aparecen por primera vez.
+func (r *ProductRepository) GetCategoryTree() []domain.CategoryTreeNode {
+	tree := []domain.CategoryTreeNode{}
+
+	if rules := r.rules.Load(); rules.Taxonomy() != nil {
+		return r.taxonomyTree(rules)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT p.category, c.count
+		FROM products p
+		JOIN (SELECT MIN(seq) AS seq, COUNT(*) AS count FROM products GROUP BY category_slug) c ON c.seq = p.seq
+		ORDER BY p.seq`)
+	if err != nil {
+		return tree
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var node domain.CategoryTreeNode
+		if err := rows.Scan(&node.Name, &node.ProductCount); err != nil {
+			return tree
+		}
+		node.ID = domain.CategorySlug(node.Name)
+		tree = append(tree, node)
+	}
+
+	return tree
+}
+
+// taxonomyTree devuelve el árbol de la taxonomía con la cantidad de productos de cada
+// subárbol: cada categoría de los productos suma a todas sus categorías ancestro
+func (r *ProductRepository) taxonomyTree(rules *validation.CatalogRules) []domain.CategoryTreeNode {
+	counts := make(map[string]int)
+
+	rows, err := r.db.Query(`SELECT MIN(category), COUNT(*) FROM products GROUP BY category_key`)
+	if err != nil {
+		return rules.Taxonomy().CategoryTree(counts)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			break
+		}
+
+		path, _ := rules.CategoryPath(category)
+		for _, ref := range path.Path {
+			counts[domain.FoldText(strings.TrimSpace(ref.ID))] += count
+		}
+	}
+
+	return rules.Taxonomy().CategoryTree(counts)
+}
+
+// HasSpecification indica si algún producto declara la especificación. Los nombres se
+// comparan en Go, ya que lower() de SQLite solo convierte letras ASCII.
+func (r *ProductRepository) HasSpecification(name string) (bool, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT name FROM specifications`)
+	if err != nil {
+		return false, fmt.Errorf("failed to query specifications: %w", err)
+	}
+	defer rows.Close()
+
+	key := strings.ToLower(strings.TrimSpace(name))
+	for rows.Next() {
+		var spec string
+		if err := rows.Scan(&spec); err != nil {
+			return false, fmt.Errorf("failed to read specifications: %w", err)
+		}
+		if strings.ToLower(strings.TrimSpace(spec)) == key {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
+
+// GetBrands devuelve todas las marcas únicas, en el orden en que aparecen por primera vez
+func (r *ProductRepository) GetBrands() []string {
+	brands := []string{}
+
+	rows, err := r.db.Query(`
+		SELECT brand FROM products
+		WHERE seq IN (SELECT MIN(seq) FROM products GROUP BY brand_key)
+		ORDER BY seq`)
+	if err != nil {
+		return brands
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var brand string
+		if err := rows.Scan(&brand); err != nil {
+			return brands
+		}
+		brands = append(brands, brand)
+	}
+
+	return brands
+}
+
+// GetValidationReport devuelve el resultado de la validación de la última importación, o un
+// reporte sin validación con la cantidad actual de productos si no se importaron productos
+func (r *ProductRepository) GetValidationReport() *domain.CatalogValidationReport {
+	if report := r.validation.Load(); report != nil {
+		return report
+	}
+
+	count := r.GetProductCount()
+	return &domain.CatalogValidationReport{
+		Mode:           domain.ValidationDisabled,
+		TotalProducts:  count,
+		LoadedProducts: count,
+		Rejected:       []domain.RejectedProduct{},
+	}
+}
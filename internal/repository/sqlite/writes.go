@@ -0,0 +1,304 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/validation"
+)
+
+// Create agrega un producto nuevo con la versión inicial o, si el ID perteneció a un producto
+// eliminado, con la siguiente a la de ese producto. Devuelve ProductAlreadyExistsError
+// si el ID ya existe y ValidationErrors si el producto no cumple sus etiquetas validate, la
+// taxonomía o el esquema de especificaciones de su categoría.
+func (r *ProductRepository) Create(product *domain.Product) (*domain.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := r.rules.Load()
+
+	return r.inTx(func(tx *sql.Tx) (*domain.Product, error) {
+		if product.ID != "" {
+			if _, _, err := current(tx, product.ID); err == nil {
+				return nil, &domain.ProductAlreadyExistsError{ID: product.ID}
+			} else if !isNotFound(err) {
+				return nil, err
+			}
+		}
+
+		created, errs := validation.PrepareProduct(product, rules)
+		if len(errs) > 0 {
+			return nil, errs
+		}
+
+		deletedVersion, err := deletedVersion(tx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		created.Version = domain.RecreatedProductVersion(deletedVersion)
+		if _, err := insertProduct(tx, created); err != nil {
+			return nil, err
+		}
+
+		return productByID(tx, rules, product.ID)
+	})
+}
+
+// Update reemplaza el producto con el mismo ID, con las mismas validaciones que Create, e
+// incrementa su versión. Devuelve ProductNotFoundError si el ID no existe y
+// ProductVersionConflictError si product.Version no es cero ni la versión actual.
+func (r *ProductRepository) Update(product *domain.Product) (*domain.Product, error) {
+	if product.ID == "" {
+		return nil, &domain.InvalidProductIDError{ID: product.ID}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := r.rules.Load()
+
+	return r.inTx(func(tx *sql.Tx) (*domain.Product, error) {
+		seq, version, err := current(tx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// La transacción toma el bloqueo de escritura al comenzar, así que la versión leída
+		// sigue siendo la vigente hasta el commit
+		if product.Version != 0 && product.Version != version {
+			return nil, &domain.ProductVersionConflictError{ID: product.ID, Expected: product.Version, Current: version}
+		}
+
+		updated, errs := validation.PrepareProduct(product, rules)
+		if len(errs) > 0 {
+			return nil, errs
+		}
+
+		updated.Version = version + 1
+		if err := updateProduct(tx, seq, updated); err != nil {
+			return nil, err
+		}
+
+		return productByID(tx, rules, product.ID)
+	})
+}
+
+// Delete elimina el producto y lo devuelve; su versión se conserva para que un producto nuevo
+// con el mismo ID no la repita. Devuelve ProductNotFoundError si el ID no existe.
+func (r *ProductRepository) Delete(id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, &domain.InvalidProductIDError{ID: id}
+	}
+
+	return r.inTx(func(tx *sql.Tx) (*domain.Product, error) {
+		deleted, err := productByID(tx, r.rules.Load(), id)
+		if err != nil {
+			return nil, err
+		}
+		seq, _, err := current(tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		// Las especificaciones se eliminan en cascada
+		if _, err := tx.Exec(`DELETE FROM products WHERE seq = ?`, seq); err != nil {
+			return nil, fmt.Errorf("failed to delete product: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM products_fts WHERE rowid = ?`, seq); err != nil {
+			return nil, fmt.Errorf("failed to delete product from search index: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO deleted_products (id, version) VALUES (?, ?)`, id, deleted.Version); err != nil {
+			return nil, fmt.Errorf("failed to record deleted product: %w", err)
+		}
+
+		return deleted, nil
+	})
+}
+
+// Import agrega los productos en una sola transacción, validados según el modo indicado
+// contra sus etiquetas validate, la taxonomía y los esquemas de especificaciones: en modo
+// estricto no agrega ninguno si alguno es inválido, en modo permisivo descarta los inválidos
+// y los informa en GetValidationReport. Los productos sin versión empiezan en la versión
+// inicial, y los de un ID eliminado continúan su numeración. Devuelve
+// ProductAlreadyExistsError si algún ID ya existe.
+func (r *ProductRepository) Import(products []*domain.Product, mode domain.CatalogValidationMode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parsed := make([]*domain.Product, len(products))
+	for i, product := range products {
+		copied := *product
+		copied.Specifications = append([]domain.Specification(nil), product.Specifications...)
+		copied.ParseSpecifications()
+		parsed[i] = &copied
+	}
+
+	valid, report, err := validation.PrepareCatalog(parsed, mode, r.rules.Load())
+	if err != nil {
+		return fmt.Errorf("invalid products: %w", err)
+	}
+
+	_, err = r.inTx(func(tx *sql.Tx) (*domain.Product, error) {
+		for _, product := range valid {
+			if _, _, err := current(tx, product.ID); err == nil {
+				return nil, &domain.ProductAlreadyExistsError{ID: product.ID}
+			} else if !isNotFound(err) {
+				return nil, err
+			}
+
+			deletedVersion, err := deletedVersion(tx, product.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			imported := *product
+			imported.Version = max(imported.Version, domain.RecreatedProductVersion(deletedVersion))
+			if _, err := insertProduct(tx, &imported); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.validation.Store(report)
+	return nil
+}
+
+// inTx ejecuta la operación en una transacción y la confirma solo si no hubo errores
+func (r *ProductRepository) inTx(operation func(tx *sql.Tx) (*domain.Product, error)) (*domain.Product, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	product, err := operation(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return product, nil
+}
+
+// current devuelve la posición y la versión del producto, o ProductNotFoundError
+func current(tx *sql.Tx, id string) (seq, version int64, err error) {
+	err = tx.QueryRow(`SELECT seq, version FROM products WHERE id = ?`, id).Scan(&seq, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, &domain.ProductNotFoundError{ID: id}
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read product: %w", err)
+	}
+
+	return seq, version, nil
+}
+
+// isNotFound indica si el error es ProductNotFoundError
+func isNotFound(err error) bool {
+	var notFound *domain.ProductNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// deletedVersion devuelve la última versión del producto eliminado con el ID indicado, o cero
+// si no se eliminó ninguno
+func deletedVersion(tx *sql.Tx, id string) (int64, error) {
+	var version int64
+	err := tx.QueryRow(`SELECT version FROM deleted_products WHERE id = ?`, id).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to read deleted product: %w", err)
+	}
+
+	return version, nil
+}
+
+// productByID lee el producto dentro de la transacción, tal como quedó escrito
+func productByID(tx *sql.Tx, rules *validation.CatalogRules, id string) (*domain.Product, error) {
+	products, err := queryProducts(tx, rules, `WHERE p.id = ? ORDER BY s.position`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, &domain.ProductNotFoundError{ID: id}
+	}
+
+	return products[0], nil
+}
+
+// productColumns devuelve los valores de las columnas editables de products, incluidas las
+// normalizadas, en el orden de insertProduct y updateProduct
+func productColumns(product *domain.Product) []interface{} {
+	return []interface{}{
+		product.Name, product.ImageURL, product.Description, product.Price, float64(product.Rating),
+		product.Category, product.Brand, product.Available, product.Version,
+		domain.FoldText(strings.TrimSpace(product.Category)), domain.CategorySlug(product.Category),
+		strings.ToLower(product.Brand),
+		normalizeWords(product.Name), normalizeWords(product.Brand), normalizeWords(product.Category),
+	}
+}
+
+// insertProduct inserta el producto con sus especificaciones y su entrada en el índice de
+// texto completo y devuelve su posición
+func insertProduct(tx *sql.Tx, product *domain.Product) (int64, error) {
+	result, err := tx.Exec(`INSERT INTO products (id, name, image_url, description, price, rating, category, brand,
+		available, version, category_key, category_slug, brand_key, name_words, brand_words, category_words)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		append([]interface{}{product.ID}, productColumns(product)...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert product: %w", err)
+	}
+
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert product: %w", err)
+	}
+
+	return seq, writeDetails(tx, seq, product)
+}
+
+// updateProduct reemplaza las columnas, las especificaciones y la entrada en el índice de
+// texto completo del producto en la posición indicada
+func updateProduct(tx *sql.Tx, seq int64, product *domain.Product) error {
+	if _, err := tx.Exec(`UPDATE products SET name = ?, image_url = ?, description = ?, price = ?, rating = ?,
+		category = ?, brand = ?, available = ?, version = ?, category_key = ?, category_slug = ?, brand_key = ?,
+		name_words = ?, brand_words = ?, category_words = ?
+		WHERE seq = ?`, append(productColumns(product), seq)...); err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM specifications WHERE product_seq = ?`, seq); err != nil {
+		return fmt.Errorf("failed to update specifications: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM products_fts WHERE rowid = ?`, seq); err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+
+	return writeDetails(tx, seq, product)
+}
+
+// writeDetails inserta las especificaciones y la entrada en el índice de texto completo
+func writeDetails(tx *sql.Tx, seq int64, product *domain.Product) error {
+	for i, spec := range product.Specifications {
+		if _, err := tx.Exec(`INSERT INTO specifications (product_seq, position, name, value, unit) VALUES (?, ?, ?, ?, ?)`,
+			seq, i, spec.Name, spec.Value, spec.Unit); err != nil {
+			return fmt.Errorf("failed to insert specification: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO products_fts (rowid, name, brand, category, description) VALUES (?, ?, ?, ?, ?)`,
+		seq, product.Name, product.Brand, product.Category, product.Description); err != nil {
+		return fmt.Errorf("failed to index product: %w", err)
+	}
+
+	return nil
+}
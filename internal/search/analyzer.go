@@ -0,0 +1,110 @@
+/*
+Package search implementa la búsqueda de texto completo sobre el catálogo de productos.
+
+El paquete no depende de ningún mecanismo de almacenamiento: construye un índice
+invertido en memoria a partir de los productos y lo consulta con un puntaje de
+relevancia BM25 ponderado por campo.
+
+Características:
+- Tokenización de texto en español e inglés
+- Normalización de mayúsculas y acentos ("Audífonos" coincide con "audifonos")
+- Eliminación de stop words y reducción simple de plurales
+- Puntaje BM25 con pesos por campo (nombre > marca > categoría > descripción)
+- Coincidencia por prefijo para términos incompletos
+- Coincidencia aproximada y sugerencia de consultas corregidas para términos mal escritos
+- Expansión de consultas con un diccionario de sinónimos reemplazable en caliente
+*/
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"meli-products-api/domain"
+)
+
+// stopWords contiene palabras vacías en español e inglés que no aportan a la relevancia
+var stopWords = map[string]bool{
+	// Español
+	"de": true, "del": true, "la": true, "las": true, "el": true, "los": true,
+	"un": true, "una": true, "unos": true, "unas": true, "y": true, "o": true,
+	"con": true, "sin": true, "para": true, "por": true, "en": true, "al": true,
+	"que": true, "se": true, "su": true, "sus": true, "es": true, "lo": true,
+	"mas": true, "muy": true,
+	// Inglés
+	"the": true, "and": true, "or": true, "with": true, "without": true,
+	"for": true, "of": true, "in": true, "an": true, "to": true, "on": true,
+	"by": true, "is": true, "at": true, "from": true, "a": true,
+}
+
+// Fold convierte el texto a minúsculas y elimina los acentos
+func Fold(text string) string {
+	return domain.FoldText(text)
+}
+
+// Token representa un término extraído de un texto
+type Token struct {
+	// Término normalizado utilizado para indexar y buscar
+	Term string
+
+	// Posición del token dentro del texto (contando solo tokens indexables)
+	Position int
+
+	// Desplazamiento en bytes del token dentro del texto original
+	Start int
+	End   int
+}
+
+// Analyze divide el texto en tokens normalizados, descartando stop words
+func Analyze(text string) []Token {
+	var tokens []Token
+	position := 0
+
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		word := Fold(text[start:end])
+		if !stopWords[word] {
+			tokens = append(tokens, Token{Term: stem(word), Position: position, Start: start, End: end})
+			position++
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+
+	return tokens
+}
+
+// Terms devuelve solo los términos normalizados del texto
+func Terms(text string) []string {
+	tokens := Analyze(text)
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = token.Term
+	}
+
+	return terms
+}
+
+// stem reduce plurales simples ("smartphones" -> "smartphone", "pantallas" -> "pantalla").
+// Es deliberadamente conservador para no confundir términos distintos.
+func stem(word string) string {
+	if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return word[:len(word)-1]
+	}
+
+	return word
+}
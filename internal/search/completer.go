@@ -0,0 +1,192 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"meli-products-api/domain"
+)
+
+// completionEntry es un texto que puede sugerirse al autocompletar
+type completionEntry struct {
+	suggestion domain.Suggestion
+	weight     float64
+}
+
+// typeOrder define la prioridad de cada tipo de sugerencia ante igual popularidad
+var typeOrder = map[domain.SuggestionType]int{
+	domain.SuggestionCategory: 0,
+	domain.SuggestionBrand:    1,
+	domain.SuggestionProduct:  2,
+}
+
+// trieNode es un nodo del árbol de prefijos. Cada nodo guarda las mejores entradas de su
+// subárbol para responder sin recorrerlo.
+type trieNode struct {
+	children map[rune]*trieNode
+	entries  []int
+	top      []int
+}
+
+// Completer sugiere nombres de productos, marcas y categorías a partir de un prefijo,
+// usando un árbol de prefijos sobre cada palabra del texto normalizado
+type Completer struct {
+	root    *trieNode
+	entries []completionEntry
+}
+
+// NewCompleter construye el árbol de prefijos a partir de los productos. Los productos se
+// ponderan por calificación (los no disponibles valen la mitad) y las marcas y categorías
+// por la suma de la ponderación de sus productos.
+func NewCompleter(products []*domain.Product) *Completer {
+	c := &Completer{root: &trieNode{}}
+
+	brands := make(map[string]int)
+	categories := make(map[string]int)
+
+	group := func(index map[string]int, kind domain.SuggestionType, text string, weight float64) {
+		key := normalizeCompletion(text)
+		if key == "" {
+			return
+		}
+
+		position, exists := index[key]
+		if !exists {
+			position = len(c.entries)
+			index[key] = position
+			c.entries = append(c.entries, completionEntry{suggestion: domain.Suggestion{Text: text, Type: kind}})
+		}
+
+		c.entries[position].suggestion.ProductCount++
+		c.entries[position].weight += weight
+	}
+
+	for _, product := range products {
+		weight := float64(product.Rating)
+		if !product.Available {
+			weight /= 2
+		}
+
+		if normalizeCompletion(product.Name) != "" {
+			c.entries = append(c.entries, completionEntry{
+				suggestion: domain.Suggestion{Text: product.Name, Type: domain.SuggestionProduct, ProductID: product.ID},
+				weight:     weight,
+			})
+		}
+
+		group(brands, domain.SuggestionBrand, product.Brand, weight)
+		group(categories, domain.SuggestionCategory, product.Category, weight)
+	}
+
+	for i := range c.entries {
+		c.entries[i].suggestion.Score = math.Round(c.entries[i].weight*100) / 100
+		c.insert(i)
+	}
+
+	c.collectTop(c.root)
+
+	return c
+}
+
+// insert agrega la entrada al árbol bajo cada sufijo que comienza en una palabra
+func (c *Completer) insert(entry int) {
+	words := strings.Fields(normalizeCompletion(c.entries[entry].suggestion.Text))
+
+	for i := range words {
+		node := c.root
+		for _, r := range strings.Join(words[i:], " ") {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			child, ok := node.children[r]
+			if !ok {
+				child = &trieNode{}
+				node.children[r] = child
+			}
+			node = child
+		}
+		node.entries = append(node.entries, entry)
+	}
+}
+
+// collectTop calcula las mejores entradas de cada subárbol
+func (c *Completer) collectTop(node *trieNode) []int {
+	seen := make(map[int]bool)
+	var candidates []int
+
+	add := func(entries []int) {
+		for _, entry := range entries {
+			if !seen[entry] {
+				seen[entry] = true
+				candidates = append(candidates, entry)
+			}
+		}
+	}
+
+	add(node.entries)
+	for _, child := range node.children {
+		add(c.collectTop(child))
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return c.less(candidates[i], candidates[j])
+	})
+	if len(candidates) > domain.MaxSuggestionLimit {
+		candidates = candidates[:domain.MaxSuggestionLimit]
+	}
+
+	node.top = candidates
+	return candidates
+}
+
+// less ordena por popularidad, luego por tipo y finalmente por texto
+func (c *Completer) less(a, b int) bool {
+	ea, eb := c.entries[a], c.entries[b]
+
+	if ea.weight != eb.weight {
+		return ea.weight > eb.weight
+	}
+	if typeOrder[ea.suggestion.Type] != typeOrder[eb.suggestion.Type] {
+		return typeOrder[ea.suggestion.Type] < typeOrder[eb.suggestion.Type]
+	}
+
+	return ea.suggestion.Text < eb.suggestion.Text
+}
+
+// Complete devuelve hasta limit sugerencias para el prefijo indicado
+func (c *Completer) Complete(prefix string, limit int) []domain.Suggestion {
+	suggestions := []domain.Suggestion{}
+
+	key := normalizeCompletion(prefix)
+	if key == "" || limit <= 0 {
+		return suggestions
+	}
+
+	node := c.root
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			return suggestions
+		}
+		node = child
+	}
+
+	for _, entry := range node.top {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestions = append(suggestions, c.entries[entry].suggestion)
+	}
+
+	return suggestions
+}
+
+// normalizeCompletion normaliza mayúsculas y acentos y reemplaza los separadores por un
+// único espacio, para que "S24-Ultra" y "s24 ultra" sean equivalentes
+func normalizeCompletion(text string) string {
+	return strings.Join(strings.FieldsFunc(Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,227 @@
+package search
+
+import (
+	"html"
+	"strings"
+
+	"meli-products-api/domain"
+)
+
+// Parámetros de los fragmentos resaltados
+const (
+	// highlightOpen y highlightClose marcan los términos coincidentes en los fragmentos
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+
+	// maxSnippetLength es la longitud máxima en bytes del fragmento de la descripción
+	maxSnippetLength = 160
+
+	// snippetContext es la cantidad de bytes que se muestran antes de la primera coincidencia
+	snippetContext = 40
+)
+
+// Explain devuelve las coincidencias del producto con la consulta y los componentes de su
+// puntuación. Reproduce la elección de Search: en cada grupo de términos se queda con la
+// mejor coincidencia entre los propios términos y sus sinónimos, de modo que la suma de los
+// aportes es la puntuación del resultado. Devuelve nil si el producto no coincide.
+func (idx *Index) Explain(query string, product *domain.Product) *domain.SearchExplanation {
+	doc, ok := idx.docByID[product.ID]
+	if !ok {
+		return nil
+	}
+
+	groups := idx.synonyms.Load().group(Terms(query))
+	if len(groups) == 0 {
+		return nil
+	}
+
+	explanation := &domain.SearchExplanation{Matches: []domain.TermMatch{}}
+	var total float64
+
+	for _, group := range groups {
+		matches, score, matched := idx.explainTerms(group.terms, true, doc)
+
+		phrase := strings.Join(group.terms, " ")
+		for _, synonym := range group.synonyms {
+			synonymMatches, synonymScore, synonymMatched := idx.explainTerms(synonym, false, doc)
+			if !synonymMatched || synonymScore*synonymWeight <= score {
+				continue
+			}
+
+			for i := range synonymMatches {
+				synonymMatches[i].QueryTerm = phrase
+				synonymMatches[i].MatchType = domain.MatchSynonym
+				synonymMatches[i].Weight *= synonymWeight
+				synonymMatches[i].Score *= synonymWeight
+			}
+			matches, score, matched = synonymMatches, synonymScore*synonymWeight, true
+		}
+
+		if !matched {
+			return nil
+		}
+
+		explanation.Matches = append(explanation.Matches, matches...)
+		total += score
+	}
+
+	for i := range explanation.Matches {
+		explanation.Matches[i].Weight = roundScore(explanation.Matches[i].Weight)
+		explanation.Matches[i].Score = roundScore(explanation.Matches[i].Score)
+	}
+	explanation.Score = roundScore(total)
+
+	return explanation
+}
+
+// explainTerms devuelve las coincidencias de la mejor alternativa de cada término en el
+// documento y la suma de sus puntajes, sin redondear. Informa false si algún término no coincide.
+func (idx *Index) explainTerms(terms []string, fuzzy bool, doc int) ([]domain.TermMatch, float64, bool) {
+	var matches []domain.TermMatch
+	var total float64
+
+	for _, term := range terms {
+		var best []domain.TermMatch
+		var bestScore float64
+
+		for _, alt := range idx.expand(term, fuzzy) {
+			idf := idx.idf(alt.term)
+
+			var candidate []domain.TermMatch
+			var score float64
+			for _, p := range idx.postings[alt.term] {
+				if p.doc != doc {
+					continue
+				}
+
+				fieldScore := idx.scorePosting(p, idf)
+				score += fieldScore
+				candidate = append(candidate, domain.TermMatch{
+					QueryTerm:     term,
+					Term:          alt.term,
+					Field:         p.field.String(),
+					MatchType:     alt.kind,
+					TermFrequency: len(p.positions),
+					IDF:           roundScore(idf),
+					FieldBoost:    fieldBoosts[p.field],
+					Weight:        alt.weight,
+					Score:         fieldScore * alt.weight,
+				})
+			}
+
+			if weighted := score * alt.weight; weighted > bestScore {
+				best, bestScore = candidate, weighted
+			}
+		}
+
+		if best == nil {
+			return nil, 0, false
+		}
+
+		matches = append(matches, best...)
+		total += bestScore
+	}
+
+	return matches, total, true
+}
+
+// Highlight devuelve el nombre y la descripción del producto con los términos que
+// coincidieron con la consulta marcados con <em>. El texto se escapa para HTML y las
+// descripciones largas se recortan alrededor de la primera coincidencia. Los campos sin
+// coincidencias se omiten.
+func (idx *Index) Highlight(query string, product *domain.Product) map[string]string {
+	highlights := make(map[string]string)
+
+	explanation := idx.Explain(query, product)
+	if explanation == nil {
+		return highlights
+	}
+
+	matched := make(map[string]bool)
+	for _, match := range explanation.Matches {
+		matched[match.Term] = true
+	}
+
+	if text, ok := highlight(product.Name, matched, 0); ok {
+		highlights[FieldName.String()] = text
+	}
+	if text, ok := highlight(product.Description, matched, maxSnippetLength); ok {
+		highlights[FieldDescription.String()] = text
+	}
+
+	return highlights
+}
+
+// highlight marca los tokens del texto cuyos términos están en matched. Si maxLength es
+// mayor que cero y el texto lo supera, devuelve solo un fragmento alrededor de la primera
+// coincidencia. Informa false si ningún token coincide.
+func highlight(text string, matched map[string]bool, maxLength int) (string, bool) {
+	tokens := Analyze(text)
+
+	first := -1
+	for i, token := range tokens {
+		if matched[token.Term] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start, end := 0, len(text)
+	if maxLength > 0 && len(text) > maxLength {
+		start, end = snippetBounds(text, tokens, first, maxLength)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+
+	last := start
+	for _, token := range tokens[first:] {
+		if token.End > end {
+			break
+		}
+		if !matched[token.Term] {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(text[last:token.Start]))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(text[token.Start:token.End]))
+		b.WriteString(highlightClose)
+		last = token.End
+	}
+	b.WriteString(html.EscapeString(text[last:end]))
+
+	if end < len(text) {
+		b.WriteString("…")
+	}
+
+	return b.String(), true
+}
+
+// snippetBounds devuelve los límites en bytes de un fragmento de a lo sumo maxLength bytes
+// que comienza poco antes del token first. Los límites coinciden con bordes de tokens para
+// no cortar palabras.
+func snippetBounds(text string, tokens []Token, first, maxLength int) (int, int) {
+	start := tokens[first].Start
+	for i := first - 1; i >= 0 && tokens[first].Start-tokens[i].Start <= snippetContext; i-- {
+		start = tokens[i].Start
+	}
+
+	end := tokens[first].End
+	for _, token := range tokens[first:] {
+		if token.End-start > maxLength {
+			break
+		}
+		end = token.End
+	}
+	if len(text)-start <= maxLength {
+		end = len(text)
+	}
+
+	return start, end
+}
@@ -0,0 +1,233 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"meli-products-api/domain"
+)
+
+// fuzzyWeight reduce el aporte de los términos que solo coinciden de forma aproximada.
+// Se divide por la cantidad de ediciones necesarias.
+const fuzzyWeight = 0.4
+
+// correctableFields son los campos cuyo vocabulario se usa para corregir términos mal escritos
+var correctableFields = map[Field]bool{
+	FieldName:     true,
+	FieldBrand:    true,
+	FieldCategory: true,
+}
+
+// MaxEdits devuelve la cantidad máxima de ediciones tolerada para un término según su
+// longitud: los términos cortos deben escribirse exactamente.
+func MaxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EditDistance calcula la distancia de edición entre dos términos (inserciones, borrados,
+// sustituciones y transposiciones de caracteres adyacentes). Si la distancia supera max
+// devuelve max+1 sin terminar el cálculo.
+func EditDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	// Se conservan las dos filas anteriores para contemplar transposiciones
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+
+		if rowMin > max {
+			return max + 1
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	if prev[len(rb)] > max {
+		return max + 1
+	}
+
+	return prev[len(rb)]
+}
+
+// minInt devuelve el menor de los valores
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+
+	return min
+}
+
+// correction representa un término del vocabulario cercano a un término mal escrito
+type correction struct {
+	term  string
+	edits int
+	freq  int
+}
+
+// corrections devuelve los términos del vocabulario de nombres, marcas y categorías que están
+// a una distancia tolerable del término, de más cercano a más lejano y, en empate, de más
+// a menos frecuente.
+func (idx *Index) corrections(term string) []correction {
+	max := MaxEdits(term)
+	if max == 0 {
+		return nil
+	}
+
+	var result []correction
+	length := len([]rune(term))
+
+	for l := length - max; l <= length+max; l++ {
+		for _, candidate := range idx.correctableByLength[l] {
+			if candidate == term {
+				continue
+			}
+			if edits := EditDistance(term, candidate, max); edits <= max {
+				result = append(result, correction{term: candidate, edits: edits, freq: idx.correctable[candidate]})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].edits != result[j].edits {
+			return result[i].edits < result[j].edits
+		}
+		if result[i].freq != result[j].freq {
+			return result[i].freq > result[j].freq
+		}
+		return result[i].term < result[j].term
+	})
+
+	return result
+}
+
+// fuzzyAlternatives devuelve las correcciones de un término como alternativas de búsqueda
+func (idx *Index) fuzzyAlternatives(term string) []alternative {
+	var alternatives []alternative
+
+	for _, c := range idx.corrections(term) {
+		alternatives = append(alternatives, alternative{term: c.term, weight: fuzzyWeight / float64(c.edits), kind: domain.MatchFuzzy})
+	}
+
+	return alternatives
+}
+
+// Suggest propone hasta limit consultas corregidas reemplazando los términos que no
+// existen en el catálogo por los términos más cercanos del vocabulario. Solo se proponen
+// consultas que devuelven resultados.
+func (idx *Index) Suggest(query string, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+
+	tokens := Analyze(query)
+
+	type replacement struct {
+		token   Token
+		options []string
+	}
+
+	var replacements []replacement
+	for _, token := range tokens {
+		if _, known := idx.postings[token.Term]; known || idx.synonyms.Load().has(token.Term) {
+			continue
+		}
+
+		corrections := idx.corrections(token.Term)
+		if len(corrections) == 0 {
+			continue
+		}
+
+		options := make([]string, 0, limit)
+		for _, c := range corrections {
+			if len(options) == limit {
+				break
+			}
+			options = append(options, idx.surface[c.term])
+		}
+		replacements = append(replacements, replacement{token: token, options: options})
+	}
+
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	// rewrite construye la consulta usando la opción choice[i] para cada reemplazo
+	rewrite := func(choice []int) string {
+		var b strings.Builder
+		last := 0
+		for i, r := range replacements {
+			b.WriteString(query[last:r.token.Start])
+			b.WriteString(r.options[choice[i]])
+			last = r.token.End
+		}
+		b.WriteString(query[last:])
+		return b.String()
+	}
+
+	// La primera propuesta usa la mejor corrección de cada término; las siguientes
+	// varían un término a la vez con sus correcciones alternativas
+	candidates := []string{rewrite(make([]int, len(replacements)))}
+	for i, r := range replacements {
+		for option := 1; option < len(r.options); option++ {
+			choice := make([]int, len(replacements))
+			choice[i] = option
+			candidates = append(candidates, rewrite(choice))
+		}
+	}
+
+	var suggestions []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if len(suggestions) == limit {
+			break
+		}
+		if seen[candidate] || strings.EqualFold(candidate, query) {
+			continue
+		}
+		seen[candidate] = true
+
+		if len(idx.Search(candidate)) > 0 {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	return suggestions
+}
@@ -0,0 +1,364 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"meli-products-api/domain"
+)
+
+// Field identifica un campo indexado del producto
+type Field int
+
+// Campos indexados, en orden de importancia
+const (
+	FieldName Field = iota
+	FieldBrand
+	FieldCategory
+	FieldDescription
+	fieldCount
+)
+
+// String devuelve el nombre del campo tal como se expone en la API
+func (f Field) String() string {
+	switch f {
+	case FieldName:
+		return "name"
+	case FieldBrand:
+		return "brand"
+	case FieldCategory:
+		return "category"
+	case FieldDescription:
+		return "description"
+	}
+
+	return "unknown"
+}
+
+// fieldBoosts define el peso de cada campo en el puntaje de relevancia
+var fieldBoosts = [fieldCount]float64{
+	FieldName:        3.0,
+	FieldBrand:       2.0,
+	FieldCategory:    1.5,
+	FieldDescription: 1.0,
+}
+
+// Parámetros de BM25
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// prefixWeight reduce el aporte de los términos que solo coinciden por prefijo
+	prefixWeight = 0.5
+
+	// minPrefixLength es la longitud mínima de un término para buscar por prefijo
+	minPrefixLength = 3
+)
+
+// posting representa la aparición de un término en un campo de un documento
+type posting struct {
+	doc       int
+	field     Field
+	positions []int
+}
+
+// Index es un índice invertido de productos con puntaje BM25 por campo
+type Index struct {
+	docs         []*domain.Product
+	docByID      map[string]int
+	postings     map[string][]posting
+	docFreq      map[string]int
+	fieldLengths [][fieldCount]int
+	avgLength    [fieldCount]float64
+	vocabulary   []string
+
+	// Vocabulario de nombres, marcas y categorías utilizado para corregir términos:
+	// frecuencia por término, términos agrupados por longitud y forma original de cada término
+	correctable         map[string]int
+	correctableByLength map[int][]string
+	surface             map[string]string
+
+	// Diccionario de sinónimos aplicado al expandir las consultas; se reemplaza atómicamente
+	synonyms atomic.Pointer[Synonyms]
+}
+
+// fieldText devuelve el texto de un campo del producto
+func fieldText(product *domain.Product, field Field) string {
+	switch field {
+	case FieldName:
+		return product.Name
+	case FieldBrand:
+		return product.Brand
+	case FieldCategory:
+		return product.Category
+	case FieldDescription:
+		return product.Description
+	}
+
+	return ""
+}
+
+// NewIndex construye el índice a partir de los productos
+func NewIndex(products []*domain.Product) *Index {
+	idx := &Index{
+		docs:         products,
+		docByID:      make(map[string]int, len(products)),
+		postings:     make(map[string][]posting),
+		docFreq:      make(map[string]int),
+		fieldLengths: make([][fieldCount]int, len(products)),
+
+		correctable:         make(map[string]int),
+		correctableByLength: make(map[int][]string),
+		surface:             make(map[string]string),
+	}
+
+	var totalLength [fieldCount]int
+
+	for doc, product := range products {
+		idx.docByID[product.ID] = doc
+		seen := make(map[string]bool)
+
+		for field := Field(0); field < fieldCount; field++ {
+			text := fieldText(product, field)
+			tokens := Analyze(text)
+			idx.fieldLengths[doc][field] = len(tokens)
+			totalLength[field] += len(tokens)
+
+			if correctableFields[field] {
+				idx.addCorrectable(text, tokens)
+			}
+
+			positions := make(map[string][]int)
+			var order []string
+			for _, token := range tokens {
+				if _, exists := positions[token.Term]; !exists {
+					order = append(order, token.Term)
+				}
+				positions[token.Term] = append(positions[token.Term], token.Position)
+			}
+
+			for _, term := range order {
+				idx.postings[term] = append(idx.postings[term], posting{
+					doc:       doc,
+					field:     field,
+					positions: positions[term],
+				})
+				if !seen[term] {
+					seen[term] = true
+					idx.docFreq[term]++
+				}
+			}
+		}
+	}
+
+	if len(products) > 0 {
+		for field := Field(0); field < fieldCount; field++ {
+			idx.avgLength[field] = float64(totalLength[field]) / float64(len(products))
+		}
+	}
+
+	idx.vocabulary = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.vocabulary = append(idx.vocabulary, term)
+	}
+	sort.Strings(idx.vocabulary)
+
+	return idx
+}
+
+// addCorrectable registra los términos de un campo en el vocabulario de corrección
+func (idx *Index) addCorrectable(text string, tokens []Token) {
+	for _, token := range tokens {
+		if _, exists := idx.correctable[token.Term]; !exists {
+			length := len([]rune(token.Term))
+			idx.correctableByLength[length] = append(idx.correctableByLength[length], token.Term)
+			idx.surface[token.Term] = strings.ToLower(text[token.Start:token.End])
+		}
+		idx.correctable[token.Term]++
+	}
+}
+
+// Size devuelve la cantidad de documentos indexados
+func (idx *Index) Size() int {
+	return len(idx.docs)
+}
+
+// alternative representa un término del índice que puede satisfacer un término de la consulta
+type alternative struct {
+	term   string
+	weight float64
+
+	// Tipo de coincidencia (domain.MatchExact, domain.MatchPrefix o domain.MatchFuzzy)
+	kind string
+}
+
+// SetSynonyms reemplaza el diccionario de sinónimos utilizado por las búsquedas siguientes.
+// Las búsquedas en curso terminan con el diccionario anterior.
+func (idx *Index) SetSynonyms(synonyms *Synonyms) {
+	idx.synonyms.Store(synonyms)
+}
+
+// expand devuelve los términos del índice que satisfacen un término de la consulta:
+// el propio término y, si es suficientemente largo, los términos que lo tienen como prefijo.
+// Si ninguno existe y fuzzy lo permite, se recurre a los términos cercanos por distancia de edición.
+func (idx *Index) expand(term string, fuzzy bool) []alternative {
+	alternatives := idx.exactAlternatives(term)
+	if len(alternatives) == 0 && fuzzy {
+		alternatives = idx.fuzzyAlternatives(term)
+	}
+
+	return alternatives
+}
+
+// exactAlternatives devuelve el propio término y los términos que lo tienen como prefijo
+func (idx *Index) exactAlternatives(term string) []alternative {
+	var alternatives []alternative
+
+	if _, ok := idx.postings[term]; ok {
+		alternatives = append(alternatives, alternative{term: term, weight: 1, kind: domain.MatchExact})
+	}
+
+	if len(term) < minPrefixLength {
+		return alternatives
+	}
+
+	for i := sort.SearchStrings(idx.vocabulary, term); i < len(idx.vocabulary); i++ {
+		candidate := idx.vocabulary[i]
+		if !strings.HasPrefix(candidate, term) {
+			break
+		}
+		if candidate != term {
+			alternatives = append(alternatives, alternative{term: candidate, weight: prefixWeight, kind: domain.MatchPrefix})
+		}
+	}
+
+	return alternatives
+}
+
+// Search devuelve los productos que contienen todos los términos de la consulta, ordenados
+// por relevancia. Una consulta vacía devuelve todos los productos en su orden original.
+func (idx *Index) Search(query string) []domain.SearchHit {
+	if strings.TrimSpace(query) == "" {
+		hits := make([]domain.SearchHit, len(idx.docs))
+		for i, product := range idx.docs {
+			hits[i] = domain.SearchHit{Product: product}
+		}
+		return hits
+	}
+
+	groups := idx.synonyms.Load().group(Terms(query))
+	if len(groups) == 0 {
+		return []domain.SearchHit{}
+	}
+
+	scores := make(map[int]float64)
+	matched := make(map[int]int)
+
+	for _, group := range groups {
+		// Cada grupo aporta su mejor coincidencia por documento: sus propios términos o,
+		// con menor peso, los de alguno de sus sinónimos
+		groupScores := idx.matchAll(group.terms, true)
+		for _, synonym := range group.synonyms {
+			for doc, score := range idx.matchAll(synonym, false) {
+				if weighted := score * synonymWeight; weighted > groupScores[doc] {
+					groupScores[doc] = weighted
+				}
+			}
+		}
+
+		for doc, score := range groupScores {
+			scores[doc] += score
+			matched[doc]++
+		}
+	}
+
+	hits := make([]domain.SearchHit, 0, len(scores))
+	docs := make([]int, 0, len(scores))
+	for doc, count := range matched {
+		if count == len(groups) {
+			docs = append(docs, doc)
+		}
+	}
+	sort.Ints(docs)
+
+	for _, doc := range docs {
+		hits = append(hits, domain.SearchHit{Product: idx.docs[doc], Score: roundScore(scores[doc])})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	return hits
+}
+
+// scoreTerm calcula el puntaje BM25 ponderado por campo de un término en cada documento
+func (idx *Index) scoreTerm(term string) map[int]float64 {
+	scores := make(map[int]float64)
+
+	idf := idx.idf(term)
+	for _, p := range idx.postings[term] {
+		scores[p.doc] += idx.scorePosting(p, idf)
+	}
+
+	return scores
+}
+
+// idf calcula la frecuencia inversa de documentos de un término
+func (idx *Index) idf(term string) float64 {
+	n := float64(len(idx.docs))
+	df := float64(idx.docFreq[term])
+
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// scorePosting calcula el puntaje BM25 ponderado de la aparición de un término en un campo
+func (idx *Index) scorePosting(p posting, idf float64) float64 {
+	tf := float64(len(p.positions))
+	length := float64(idx.fieldLengths[p.doc][p.field])
+
+	norm := 1.0
+	if avg := idx.avgLength[p.field]; avg > 0 {
+		norm = 1 - bm25B + bm25B*length/avg
+	}
+
+	return fieldBoosts[p.field] * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+}
+
+// matchAll devuelve los documentos que contienen todos los términos, con la suma del
+// puntaje de la mejor alternativa de cada término
+func (idx *Index) matchAll(terms []string, fuzzy bool) map[int]float64 {
+	var result map[int]float64
+
+	for i, term := range terms {
+		termScores := make(map[int]float64)
+		for _, alt := range idx.expand(term, fuzzy) {
+			for doc, score := range idx.scoreTerm(alt.term) {
+				if weighted := score * alt.weight; weighted > termScores[doc] {
+					termScores[doc] = weighted
+				}
+			}
+		}
+
+		if i == 0 {
+			result = termScores
+			continue
+		}
+		for doc := range result {
+			if score, ok := termScores[doc]; ok {
+				result[doc] += score
+			} else {
+				delete(result, doc)
+			}
+		}
+	}
+
+	return result
+}
+
+// roundScore redondea el puntaje a 4 decimales para exponerlo en la API
+func roundScore(score float64) float64 {
+	return math.Round(score*10000) / 10000
+}
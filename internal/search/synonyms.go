@@ -0,0 +1,142 @@
+package search
+
+import (
+	"strings"
+
+	"meli-products-api/domain"
+)
+
+// synonymWeight reduce el aporte de los términos que solo coinciden a través de un sinónimo
+const synonymWeight = 0.8
+
+// Synonyms es un diccionario de sinónimos compilado a partir de un domain.SynonymSet.
+// Las frases se guardan como secuencias de términos normalizados, de modo que "Teléfonos
+// celulares" y "telefono celular" resuelven a la misma entrada. Es inmutable una vez
+// construido, por lo que puede consultarse concurrentemente.
+type Synonyms struct {
+	// Alternativas de cada frase, indexadas por sus términos unidos por espacios
+	expansions map[string][][]string
+
+	// Cantidad máxima de términos de una frase con sinónimos
+	maxLength int
+}
+
+// NewSynonyms compila el conjunto de sinónimos. Las reglas bidireccionales relacionan
+// cada término con todos los demás; las de un solo sentido, cada término de From con
+// todos los de To.
+func NewSynonyms(set *domain.SynonymSet) *Synonyms {
+	s := &Synonyms{expansions: make(map[string][][]string)}
+	if set == nil {
+		return s
+	}
+
+	for _, rule := range set.Rules {
+		if len(rule.Equivalent) > 0 {
+			for _, from := range rule.Equivalent {
+				for _, to := range rule.Equivalent {
+					s.add(from, to)
+				}
+			}
+			continue
+		}
+
+		for _, from := range rule.From {
+			for _, to := range rule.To {
+				s.add(from, to)
+			}
+		}
+	}
+
+	return s
+}
+
+// add registra to como alternativa de from, ignorando duplicados y frases vacías
+func (s *Synonyms) add(from, to string) {
+	fromTerms, toTerms := Terms(from), Terms(to)
+	key, alternative := strings.Join(fromTerms, " "), strings.Join(toTerms, " ")
+	if key == "" || alternative == "" || key == alternative {
+		return
+	}
+
+	for _, existing := range s.expansions[key] {
+		if strings.Join(existing, " ") == alternative {
+			return
+		}
+	}
+
+	s.expansions[key] = append(s.expansions[key], toTerms)
+	if len(fromTerms) > s.maxLength {
+		s.maxLength = len(fromTerms)
+	}
+}
+
+// Len devuelve la cantidad de frases con sinónimos
+func (s *Synonyms) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return len(s.expansions)
+}
+
+// Lookup devuelve las alternativas de una palabra o frase, o nil si no tiene sinónimos
+func (s *Synonyms) Lookup(phrase string) []string {
+	if s == nil {
+		return nil
+	}
+
+	var alternatives []string
+	for _, terms := range s.expansions[strings.Join(Terms(phrase), " ")] {
+		alternatives = append(alternatives, strings.Join(terms, " "))
+	}
+
+	return alternatives
+}
+
+// termGroup es una secuencia de términos de la consulta que debe coincidir como unidad,
+// ya sea con sus propios términos o con los de alguno de sus sinónimos
+type termGroup struct {
+	terms    []string
+	synonyms [][]string
+}
+
+// group divide los términos de la consulta en grupos, reconociendo de izquierda a derecha
+// la frase con sinónimos más larga en cada posición. Los grupos repetidos se descartan.
+func (s *Synonyms) group(terms []string) []termGroup {
+	var groups []termGroup
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(terms); {
+		group := termGroup{terms: terms[i : i+1]}
+
+		if s != nil {
+			for length := s.maxLength; length > 0; length-- {
+				if i+length > len(terms) {
+					continue
+				}
+				if synonyms, ok := s.expansions[strings.Join(terms[i:i+length], " ")]; ok {
+					group = termGroup{terms: terms[i : i+length], synonyms: synonyms}
+					break
+				}
+			}
+		}
+
+		i += len(group.terms)
+		if key := strings.Join(group.terms, " "); !seen[key] {
+			seen[key] = true
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// has indica si el término tiene sinónimos por sí solo
+func (s *Synonyms) has(term string) bool {
+	if s == nil {
+		return false
+	}
+
+	_, ok := s.expansions[term]
+	return ok
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"meli-products-api/domain"
+)
+
+// ValidateCatalog valida los productos de un catálogo según el modo indicado. En modo estricto
+// devuelve un error con todos los campos inválidos de todos los productos; en modo permisivo
+// devuelve solo los productos válidos y el reporte lista los descartados. Sin validación se
+// devuelven todos.
+func ValidateCatalog(products []*domain.Product, mode domain.CatalogValidationMode) ([]*domain.Product, *domain.CatalogValidationReport, error) {
+	report := &domain.CatalogValidationReport{
+		Mode:          mode,
+		ValidatedAt:   time.Now().UTC(),
+		TotalProducts: len(products),
+		Rejected:      []domain.RejectedProduct{},
+	}
+
+	if mode == domain.ValidationDisabled || mode == "" {
+		report.Mode = domain.ValidationDisabled
+		report.LoadedProducts = len(products)
+		return products, report, nil
+	}
+
+	var all domain.ValidationErrors
+	valid := make([]*domain.Product, 0, len(products))
+
+	for i, product := range products {
+		errs := ValidateProduct(product, fmt.Sprintf("products[%d]", i))
+		if len(errs) == 0 {
+			valid = append(valid, product)
+			continue
+		}
+
+		for _, err := range errs {
+			all = append(all, &domain.ValidationError{Field: err.Field, Message: fmt.Sprintf("product %s: %s", product.ID, err.Message)})
+		}
+		report.Rejected = append(report.Rejected, domain.RejectedProduct{Index: i, ProductID: product.ID, Errors: errs})
+	}
+
+	if mode == domain.ValidationStrict && len(all) > 0 {
+		return nil, nil, all
+	}
+
+	report.LoadedProducts = len(valid)
+	return valid, report, nil
+}
@@ -0,0 +1,83 @@
+/*
+Package validation aplica las etiquetas validate de las entidades de dominio.
+
+Los errores se informan como domain.ValidationErrors, con el nombre JSON de cada campo
+("image_url", "specifications[0].value"), de modo que se pueden devolver tal cual al
+cliente o prefijar con la posición del producto en un archivo ("products[3].price").
+*/
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"meli-products-api/domain"
+)
+
+// productValidator aplica las etiquetas validate de domain.Product y domain.Specification
+var productValidator = newValidator()
+
+// newValidator crea un validador que nombra los campos por su nombre JSON
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return v
+}
+
+// ValidateProduct devuelve un error de validación por cada campo del producto que no cumple
+// sus etiquetas validate. Si se indica un prefijo, los campos se informan debajo de él
+// ("products[3]" -> "products[3].price").
+func ValidateProduct(product *domain.Product, prefix string) domain.ValidationErrors {
+	err := productValidator.Struct(product)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return domain.ValidationErrors{{Field: prefix, Message: err.Error()}}
+	}
+
+	errs := make(domain.ValidationErrors, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		// El namespace comienza con el nombre del tipo ("Product.price")
+		_, field, _ := strings.Cut(fieldErr.Namespace(), ".")
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		errs[i] = &domain.ValidationError{Field: field, Message: message(fieldErr)}
+	}
+
+	return errs
+}
+
+// message describe en texto la regla que no cumple el campo
+func message(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "url":
+		return "must be a valid URL"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fieldErr.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fieldErr.Param())
+	case "lt":
+		return fmt.Sprintf("must be less than %s", fieldErr.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fieldErr.Param())
+	}
+
+	return fmt.Sprintf("failed the '%s' rule", fieldErr.Tag())
+}
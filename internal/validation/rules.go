@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"meli-products-api/domain"
+)
+
+// CatalogRules son las reglas que dependen de la configuración del catálogo, además de las
+// etiquetas validate de cada producto: la taxonomía de categorías, a cuyas hojas deben
+// pertenecer los productos, y los esquemas de especificaciones por categoría. Ambas son
+// opcionales y un valor nil no aplica ninguna. Es inmutable, por lo que puede usarse
+// concurrentemente.
+type CatalogRules struct {
+	taxonomy *domain.Taxonomy
+	schemas  *domain.SpecSchemaSet
+
+	// Camino de cada categoría de la taxonomía por su ID y su nombre normalizados
+	paths map[string]domain.CategoryPath
+}
+
+// NewCatalogRules crea las reglas del catálogo con la taxonomía y los esquemas indicados
+func NewCatalogRules(taxonomy *domain.Taxonomy, schemas *domain.SpecSchemaSet) *CatalogRules {
+	rules := &CatalogRules{taxonomy: taxonomy, schemas: schemas}
+	if taxonomy != nil {
+		rules.paths = taxonomy.Paths()
+	}
+
+	return rules
+}
+
+// Taxonomy devuelve la taxonomía de categorías; nil si las categorías son planas
+func (c *CatalogRules) Taxonomy() *domain.Taxonomy {
+	if c == nil {
+		return nil
+	}
+
+	return c.taxonomy
+}
+
+// Schemas devuelve los esquemas de especificaciones; nil si no se validan las especificaciones
+func (c *CatalogRules) Schemas() *domain.SpecSchemaSet {
+	if c == nil {
+		return nil
+	}
+
+	return c.schemas
+}
+
+// CategoryPath devuelve el camino de la categoría en la taxonomía, indicada por nombre o
+// por ID sin distinguir mayúsculas ni acentos
+func (c *CatalogRules) CategoryPath(category string) (domain.CategoryPath, bool) {
+	if c == nil {
+		return domain.CategoryPath{}, false
+	}
+
+	path, ok := c.paths[domain.FoldText(strings.TrimSpace(category))]
+	return path, ok
+}
+
+// Apply asigna al producto sus migas de pan y su puntaje de completitud y devuelve un error
+// de validación por cada regla que no cumple, con el prefijo indicado ("products[3]") o sin
+// prefijo. Sin taxonomía, o si la categoría no es una hoja de ella, las migas de pan tienen
+// un único nivel. Las especificaciones del producto ya deben estar interpretadas.
+func (c *CatalogRules) Apply(product *domain.Product, prefix string) domain.ValidationErrors {
+	var errs domain.ValidationErrors
+
+	product.Breadcrumbs = []domain.CategoryRef{{ID: domain.CategorySlug(product.Category), Name: product.Category}}
+	if c.Taxonomy() != nil {
+		path, ok := c.CategoryPath(product.Category)
+		switch {
+		case !ok:
+			errs = append(errs, &domain.ValidationError{
+				Field:   prefixed(prefix, "category"),
+				Message: fmt.Sprintf("product %s has unknown category '%s'", product.ID, product.Category),
+			})
+		case !path.Leaf:
+			errs = append(errs, &domain.ValidationError{
+				Field:   prefixed(prefix, "category"),
+				Message: fmt.Sprintf("product %s must belong to a leaf category, '%s' has subcategories", product.ID, product.Category),
+			})
+		default:
+			product.Breadcrumbs = path.Path
+		}
+	}
+
+	attributes := c.Schemas().AttributesFor(product)
+	for _, err := range domain.ValidateSpecifications(product, attributes, prefix) {
+		err.Field = strings.TrimPrefix(err.Field, ".")
+		errs = append(errs, err)
+	}
+	product.Completeness = domain.Completeness(product, attributes)
+
+	return errs
+}
+
+// prefixed antepone el prefijo al campo, si lo hay
+func prefixed(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+
+	return prefix + "." + field
+}
+
+// PrepareProduct devuelve una copia del producto con sus especificaciones interpretadas, sus
+// migas de pan y su completitud, o los errores de validación si no cumple sus etiquetas
+// validate o las reglas del catálogo
+func PrepareProduct(product *domain.Product, rules *CatalogRules) (*domain.Product, domain.ValidationErrors) {
+	if errs := ValidateProduct(product, ""); len(errs) > 0 {
+		return nil, errs
+	}
+
+	copied := *product
+	copied.Specifications = append([]domain.Specification(nil), product.Specifications...)
+	copied.ParseSpecifications()
+	if errs := rules.Apply(&copied, ""); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &copied, nil
+}
+
+// PrepareCatalog valida los productos como ValidateCatalog y además contra las reglas del
+// catálogo, y devuelve copias de los válidos con sus migas de pan y su completitud. Un
+// producto que no cumple las reglas se descarta en modo permisivo, y se informa en el reporte
+// junto a los que no cumplen sus etiquetas; en los demás modos se devuelve un error con todos
+// los incumplimientos. Las especificaciones de los productos ya deben estar interpretadas.
+func PrepareCatalog(products []*domain.Product, mode domain.CatalogValidationMode, rules *CatalogRules) ([]*domain.Product, *domain.CatalogValidationReport, error) {
+	_, report, err := ValidateCatalog(products, mode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skip := make(map[int]bool, len(report.Rejected))
+	for _, rejected := range report.Rejected {
+		skip[rejected.Index] = true
+	}
+
+	prepared := make([]*domain.Product, 0, len(products))
+	var rejected []domain.RejectedProduct
+
+	for i, product := range products {
+		if skip[i] {
+			continue
+		}
+
+		// La copia evita modificar los productos de otro catálogo
+		copied := *product
+		if errs := rules.Apply(&copied, fmt.Sprintf("products[%d]", i)); len(errs) > 0 {
+			rejected = append(rejected, domain.RejectedProduct{Index: i, ProductID: product.ID, Errors: errs})
+			continue
+		}
+		prepared = append(prepared, &copied)
+	}
+
+	if len(rejected) > 0 {
+		if mode != domain.ValidationLenient {
+			var all domain.ValidationErrors
+			for _, product := range rejected {
+				all = append(all, product.Errors...)
+			}
+			return nil, nil, all
+		}
+
+		report.Rejected = append(report.Rejected, rejected...)
+		sort.SliceStable(report.Rejected, func(i, j int) bool {
+			return report.Rejected[i].Index < report.Rejected[j].Index
+		})
+	}
+	report.LoadedProducts = len(prepared)
+
+	return prepared, report, nil
+}
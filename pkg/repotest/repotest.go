@@ -0,0 +1,614 @@
+/*
+Package repotest verifica que una implementación de domain.ProductRepository cumpla el
+comportamiento que esperan los handlers de la API.
+
+Cada backend prueba su conformidad con una sola llamada desde un test:
+
+	func TestMyRepository(t *testing.T) {
+		repotest.Run(t, func(t *testing.T, products []*domain.Product) domain.ProductRepository {
+			return newMyRepository(t, products)
+		})
+	}
+
+La suite cubre los errores de ID inexistente e inválido, el filtrado de categorías sin
+distinguir mayúsculas ni acentos, los límites de precio, los resultados parciales de
+GetByIDs, la semántica de la búsqueda y el acceso concurrente, que conviene ejecutar con
+-race. Si el repositorio implementa domain.WritableProductRepository se verifican también
+las escrituras, y si organiza las categorías con SetTaxonomy, los filtros por subárbol, las
+migas de pan y el árbol de categorías con la taxonomía de Taxonomy; si no, esos subtests se
+omiten. Un repositorio que no cumple el contrato hace fallar el subtest correspondiente sin
+interrumpir el resto de la suite.
+*/
+package repotest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+// Factory crea un repositorio que contiene exactamente los productos indicados, en ese
+// orden. Cada llamada debe devolver un repositorio independiente; los recursos que use se
+// liberan con t.Cleanup.
+type Factory func(t *testing.T, products []*domain.Product) domain.ProductRepository
+
+// Products devuelve los productos con los que la suite crea los repositorios. Cada llamada
+// devuelve copias nuevas.
+func Products() []*domain.Product {
+	return []*domain.Product{
+		{
+			ID: "RT-PHONE-1", Name: "Samsung Galaxy S24", ImageURL: "https://example.com/s24.jpg",
+			Description: "Smartphone con cámara de 200 MP", Price: 1200, Rating: 4.5,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "12", Unit: "GB"}},
+			Category:       "Smartphones", Brand: "Samsung", Available: true, Version: 1,
+		},
+		{
+			ID: "RT-PHONE-2", Name: "iPhone 15 Pro", ImageURL: "https://example.com/iphone.jpg",
+			Description: "Smartphone con chip A17 Pro", Price: 1400, Rating: 4.8,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "8", Unit: "GB"}},
+			Category:       "Smartphones", Brand: "Apple", Available: false, Version: 1,
+		},
+		{
+			ID: "RT-HOME-1", Name: "Cafetera Espresso", ImageURL: "https://example.com/cafetera.jpg",
+			Description: "Prepara café en casa", Price: 150, Rating: 4.0,
+			Specifications: []domain.Specification{},
+			Category:       "Electrodomésticos", Brand: "Oster", Available: true, Version: 1,
+		},
+		{
+			ID: "RT-LAPTOP-1", Name: "MacBook Air", ImageURL: "https://example.com/macbook.jpg",
+			Description: "Laptop liviana con chip M3", Price: 999.99, Rating: 4.7,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "16", Unit: "GB"}},
+			Category:       "Laptops", Brand: "Apple", Available: true, Version: 1,
+		},
+		{
+			ID: "RT-AUDIO-1", Name: "Galaxy Buds", ImageURL: "https://example.com/buds.jpg",
+			Description: "Auriculares inalámbricos", Price: 149.99, Rating: 4.1,
+			Specifications: []domain.Specification{},
+			Category:       "Audio", Brand: "Samsung", Available: true, Version: 1,
+		},
+	}
+}
+
+// Taxonomy devuelve una taxonomía en la que cada categoría de Products, y la de los productos
+// que crea la suite, es una hoja
+func Taxonomy() *domain.Taxonomy {
+	return &domain.Taxonomy{Categories: []domain.CategoryNode{
+		{ID: "electronica", Name: "Electrónica", Children: []domain.CategoryNode{
+			{ID: "telefonia", Name: "Telefonía", Children: []domain.CategoryNode{
+				{ID: "smartphones", Name: "Smartphones"},
+			}},
+			{ID: "computacion", Name: "Computación", Children: []domain.CategoryNode{
+				{ID: "laptops", Name: "Laptops"},
+			}},
+			{ID: "audio", Name: "Audio"},
+		}},
+		{ID: "hogar", Name: "Hogar", Children: []domain.CategoryNode{
+			{ID: "electrodomesticos", Name: "Electrodomésticos"},
+		}},
+	}}
+}
+
+// taxonomyRepository es un repositorio que organiza las categorías según una taxonomía
+type taxonomyRepository interface {
+	SetTaxonomy(taxonomy *domain.Taxonomy) error
+	GetCategoryTree() []domain.CategoryTreeNode
+}
+
+// Run ejecuta la suite completa sobre repositorios creados con factory
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("GetByID", func(t *testing.T) { testGetByID(t, factory) })
+	t.Run("GetAll", func(t *testing.T) { testGetAll(t, factory) })
+	t.Run("GetByIDs", func(t *testing.T) { testGetByIDs(t, factory) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, factory) })
+	t.Run("Concurrencia", func(t *testing.T) { testConcurrentReads(t, factory) })
+	t.Run("Escrituras", func(t *testing.T) { testWrites(t, writable(t, factory)) })
+	t.Run("Escrituras concurrentes", func(t *testing.T) { testConcurrentWrites(t, writable(t, factory)) })
+	t.Run("Taxonomía", func(t *testing.T) { testTaxonomy(t, factory) })
+}
+
+// writable crea un repositorio para el subtest y omite el subtest si el repositorio no
+// admite escrituras
+func writable(t *testing.T, factory Factory) domain.WritableProductRepository {
+	t.Helper()
+
+	repo, ok := factory(t, Products()).(domain.WritableProductRepository)
+	if !ok {
+		t.Skip("the repository does not implement domain.WritableProductRepository")
+	}
+
+	return repo
+}
+
+// ids devuelve los IDs de los productos en orden
+func ids(products []*domain.Product) []string {
+	result := make([]string, len(products))
+	for i, product := range products {
+		result[i] = product.ID
+	}
+
+	return result
+}
+
+// sameIDs indica si los productos tienen exactamente los IDs esperados, sin importar el orden
+func sameIDs(products []*domain.Product, want ...string) bool {
+	got := ids(products)
+	sort.Strings(got)
+	sorted := append([]string(nil), want...)
+	sort.Strings(sorted)
+
+	return fmt.Sprint(got) == fmt.Sprint(sorted)
+}
+
+func testGetByID(t *testing.T, factory Factory) {
+	repo := factory(t, Products())
+
+	t.Run("Producto existente con todos sus campos", func(t *testing.T) {
+		want := Products()[0]
+
+		product, err := repo.GetByID(want.ID)
+		if err != nil || product == nil {
+			t.Fatalf("GetByID() = %v, %v", product, err)
+		}
+		if product.ID != want.ID || product.Name != want.Name || product.ImageURL != want.ImageURL ||
+			product.Description != want.Description || product.Price != want.Price || product.Rating != want.Rating ||
+			product.Category != want.Category || product.Brand != want.Brand || product.Available != want.Available {
+			t.Errorf("GetByID() = %+v, want %+v", product, want)
+		}
+		if len(product.Specifications) != 1 {
+			t.Fatalf("GetByID() specifications = %+v, want 1", product.Specifications)
+		}
+		if spec := product.Specifications[0]; spec.Name != "RAM" || spec.Value != "12" || spec.Unit != "GB" {
+			t.Errorf("GetByID() specifications = %+v", product.Specifications)
+		}
+		if product.Specifications[0].Typed.Kind != domain.SpecKindNumber {
+			t.Errorf("expected typed specification values, got %+v", product.Specifications[0].Typed)
+		}
+		if product.Version < domain.InitialProductVersion {
+			t.Errorf("expected a version of at least %d, got %d", domain.InitialProductVersion, product.Version)
+		}
+	})
+
+	t.Run("Producto inexistente", func(t *testing.T) {
+		_, err := repo.GetByID("RT-MISSING")
+
+		var notFound *domain.ProductNotFoundError
+		if !errors.As(err, &notFound) || notFound.ID != "RT-MISSING" {
+			t.Errorf("GetByID() error = %v (%T), want *domain.ProductNotFoundError", err, err)
+		}
+	})
+
+	t.Run("ID vacío", func(t *testing.T) {
+		_, err := repo.GetByID("")
+
+		var invalid *domain.InvalidProductIDError
+		if !errors.As(err, &invalid) {
+			t.Errorf("GetByID() error = %v (%T), want *domain.InvalidProductIDError", err, err)
+		}
+	})
+
+	t.Run("Los IDs distinguen mayúsculas", func(t *testing.T) {
+		if _, err := repo.GetByID(strings.ToLower(Products()[0].ID)); err == nil {
+			t.Error("expected no product for a differently cased ID")
+		}
+	})
+}
+
+func testGetAll(t *testing.T, factory Factory) {
+	repo := factory(t, Products())
+	available := true
+
+	tests := []struct {
+		name   string
+		filter domain.ProductFilter
+		want   []string
+	}{
+		{"Filtro vacío", domain.ProductFilter{}, []string{"RT-PHONE-1", "RT-PHONE-2", "RT-HOME-1", "RT-LAPTOP-1", "RT-AUDIO-1"}},
+		{"Categoría con otras mayúsculas", domain.ProductFilter{Category: "SMARTPHONES"}, []string{"RT-PHONE-1", "RT-PHONE-2"}},
+		{"Categoría en minúsculas", domain.ProductFilter{Category: "laptops"}, []string{"RT-LAPTOP-1"}},
+		{"Categoría sin acentos", domain.ProductFilter{Category: "electrodomesticos"}, []string{"RT-HOME-1"}},
+		{"Categoría inexistente", domain.ProductFilter{Category: "Tablets"}, nil},
+		{"Marca sin distinguir mayúsculas", domain.ProductFilter{Brand: "APPLE"}, []string{"RT-PHONE-2", "RT-LAPTOP-1"}},
+		{"Precio mínimo inclusivo", domain.ProductFilter{MinPrice: 1200}, []string{"RT-PHONE-1", "RT-PHONE-2"}},
+		{"Precio máximo inclusivo", domain.ProductFilter{MaxPrice: 150}, []string{"RT-HOME-1", "RT-AUDIO-1"}},
+		{"Rango de precios", domain.ProductFilter{MinPrice: 149.99, MaxPrice: 999.99}, []string{"RT-HOME-1", "RT-LAPTOP-1", "RT-AUDIO-1"}},
+		{"Rango de precios vacío", domain.ProductFilter{MinPrice: 1500}, nil},
+		{"Disponibilidad", domain.ProductFilter{Brand: "apple", Available: &available}, []string{"RT-LAPTOP-1"}},
+		{"Calificación mínima", domain.ProductFilter{MinRating: 4.7}, []string{"RT-PHONE-2", "RT-LAPTOP-1"}},
+		{"Especificación", domain.ProductFilter{Specs: []domain.SpecPredicate{{Name: "RAM", Operator: domain.OpGreaterOrEqual, Value: "12"}}}, []string{"RT-PHONE-1", "RT-LAPTOP-1"}},
+		{"Criterios combinados", domain.ProductFilter{Category: "smartphones", Brand: "samsung", MaxPrice: 1300}, []string{"RT-PHONE-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, err := repo.GetAll(tt.filter)
+			if err != nil {
+				t.Fatalf("GetAll() error = %v", err)
+			}
+			if !sameIDs(products, tt.want...) {
+				t.Errorf("GetAll() = %v, want %v", ids(products), tt.want)
+			}
+		})
+	}
+}
+
+func testGetByIDs(t *testing.T, factory Factory) {
+	repo := factory(t, Products())
+
+	t.Run("Todos encontrados en el orden pedido", func(t *testing.T) {
+		products, err := repo.GetByIDs([]string{"RT-AUDIO-1", "RT-PHONE-1"})
+		if err != nil {
+			t.Fatalf("GetByIDs() error = %v", err)
+		}
+		if got := fmt.Sprint(ids(products)); got != "[RT-AUDIO-1 RT-PHONE-1]" {
+			t.Errorf("GetByIDs() = %s, want [RT-AUDIO-1 RT-PHONE-1]", got)
+		}
+	})
+
+	t.Run("Resultados parciales", func(t *testing.T) {
+		products, err := repo.GetByIDs([]string{"RT-HOME-1", "RT-MISSING", "RT-LAPTOP-1"})
+		if err == nil {
+			t.Fatal("GetByIDs() expected an error for the missing ID")
+		}
+		if !strings.Contains(err.Error(), "RT-MISSING") {
+			t.Errorf("GetByIDs() error %q should name the missing ID", err)
+		}
+		if got := fmt.Sprint(ids(products)); got != "[RT-HOME-1 RT-LAPTOP-1]" {
+			t.Errorf("GetByIDs() = %s, want the found products [RT-HOME-1 RT-LAPTOP-1]", got)
+		}
+	})
+
+	t.Run("Ninguno encontrado", func(t *testing.T) {
+		products, err := repo.GetByIDs([]string{"RT-MISSING"})
+		if err == nil || len(products) != 0 {
+			t.Errorf("GetByIDs() = %v, %v; want no products and an error", ids(products), err)
+		}
+	})
+
+	t.Run("Lista vacía", func(t *testing.T) {
+		products, err := repo.GetByIDs([]string{})
+		if err != nil || products == nil || len(products) != 0 {
+			t.Errorf("GetByIDs() = %v, %v; want an empty list", products, err)
+		}
+	})
+
+	t.Run("ID vacío", func(t *testing.T) {
+		_, err := repo.GetByIDs([]string{"RT-PHONE-1", ""})
+
+		var invalid *domain.InvalidProductIDError
+		if !errors.As(err, &invalid) {
+			t.Errorf("GetByIDs() error = %v (%T), want *domain.InvalidProductIDError", err, err)
+		}
+	})
+}
+
+func testSearch(t *testing.T, factory Factory) {
+	repo := factory(t, Products())
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"Por nombre", "galaxy", []string{"RT-PHONE-1", "RT-AUDIO-1"}},
+		{"Sin distinguir mayúsculas", "GALAXY", []string{"RT-PHONE-1", "RT-AUDIO-1"}},
+		{"Por descripción", "chip", []string{"RT-PHONE-2", "RT-LAPTOP-1"}},
+		{"Por marca", "oster", []string{"RT-HOME-1"}},
+		{"Sin acentos", "cafe", []string{"RT-HOME-1"}},
+		{"Por prefijo", "macb", []string{"RT-LAPTOP-1"}},
+		{"Término corto sin prefijo", "ai", nil},
+		{"Plural", "cafeteras", []string{"RT-HOME-1"}},
+		{"Ignora palabras vacías", "cafetera de espresso", []string{"RT-HOME-1"}},
+		{"Solo palabras vacías", "de la", nil},
+		{"Sin resultados", "xyzzy", nil},
+		{"Consulta vacía devuelve todos", "", []string{"RT-PHONE-1", "RT-PHONE-2", "RT-HOME-1", "RT-LAPTOP-1", "RT-AUDIO-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, err := repo.Search(tt.query)
+			if err != nil {
+				t.Fatalf("Search(%q) error = %v", tt.query, err)
+			}
+			if !sameIDs(products, tt.want...) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, ids(products), tt.want)
+			}
+		})
+	}
+
+	t.Run("El más relevante primero", func(t *testing.T) {
+		products, err := repo.Search("galaxy buds")
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(products) == 0 || products[0].ID != "RT-AUDIO-1" {
+			t.Errorf("Search(galaxy buds) = %v, want RT-AUDIO-1 first", ids(products))
+		}
+	})
+}
+
+// concurrency es la cantidad de goroutines de los tests de concurrencia
+const concurrency = 16
+
+func testConcurrentReads(t *testing.T, factory Factory) {
+	repo := factory(t, Products())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 20; j++ {
+				if product, err := repo.GetByID("RT-PHONE-1"); err != nil || product == nil || product.ID != "RT-PHONE-1" {
+					errs <- fmt.Errorf("GetByID() = %v, %v", product, err)
+					return
+				}
+				if products, err := repo.GetAll(domain.ProductFilter{Category: "smartphones"}); err != nil || len(products) != 2 {
+					errs <- fmt.Errorf("GetAll() = %v, %v", ids(products), err)
+					return
+				}
+				if products, err := repo.GetByIDs([]string{"RT-HOME-1", "RT-AUDIO-1"}); err != nil || len(products) != 2 {
+					errs <- fmt.Errorf("GetByIDs() = %v, %v", ids(products), err)
+					return
+				}
+				if products, err := repo.Search("galaxy"); err != nil || len(products) != 2 {
+					errs <- fmt.Errorf("Search() = %v, %v", ids(products), err)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// newProduct devuelve un producto válido que no está entre los productos de la suite
+func newProduct(id string) *domain.Product {
+	return &domain.Product{
+		ID: id, Name: "Pixel 9", ImageURL: "https://example.com/pixel.jpg",
+		Description: "Smartphone Google", Price: 799, Rating: 4.4,
+		Specifications: []domain.Specification{{Name: "RAM", Value: "12", Unit: "GB"}},
+		Category:       "Smartphones", Brand: "Google", Available: true,
+	}
+}
+
+func testWrites(t *testing.T, repo domain.WritableProductRepository) {
+	t.Run("Create", func(t *testing.T) {
+		created, err := repo.Create(newProduct("RT-NEW-1"))
+		if err != nil || created == nil {
+			t.Fatalf("Create() = %v, %v", created, err)
+		}
+		if created.Version != domain.InitialProductVersion {
+			t.Errorf("expected version %d, got %d", domain.InitialProductVersion, created.Version)
+		}
+		if product, err := repo.GetByID("RT-NEW-1"); err != nil || product == nil || product.Name != "Pixel 9" {
+			t.Errorf("GetByID() after Create = %v, %v", product, err)
+		}
+		if products, _ := repo.GetAll(domain.ProductFilter{Category: "smartphones"}); len(products) != 3 {
+			t.Errorf("expected the new product in the category filter, got %v", ids(products))
+		}
+		if products, _ := repo.Search("pixel"); !sameIDs(products, "RT-NEW-1") {
+			t.Errorf("expected the new product to be searchable, got %v", ids(products))
+		}
+
+		var exists *domain.ProductAlreadyExistsError
+		if _, err := repo.Create(newProduct("RT-NEW-1")); !errors.As(err, &exists) {
+			t.Errorf("Create() duplicate error = %v (%T), want *domain.ProductAlreadyExistsError", err, err)
+		}
+
+		invalid := newProduct("RT-NEW-2")
+		invalid.Price = 0
+		var validationErrs domain.ValidationErrors
+		if _, err := repo.Create(invalid); !errors.As(err, &validationErrs) {
+			t.Errorf("Create() invalid error = %v (%T), want domain.ValidationErrors", err, err)
+		}
+		if _, err := repo.GetByID("RT-NEW-2"); err == nil {
+			t.Error("an invalid product should not be created")
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		product := newProduct("RT-NEW-1")
+		product.Version = domain.InitialProductVersion
+		product.Price = 699
+
+		updated, err := repo.Update(product)
+		if err != nil || updated == nil {
+			t.Fatalf("Update() = %v, %v", updated, err)
+		}
+		if updated.Price != 699 || updated.Version != domain.InitialProductVersion+1 {
+			t.Errorf("Update() = %+v", updated)
+		}
+
+		var conflict *domain.ProductVersionConflictError
+		if _, err := repo.Update(product); !errors.As(err, &conflict) {
+			t.Errorf("Update() stale version error = %v (%T), want *domain.ProductVersionConflictError", err, err)
+		}
+
+		var notFound *domain.ProductNotFoundError
+		if _, err := repo.Update(newProduct("RT-MISSING")); !errors.As(err, &notFound) {
+			t.Errorf("Update() missing error = %v (%T), want *domain.ProductNotFoundError", err, err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		deleted, err := repo.Delete("RT-NEW-1")
+		if err != nil || deleted == nil || deleted.ID != "RT-NEW-1" {
+			t.Fatalf("Delete() = %v, %v", deleted, err)
+		}
+
+		var notFound *domain.ProductNotFoundError
+		if _, err := repo.GetByID("RT-NEW-1"); !errors.As(err, &notFound) {
+			t.Errorf("GetByID() after Delete error = %v, want *domain.ProductNotFoundError", err)
+		}
+		if _, err := repo.Delete("RT-NEW-1"); !errors.As(err, &notFound) {
+			t.Errorf("Delete() missing error = %v (%T), want *domain.ProductNotFoundError", err, err)
+		}
+		if products, _ := repo.Search("pixel"); len(products) != 0 {
+			t.Errorf("deleted product should not be searchable, got %v", ids(products))
+		}
+	})
+
+	t.Run("Create después de Delete continúa la versión", func(t *testing.T) {
+		created, err := repo.Create(newProduct("RT-NEW-1"))
+		if err != nil || created == nil {
+			t.Fatalf("Create() = %v, %v", created, err)
+		}
+		// El producto eliminado estaba en la versión siguiente a la inicial
+		if created.Version != domain.InitialProductVersion+2 {
+			t.Errorf("expected version %d, got %d", domain.InitialProductVersion+2, created.Version)
+		}
+
+		stale := newProduct("RT-NEW-1")
+		stale.Version = domain.InitialProductVersion
+		var conflict *domain.ProductVersionConflictError
+		if _, err := repo.Update(stale); !errors.As(err, &conflict) {
+			t.Errorf("Update() with a version of the deleted product error = %v (%T), want *domain.ProductVersionConflictError", err, err)
+		}
+	})
+}
+
+func testTaxonomy(t *testing.T, factory Factory) {
+	repo := factory(t, Products())
+	organized, ok := repo.(taxonomyRepository)
+	if !ok {
+		t.Skip("the repository does not organize categories with a taxonomy")
+	}
+	if err := organized.SetTaxonomy(Taxonomy()); err != nil {
+		t.Fatalf("SetTaxonomy() error = %v", err)
+	}
+
+	t.Run("Filtro por subárbol", func(t *testing.T) {
+		tests := []struct {
+			category string
+			want     []string
+		}{
+			{"Electrónica", []string{"RT-PHONE-1", "RT-PHONE-2", "RT-LAPTOP-1", "RT-AUDIO-1"}},
+			{"telefonia", []string{"RT-PHONE-1", "RT-PHONE-2"}},
+			{"HOGAR", []string{"RT-HOME-1"}},
+			{"Smartphones", []string{"RT-PHONE-1", "RT-PHONE-2"}},
+		}
+
+		for _, tt := range tests {
+			products, err := repo.GetAll(domain.ProductFilter{Category: tt.category})
+			if err != nil {
+				t.Fatalf("GetAll(%q) error = %v", tt.category, err)
+			}
+			if !sameIDs(products, tt.want...) {
+				t.Errorf("GetAll(%q) = %v, want %v", tt.category, ids(products), tt.want)
+			}
+		}
+	})
+
+	t.Run("Migas de pan", func(t *testing.T) {
+		product, err := repo.GetByID("RT-PHONE-1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		var path []string
+		for _, ref := range product.Breadcrumbs {
+			path = append(path, ref.ID)
+		}
+		if fmt.Sprint(path) != "[electronica telefonia smartphones]" {
+			t.Errorf("breadcrumbs = %v, want [electronica telefonia smartphones]", path)
+		}
+	})
+
+	t.Run("Árbol de categorías", func(t *testing.T) {
+		tree := organized.GetCategoryTree()
+		counts := make(map[string]int)
+		for _, root := range tree {
+			counts[root.ID] = root.ProductCount
+		}
+		if len(tree) != 2 || counts["electronica"] != 4 || counts["hogar"] != 1 {
+			t.Errorf("GetCategoryTree() roots = %v, want electronica: 4 and hogar: 1", counts)
+		}
+	})
+
+	writable, ok := repo.(domain.WritableProductRepository)
+	if !ok {
+		return
+	}
+
+	t.Run("Escrituras en categorías que no son hojas", func(t *testing.T) {
+		for _, category := range []string{"Electrónica", "Tablets"} {
+			product := newProduct("RT-TAXONOMY-1")
+			product.Category = category
+
+			var validationErrs domain.ValidationErrors
+			if _, err := writable.Create(product); !errors.As(err, &validationErrs) {
+				t.Errorf("Create() in %q error = %v (%T), want domain.ValidationErrors", category, err, err)
+			}
+		}
+
+		created, err := writable.Create(newProduct("RT-TAXONOMY-1"))
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if len(created.Breadcrumbs) != 3 {
+			t.Errorf("Create() breadcrumbs = %v, want the taxonomy path", created.Breadcrumbs)
+		}
+	})
+}
+
+func testConcurrentWrites(t *testing.T, repo domain.WritableProductRepository) {
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*concurrency)
+	applied := make(chan bool, concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if _, err := repo.Create(newProduct(fmt.Sprintf("RT-CONCURRENT-%d", i))); err != nil {
+				errs <- fmt.Errorf("Create() error = %v", err)
+			}
+
+			// Todas las goroutines actualizan la misma versión: solo una debe aplicarse
+			product := Products()[0]
+			product.Name = fmt.Sprintf("Samsung Galaxy S24 #%d", i)
+			_, err := repo.Update(product)
+
+			var conflict *domain.ProductVersionConflictError
+			switch {
+			case err == nil:
+				applied <- true
+			case !errors.As(err, &conflict):
+				errs <- fmt.Errorf("Update() error = %v", err)
+			}
+
+			if _, err := repo.GetAll(domain.ProductFilter{}); err != nil {
+				errs <- fmt.Errorf("GetAll() error = %v", err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	close(applied)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if count := len(applied); count != 1 {
+		t.Errorf("expected exactly one update of the same version applied, got %d", count)
+	}
+	if products, _ := repo.GetAll(domain.ProductFilter{}); len(products) != len(Products())+concurrency {
+		t.Errorf("expected %d products, got %d", len(Products())+concurrency, len(products))
+	}
+}
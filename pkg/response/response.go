@@ -2,6 +2,7 @@ package response
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"meli-products-api/domain"
@@ -21,17 +22,28 @@ type ErrorInfo struct {
 	Code    string `json:"code" example:"PRODUCT_NOT_FOUND"`
 	Message string `json:"message" example:"Product with ID 'INVALID_ID' not found"`
 	Details string `json:"details,omitempty" example:"Please check the product ID and try again"`
+
+	// Errores por campo, cuando la solicitud tiene varios campos inválidos
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError representa el error de validación de un campo de la solicitud
+type FieldError struct {
+	Field   string `json:"field" example:"filter.and[1].op"`
+	Message string `json:"message" example:"is required"`
 }
 
 // Meta representa la información de metadatos en la respuesta
 type Meta struct {
-	Timestamp  string `json:"timestamp" example:"2024-01-15T10:30:00Z"`
-	RequestID  string `json:"request_id,omitempty" example:"req-12345-abcde"`
-	Version    string `json:"version" example:"v1"`
-	TotalCount int    `json:"total_count,omitempty" example:"150"`
-	Page       int    `json:"page,omitempty" example:"1"`
-	PageSize   int    `json:"page_size,omitempty" example:"20"`
-	TotalPages int    `json:"total_pages,omitempty" example:"8"`
+	Timestamp  string      `json:"timestamp" example:"2024-01-15T10:30:00Z"`
+	RequestID  string      `json:"request_id,omitempty" example:"req-12345-abcde"`
+	Version    string      `json:"version" example:"v1"`
+	TotalCount int         `json:"total_count,omitempty" example:"150"`
+	Page       int         `json:"page,omitempty" example:"1"`
+	PageSize   int         `json:"page_size,omitempty" example:"20"`
+	TotalPages int         `json:"total_pages,omitempty" example:"8"`
+	NextCursor string      `json:"next_cursor,omitempty" example:"eyJzIjoicHJpY2U6YXNjIiwiaWQiOiJQSE9ORTAwMSJ9"`
+	Facets     interface{} `json:"facets,omitempty" swaggertype:"array,object"`
 }
 
 // JSON envía una respuesta JSON con el código de estado dado
@@ -134,6 +146,19 @@ func Forbidden(w http.ResponseWriter, code, message, details string) {
 	})
 }
 
+// Conflict envía una respuesta 409 Conflict
+func Conflict(w http.ResponseWriter, code, message, details string) {
+	JSON(w, http.StatusConflict, &APIResponse{
+		Success: false,
+		Message: "Conflict",
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
 // ValidationError envía una respuesta 422 Unprocessable Entity para errores de validación
 func ValidationError(w http.ResponseWriter, code, message, details string) {
 	JSON(w, http.StatusUnprocessableEntity, &APIResponse{
@@ -147,15 +172,42 @@ func ValidationError(w http.ResponseWriter, code, message, details string) {
 	})
 }
 
+// FieldValidationErrors envía una respuesta 422 Unprocessable Entity con un error por campo
+func FieldValidationErrors(w http.ResponseWriter, code, message, details string, fields []FieldError) {
+	JSON(w, http.StatusUnprocessableEntity, &APIResponse{
+		Success: false,
+		Message: "Validation Error",
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+			Fields:  fields,
+		},
+	})
+}
+
 // HandleError analiza un error y envía la respuesta HTTP apropiada
 func HandleError(w http.ResponseWriter, err error) {
 	switch e := err.(type) {
 	case *domain.ProductNotFoundError:
 		NotFound(w, "PRODUCT_NOT_FOUND", e.Error(), "Please verify the product ID and try again")
+	case *domain.ProductAlreadyExistsError:
+		Conflict(w, "PRODUCT_ALREADY_EXISTS", e.Error(), "Please use a different product ID or update the existing product")
+	case *domain.ProductVersionConflictError:
+		w.Header().Set("ETag", domain.ProductETag(e.Current))
+		Conflict(w, "VERSION_CONFLICT", e.Error(), fmt.Sprintf("The current version is %d; fetch the product again and retry with If-Match: %s", e.Current, domain.ProductETag(e.Current)))
 	case *domain.InvalidProductIDError:
 		BadRequest(w, "INVALID_PRODUCT_ID", e.Error(), "Product ID must be a valid non-empty string")
 	case *domain.ValidationError:
 		ValidationError(w, "VALIDATION_ERROR", e.Error(), "Please check your input and try again")
+	case domain.ValidationErrors:
+		fields := make([]FieldError, len(e))
+		for i, fieldErr := range e {
+			fields[i] = FieldError{Field: fieldErr.Field, Message: fieldErr.Message}
+		}
+		FieldValidationErrors(w, "VALIDATION_ERROR", "The request has invalid fields", "Please check the fields listed in 'fields' and try again", fields)
+	case *domain.QuerySyntaxError:
+		BadRequest(w, "INVALID_QUERY_SYNTAX", e.Error(), "Please check the search query syntax near the indicated position")
 	default:
 		InternalServerError(w, "INTERNAL_ERROR", "An unexpected error occurred", "Please try again later or contact support if the problem persists")
 	}
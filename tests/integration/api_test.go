@@ -1,28 +1,61 @@
 package integration
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 
+	"meli-products-api/domain"
+	analyticsCommands "meli-products-api/internal/application/commands/analytics"
+	productCommands "meli-products-api/internal/application/commands/product"
+	"meli-products-api/internal/application/controllers/analytics"
+	"meli-products-api/internal/application/controllers/catalog"
 	"meli-products-api/internal/application/controllers/product"
 	"meli-products-api/internal/application/mediator"
+	analyticsQueries "meli-products-api/internal/application/queries/analytics"
+	catalogQueries "meli-products-api/internal/application/queries/catalog"
 	productQueries "meli-products-api/internal/application/queries/product"
 	"meli-products-api/internal/delivery/rest/controllers"
+	"meli-products-api/internal/delivery/rest/middleware"
 	jsonRepo "meli-products-api/internal/repository/json"
+	"meli-products-api/internal/repository/searchlog"
 	"meli-products-api/pkg/response"
 )
 
 // setupTestAPI configura una instancia completa de la API para testing de integración
 func setupTestAPI(t *testing.T) *gin.Engine {
+	return setupTestAPIWithData(t, filepath.Join("..", "fixtures", "test_products.json"))
+}
+
+// setupWritableTestAPI configura la API sobre una copia temporal de los datos de prueba, para
+// que los comandos de escritura no modifiquen el fixture. Devuelve también la ruta de la copia.
+func setupWritableTestAPI(t *testing.T) (*gin.Engine, string) {
+	data, err := os.ReadFile(filepath.Join("..", "fixtures", "test_products.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test products: %v", err)
+	}
+
+	dataPath := filepath.Join(t.TempDir(), "products.json")
+	if err := os.WriteFile(dataPath, data, 0o644); err != nil {
+		t.Fatalf("Failed to copy test products: %v", err)
+	}
+
+	return setupTestAPIWithData(t, dataPath), dataPath
+}
+
+// setupTestAPIWithData configura la API completa sobre el archivo de productos indicado
+func setupTestAPIWithData(t *testing.T, dataPath string) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 
-	// Usar datos de prueba
-	dataPath := filepath.Join("..", "fixtures", "test_products.json")
 	repo, err := jsonRepo.NewProductRepository(dataPath)
 	if err != nil {
 		// Si no existe el archivo de test, crear uno temporal
@@ -33,13 +66,34 @@ func setupTestAPI(t *testing.T) *gin.Engine {
 		}
 	}
 
+	// Validar los productos igual que al iniciar la API
+	if err := repo.SetValidationMode(domain.ValidationStrict); err != nil {
+		t.Fatalf("Failed to validate test products: %v", err)
+	}
+
+	// Registro de búsquedas en un directorio temporal
+	searchLog, err := searchlog.Open(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to open search log: %v", err)
+	}
+	t.Cleanup(func() { searchLog.Close() })
+
+	// Reglas de comparación reales de la API
+	rules, err := jsonRepo.LoadComparisonRules(filepath.Join("..", "..", "data", "comparison_rules.json"))
+	if err != nil {
+		t.Fatalf("Failed to load comparison rules: %v", err)
+	}
+
 	// Configurar mediator con handlers
 	mediatorInstance := mediator.NewMediator()
-	registerHandlers(mediatorInstance, repo)
+	registerHandlers(mediatorInstance, repo, searchLog, rules)
 
-	// Configurar controlador y router
+	// Configurar controladores y router
 	productController := controllers.NewProductController(mediatorInstance)
+	analyticsController := controllers.NewAnalyticsController(mediatorInstance)
+	catalogController := controllers.NewCatalogController(mediatorInstance)
 	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
 
 	// Configurar rutas
 	v1 := router.Group("/api/v1")
@@ -50,24 +104,51 @@ func setupTestAPI(t *testing.T) *gin.Engine {
 		{
 			products.GET("", productController.GetAllProducts)
 			products.GET("/search", productController.SearchProducts)
+			products.POST("/search/clicks", analyticsController.RecordSearchClick)
+			products.GET("/suggest", productController.SuggestProducts)
+			products.POST("/query", productController.QueryProducts)
 			products.GET("/compare", productController.CompareProducts)
+			products.POST("/compare/score", productController.ScoreProducts)
 			products.GET("/:id", productController.GetProduct)
+			products.POST("", productController.CreateProduct)
+			products.PUT("/:id", productController.UpdateProduct)
+			products.PATCH("/:id", productController.PatchProduct)
+			products.DELETE("/:id", productController.DeleteProduct)
 		}
 
 		v1.GET("/categories", productController.GetCategories)
 		v1.GET("/brands", productController.GetBrands)
+
+		admin := v1.Group("/admin/search")
+		{
+			admin.GET("/top-queries", analyticsController.GetTopQueries)
+			admin.GET("/zero-result-queries", analyticsController.GetZeroResultQueries)
+			admin.GET("/clickless-queries", analyticsController.GetClicklessQueries)
+		}
+
+		v1.GET("/admin/catalog/validation", catalogController.GetValidationReport)
 	}
 
 	return router
 }
 
-func registerHandlers(m mediator.Mediator, repo *jsonRepo.ProductRepository) {
+func registerHandlers(m mediator.Mediator, repo *jsonRepo.ProductRepository, searchLog domain.SearchLog, rules *domain.ComparisonRuleSet) {
 	m.Register(&productQueries.GetProductQuery{}, product.NewGetProductHandler(repo))
 	m.Register(&productQueries.GetAllProductsQuery{}, product.NewGetAllProductsHandler(repo))
-	m.Register(&productQueries.CompareProductsQuery{}, product.NewCompareProductsHandler(repo))
-	m.Register(&productQueries.SearchProductsQuery{}, product.NewSearchProductsHandler(repo))
+	m.Register(&productQueries.QueryProductsQuery{}, product.NewQueryProductsHandler(repo))
+	m.Register(&productQueries.CompareProductsQuery{}, product.NewCompareProductsHandler(repo, rules))
+	m.Register(&productQueries.ScoreProductsQuery{}, product.NewScoreProductsHandler(repo, rules))
+	m.Register(&productQueries.SearchProductsQuery{}, analytics.NewSearchLoggingHandler(product.NewSearchProductsHandler(repo), searchLog))
+	m.Register(&productQueries.SuggestProductsQuery{}, product.NewSuggestProductsHandler(repo))
+	m.Register(&productCommands.CreateProductCommand{}, product.NewCreateProductHandler(repo))
+	m.Register(&productCommands.UpdateProductCommand{}, product.NewUpdateProductHandler(repo))
+	m.Register(&productCommands.PatchProductCommand{}, product.NewPatchProductHandler(repo))
+	m.Register(&productCommands.DeleteProductCommand{}, product.NewDeleteProductHandler(repo))
 	m.Register(&productQueries.GetCategoriesQuery{}, product.NewGetCategoriesHandler(repo))
 	m.Register(&productQueries.GetBrandsQuery{}, product.NewGetBrandsHandler(repo))
+	m.Register(&analyticsCommands.RecordSearchClickCommand{}, analytics.NewRecordSearchClickHandler(repo, searchLog))
+	m.Register(&analyticsQueries.GetSearchReportQuery{}, analytics.NewGetSearchReportHandler(searchLog))
+	m.Register(&catalogQueries.GetValidationReportQuery{}, catalog.NewGetValidationReportHandler(repo))
 }
 
 func createTestDataFile(t *testing.T) string {
@@ -220,6 +301,120 @@ func TestIntegration_GetAllProducts(t *testing.T) {
 		}
 	})
 
+	t.Run("Get products with pagination and sorting", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products?page=1&page_size=2&sort=-price", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Get products with pagination failed with status: %d", w.Code)
+		}
+
+		var response response.APIResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if response.Meta == nil || response.Meta.Page != 1 || response.Meta.PageSize != 2 || response.Meta.TotalPages == 0 {
+			t.Errorf("Expected pagination meta, got: %+v", response.Meta)
+		}
+	})
+
+	t.Run("Get products with invalid sort", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products?sort=stock", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for invalid sort, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Get products with specification filters", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products?category=Smartphones&spec.RAM>=8&spec.Pantalla~6.1&min_rating=4", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Get products with specification filters failed with status: %d", w.Code)
+		}
+
+		var body struct {
+			Data []domain.Product `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		// PHONE003 tiene RAM suficiente pero una pantalla de 6.2 pulgadas
+		ids := make([]string, len(body.Data))
+		for i, product := range body.Data {
+			ids[i] = product.ID
+		}
+		if want := []string{"PHONE001", "PHONE002"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("Expected products %v, got %v", want, ids)
+		}
+	})
+
+	t.Run("Get products with invalid specification filter", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products?spec.RAM", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for invalid specification filter, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Get products with facets", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products?page_size=1&facets=brand,category,spec.RAM", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Get products with facets failed with status: %d", w.Code)
+		}
+
+		var body struct {
+			Data []interface{} `json:"data"`
+			Meta struct {
+				TotalCount int            `json:"total_count"`
+				Facets     []domain.Facet `json:"facets"`
+			} `json:"meta"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if len(body.Data) != 1 || len(body.Meta.Facets) != 3 {
+			t.Fatalf("Expected 1 product and 3 facets, got %d products and %+v", len(body.Data), body.Meta.Facets)
+		}
+		// Las facetas cuentan todos los resultados, no solo la página devuelta
+		total := 0
+		for _, value := range body.Meta.Facets[0].Values {
+			total += value.Count
+		}
+		if body.Meta.Facets[0].Name != "brand" || total != body.Meta.TotalCount || total < 2 {
+			t.Errorf("Expected brand counts to add up to %d, got %+v", body.Meta.TotalCount, body.Meta.Facets[0])
+		}
+	})
+
+	t.Run("Get products with invalid facet", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products?facets=color", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for invalid facet, got: %d", w.Code)
+		}
+	})
+
 	t.Run("Get products with category filter", func(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/api/v1/products?category=Smartphones", nil)
 		w := httptest.NewRecorder()
@@ -265,6 +460,203 @@ func TestIntegration_SearchProducts(t *testing.T) {
 			t.Errorf("Expected 400 for empty search query, got: %d", w.Code)
 		}
 	})
+
+	t.Run("Search with typos returns suggestions", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q=samsumg%20galxy", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Search products failed with status: %d", w.Code)
+		}
+
+		var body struct {
+			Data struct {
+				Products []struct {
+					ID    string  `json:"id"`
+					Score float64 `json:"score"`
+				} `json:"products"`
+				Suggestions []string `json:"suggestions"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if len(body.Data.Products) == 0 || body.Data.Products[0].ID != "PHONE001" {
+			t.Errorf("Expected fuzzy match on PHONE001, got %+v", body.Data.Products)
+		}
+		if len(body.Data.Suggestions) == 0 || body.Data.Suggestions[0] != "samsung galaxy" {
+			t.Errorf("Expected suggestion 'samsung galaxy', got %v", body.Data.Suggestions)
+		}
+	})
+
+	t.Run("Search with structured query", func(t *testing.T) {
+		q := url.QueryEscape(`category:smartphones price<1000 ram>=8 -brand:Google`)
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q="+q, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Search products failed with status: %d", w.Code)
+		}
+
+		var body struct {
+			Data struct {
+				Products []struct {
+					ID string `json:"id"`
+				} `json:"products"`
+				Count int `json:"count"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if body.Data.Count != 1 || body.Data.Products[0].ID != "PHONE001" {
+			t.Errorf("Expected only PHONE001, got %+v", body.Data.Products)
+		}
+	})
+
+	t.Run("Search with highlight and explain", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q=samsung&highlight=true&explain=true", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Search products failed with status: %d", w.Code)
+		}
+
+		var body struct {
+			Data struct {
+				Products []domain.SearchHit `json:"products"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if len(body.Data.Products) == 0 {
+			t.Fatal("Expected at least one product")
+		}
+		for _, hit := range body.Data.Products {
+			if !strings.Contains(hit.Highlights["name"]+hit.Highlights["description"], "<em>Samsung</em>") {
+				t.Errorf("Expected highlighted Samsung for %s, got %v", hit.ID, hit.Highlights)
+			}
+			if hit.Explanation == nil || hit.Explanation.Score != hit.Score || len(hit.Explanation.Matches) == 0 {
+				t.Errorf("Expected explanation matching score %v for %s, got %+v", hit.Score, hit.ID, hit.Explanation)
+			}
+		}
+	})
+
+	t.Run("Search with invalid highlight flag", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q=samsung&highlight=maybe", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for invalid highlight flag, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Search with syntax error", func(t *testing.T) {
+		q := url.QueryEscape(`brand:Apple "Pro`)
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q="+q, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for syntax error, got: %d", w.Code)
+		}
+
+		var response response.APIResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if response.Error == nil || response.Error.Code != "INVALID_QUERY_SYNTAX" || !strings.Contains(response.Error.Details, "position 13") {
+			t.Errorf("Expected INVALID_QUERY_SYNTAX at position 13, got %+v", response.Error)
+		}
+	})
+
+	t.Run("Search with unknown field", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q="+url.QueryEscape("galaxy brnad:Samsung"), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for an unknown field, got: %d", w.Code)
+		}
+
+		var response response.APIResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if response.Error == nil || response.Error.Code != "INVALID_QUERY_SYNTAX" || !strings.Contains(response.Error.Message, "unknown field 'brnad'") {
+			t.Errorf("Expected INVALID_QUERY_SYNTAX for brnad, got %+v", response.Error)
+		}
+	})
+}
+
+func TestIntegration_SuggestProducts(t *testing.T) {
+	router := setupTestAPI(t)
+
+	t.Run("Suggest with single character prefix", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/suggest?q=m&limit=3", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Suggest failed with status: %d", w.Code)
+		}
+
+		var body struct {
+			Data struct {
+				Suggestions []struct {
+					Text      string `json:"text"`
+					Type      string `json:"type"`
+					ProductID string `json:"product_id"`
+				} `json:"suggestions"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if len(body.Data.Suggestions) != 1 || body.Data.Suggestions[0].ProductID != "LAPTOP001" || body.Data.Suggestions[0].Type != "product" {
+			t.Errorf("Expected MacBook suggestion, got %+v", body.Data.Suggestions)
+		}
+	})
+
+	t.Run("Suggest without prefix", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/suggest", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for missing prefix, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Suggest with invalid limit", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/suggest?q=gal&limit=50", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for invalid limit, got: %d", w.Code)
+		}
+	})
 }
 
 func TestIntegration_CompareProducts(t *testing.T) {
@@ -288,6 +680,30 @@ func TestIntegration_CompareProducts(t *testing.T) {
 		if !response.Success {
 			t.Error("Compare should return success = true")
 		}
+
+		winners := comparisonWinners(t, w.Body.Bytes())
+		if got := winners["price"]; len(got) != 1 || got[0] != "PHONE001" {
+			t.Errorf("Expected PHONE001 to win on price, got %v", got)
+		}
+		if got := winners["Batería"]; len(got) != 1 || got[0] != "PHONE001" {
+			t.Errorf("Expected PHONE001 to win on Batería, got %v", got)
+		}
+	})
+
+	t.Run("Compare products of different categories", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/compare?ids=PHONE001,LAPTOP001", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Compare products failed with status: %d", w.Code)
+		}
+
+		winners := comparisonWinners(t, w.Body.Bytes())
+		if got := winners["RAM"]; len(got) != 1 || got[0] != "LAPTOP001" {
+			t.Errorf("Expected LAPTOP001 to win on RAM, got %v", got)
+		}
 	})
 
 	t.Run("Compare with insufficient products", func(t *testing.T) {
@@ -302,6 +718,298 @@ func TestIntegration_CompareProducts(t *testing.T) {
 	})
 }
 
+// comparisonWinners devuelve los ganadores de cada fila de la matriz de una comparación
+func comparisonWinners(t *testing.T, raw []byte) map[string][]string {
+	t.Helper()
+
+	var body struct {
+		Data struct {
+			Matrix struct {
+				Fields []domain.SpecComparisonRow `json:"fields"`
+				Rows   []domain.SpecComparisonRow `json:"rows"`
+			} `json:"matrix"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	winners := make(map[string][]string)
+	for _, row := range append(body.Data.Matrix.Fields, body.Data.Matrix.Rows...) {
+		if row.RuleConflict != "" {
+			t.Errorf("Unexpected rule conflict on %s: %s", row.Name, row.RuleConflict)
+		}
+		winners[row.Name] = row.Winners
+	}
+
+	return winners
+}
+
+func TestIntegration_ScoreProducts(t *testing.T) {
+	router := setupTestAPI(t)
+
+	t.Run("Score existing products", func(t *testing.T) {
+		body := []byte(`{"product_ids": ["PHONE001", "PHONE002"], "weights": {"price": 2, "rating": 1}}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/compare/score", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Score products failed with status: %d", w.Code)
+		}
+	})
+
+	t.Run("Score with unknown criterion", func(t *testing.T) {
+		body := []byte(`{"product_ids": ["PHONE001", "PHONE002"], "weights": {"unknown": 1}}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/compare/score", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Expected 422 for unknown criterion, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Score without weights", func(t *testing.T) {
+		body := []byte(`{"product_ids": ["PHONE001", "PHONE002"]}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/compare/score", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 without weights, got: %d", w.Code)
+		}
+	})
+}
+
+func TestIntegration_QueryProducts(t *testing.T) {
+	router := setupTestAPI(t)
+
+	t.Run("Query with filter tree and projection", func(t *testing.T) {
+		body := []byte(`{
+			"filter": {"and": [
+				{"or": [{"field": "brand", "op": "=", "value": "Apple"}, {"field": "brand", "op": "=", "value": "Samsung"}]},
+				{"spec": "RAM", "op": ">=", "value": 8},
+				{"not": {"field": "category", "op": "=", "value": "Laptops"}}
+			]},
+			"sort": [{"field": "price", "descending": true}],
+			"fields": ["id", "price"],
+			"page_size": 2
+		}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/query", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Query products failed with status: %d", w.Code)
+		}
+
+		var result struct {
+			Data []map[string]interface{} `json:"data"`
+			Meta response.Meta            `json:"meta"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if len(result.Data) != 2 || result.Data[0]["id"] != "PHONE002" || len(result.Data[0]) != 2 {
+			t.Errorf("Expected PHONE002 first with only id and price, got %v", result.Data)
+		}
+		if result.Meta.TotalCount != 3 || result.Meta.NextCursor == "" {
+			t.Errorf("Expected 3 matches with a next cursor, got %+v", result.Meta)
+		}
+	})
+
+	t.Run("Query with invalid fields", func(t *testing.T) {
+		body := []byte(`{
+			"filter": {"and": [{"field": "price", "op": ">", "value": "cheap"}, {"spec": "RAM", "value": 8}]},
+			"sort": [{"field": "popularity"}],
+			"fields": ["id", "color"]
+		}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/query", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("Expected 422 for invalid document, got: %d", w.Code)
+		}
+
+		var response response.APIResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		var fields []string
+		for _, fieldErr := range response.Error.Fields {
+			fields = append(fields, fieldErr.Field)
+		}
+		want := []string{"filter.and[0].value", "filter.and[1].op", "sort[0].field", "fields[1]"}
+		if strings.Join(fields, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected field errors %v, got %v", want, fields)
+		}
+	})
+
+	t.Run("Query with unknown property", func(t *testing.T) {
+		body := []byte(`{"filters": {}}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/query", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for unknown property, got: %d", w.Code)
+		}
+	})
+}
+
+func TestIntegration_SearchAnalytics(t *testing.T) {
+	router := setupTestAPI(t)
+
+	search := func(q string) string {
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q="+url.QueryEscape(q), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Search %q failed with status: %d", q, w.Code)
+		}
+
+		var body struct {
+			Data struct {
+				SearchID string `json:"search_id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if body.Data.SearchID == "" {
+			t.Fatalf("Search %q returned no search_id", q)
+		}
+		return body.Data.SearchID
+	}
+
+	report := func(path string) domain.SearchReport {
+		req, _ := http.NewRequest("GET", "/api/v1/admin/search/"+path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Report %s failed with status: %d", path, w.Code)
+		}
+
+		var body struct {
+			Data domain.SearchReport `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		return body.Data
+	}
+
+	queries := func(r domain.SearchReport) []string {
+		result := make([]string, len(r.Queries))
+		for i, stat := range r.Queries {
+			result[i] = stat.Query
+		}
+		return result
+	}
+
+	clickedID := search("Samsung")
+	search("samsung")
+	search("Pixel")
+	search("zzzz")
+
+	t.Run("Record click", func(t *testing.T) {
+		body := []byte(`{"search_id": "` + clickedID + `", "product_id": "PHONE001"}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/search/clicks", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected 201 for click, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Record click for unknown product", func(t *testing.T) {
+		body := []byte(`{"search_id": "` + clickedID + `", "product_id": "NOPE"}`)
+		req, _ := http.NewRequest("POST", "/api/v1/products/search/clicks", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected 404 for unknown product, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Record click without search ID", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "/api/v1/products/search/clicks", bytes.NewReader([]byte(`{"product_id": "PHONE001"}`)))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Expected 422 without search ID, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Record click with a client request ID", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/products/search?q=Pixel", nil)
+		req.Header.Set("X-Request-ID", "req-spoofed")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		body := []byte(`{"search_id": "req-spoofed", "product_id": "PHONE003"}`)
+		req, _ = http.NewRequest("POST", "/api/v1/products/search/clicks", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Errorf("Expected 201 for click, got: %d", w.Code)
+		}
+	})
+
+	t.Run("Top queries", func(t *testing.T) {
+		r := report("top-queries?window=1h")
+		if r.TotalSearches != 5 || len(r.Queries) != 3 || r.Queries[0].Query != "pixel" || r.Queries[0].ClickedSearches != 0 || r.Queries[1].Query != "samsung" || r.Queries[1].Searches != 2 || r.Queries[1].ClickedSearches != 1 {
+			t.Errorf("Unexpected top queries report %+v", r)
+		}
+	})
+
+	t.Run("Zero-result queries", func(t *testing.T) {
+		if got := queries(report("zero-result-queries")); !reflect.DeepEqual(got, []string{"zzzz"}) {
+			t.Errorf("Expected [zzzz], got %v", got)
+		}
+	})
+
+	t.Run("Click-less queries", func(t *testing.T) {
+		if got := queries(report("clickless-queries")); !reflect.DeepEqual(got, []string{"pixel"}) {
+			t.Errorf("Expected [pixel], got %v", got)
+		}
+	})
+
+	t.Run("Invalid window", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/admin/search/top-queries?window=forever", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for invalid window, got: %d", w.Code)
+		}
+	})
+}
+
 func TestIntegration_GetCategories(t *testing.T) {
 	router := setupTestAPI(t)
 
@@ -322,6 +1030,348 @@ func TestIntegration_GetCategories(t *testing.T) {
 	if !response.Success {
 		t.Error("Get categories should return success = true")
 	}
+
+	// Sin taxonomía, cada categoría de los productos es una raíz sin subcategorías
+	var tree []domain.CategoryTreeNode
+	data, _ := json.Marshal(response.Data)
+	if err := json.Unmarshal(data, &tree); err != nil {
+		t.Fatalf("Failed to unmarshal categories: %v", err)
+	}
+
+	if len(tree) == 0 {
+		t.Fatal("Expected at least one category")
+	}
+	for _, node := range tree {
+		if node.ID != domain.CategorySlug(node.Name) || node.ProductCount == 0 || len(node.Children) != 0 {
+			t.Errorf("Unexpected category node %+v", node)
+		}
+	}
+}
+
+func TestIntegration_CatalogValidationReport(t *testing.T) {
+	router := setupTestAPI(t)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/catalog/validation", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Get validation report failed with status: %d", w.Code)
+	}
+
+	var response response.APIResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	var report domain.CatalogValidationReport
+	data, _ := json.Marshal(response.Data)
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to unmarshal report: %v", err)
+	}
+
+	if report.Mode != domain.ValidationStrict || report.TotalProducts == 0 || report.LoadedProducts != report.TotalProducts || len(report.Rejected) != 0 {
+		t.Errorf("Unexpected validation report %+v", report)
+	}
+}
+
+func TestIntegration_ProductWriteCommands(t *testing.T) {
+	router, dataPath := setupWritableTestAPI(t)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decodeProduct := func(w *httptest.ResponseRecorder) domain.Product {
+		var response response.APIResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		var product domain.Product
+		data, _ := json.Marshal(response.Data)
+		if err := json.Unmarshal(data, &product); err != nil {
+			t.Fatalf("Failed to unmarshal product: %v", err)
+		}
+		return product
+	}
+
+	stored := func() map[string]domain.Product {
+		data, err := os.ReadFile(dataPath)
+		if err != nil {
+			t.Fatalf("Failed to read data file: %v", err)
+		}
+
+		var products []domain.Product
+		if err := json.Unmarshal(data, &products); err != nil {
+			t.Fatalf("Data file is not valid JSON: %v", err)
+		}
+
+		byID := make(map[string]domain.Product, len(products))
+		for _, product := range products {
+			byID[product.ID] = product
+		}
+		return byID
+	}
+
+	newProduct := `{
+		"id": "PHONE100",
+		"name": "Pixel 9",
+		"image_url": "https://example.com/pixel-9.jpg",
+		"description": "Google Pixel 9",
+		"price": 799.99,
+		"rating": 4.4,
+		"category": "Smartphones",
+		"brand": "Google",
+		"available": true,
+		"specifications": [{"name": "RAM", "value": "12", "unit": "GB"}]
+	}`
+
+	t.Run("Crear producto", func(t *testing.T) {
+		w := send("POST", "/api/v1/products", newProduct)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Create failed with status %d: %s", w.Code, w.Body.String())
+		}
+
+		created := decodeProduct(w)
+		if created.ID != "PHONE100" || created.Specifications[0].Value != "12" || created.Specifications[0].Unit != "GB" {
+			t.Errorf("Unexpected created product %+v", created)
+		}
+		if strings.Contains(w.Body.String(), `"typed"`) {
+			t.Errorf("Typed specification values should not be exposed: %s", w.Body.String())
+		}
+
+		if _, ok := stored()["PHONE100"]; !ok {
+			t.Error("Created product was not persisted")
+		}
+
+		if w := send("GET", "/api/v1/products/PHONE100", ""); w.Code != http.StatusOK {
+			t.Errorf("Created product is not readable, status %d", w.Code)
+		}
+
+		// La especificación se interpreta como número al crearla: 12GB cumple RAM>=8GB
+		w = send("GET", "/api/v1/products?"+url.QueryEscape("spec.RAM>=8GB")+"&brand=Google", "")
+		if !strings.Contains(w.Body.String(), `"PHONE100"`) {
+			t.Errorf("Expected the created product in the spec filter, got %d: %s", w.Code, w.Body.String())
+		}
+		w = send("GET", "/api/v1/products?"+url.QueryEscape("spec.RAM>=16GB")+"&brand=Google", "")
+		if w.Code != http.StatusOK || strings.Contains(w.Body.String(), `"PHONE100"`) {
+			t.Errorf("Expected the created product outside the spec filter, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("ID duplicado", func(t *testing.T) {
+		if w := send("POST", "/api/v1/products", newProduct); w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("Campos inválidos", func(t *testing.T) {
+		w := send("POST", "/api/v1/products", `{"id": "PHONE101", "name": "Sin precio", "image_url": "not-a-url"}`)
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("Expected status 422, got %d", w.Code)
+		}
+
+		var response response.APIResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		fields := make(map[string]bool)
+		for _, field := range response.Error.Fields {
+			fields[field.Field] = true
+		}
+		for _, field := range []string{"image_url", "description", "price"} {
+			if !fields[field] {
+				t.Errorf("Expected error for field %s, got %+v", field, response.Error.Fields)
+			}
+		}
+
+		if _, ok := stored()["PHONE101"]; ok {
+			t.Error("Invalid product was persisted")
+		}
+	})
+
+	t.Run("Campo desconocido", func(t *testing.T) {
+		if w := send("POST", "/api/v1/products", `{"id": "PHONE102", "color": "red"}`); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("Reemplazar producto", func(t *testing.T) {
+		body := strings.Replace(strings.Replace(newProduct, `"id": "PHONE100",`, "", 1), `"price": 799.99`, `"price": 749.99`, 1)
+		w := send("PUT", "/api/v1/products/PHONE100", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Update failed with status %d: %s", w.Code, w.Body.String())
+		}
+
+		if updated := decodeProduct(w); updated.Price != 749.99 || updated.Name != "Pixel 9" {
+			t.Errorf("Unexpected updated product %+v", updated)
+		}
+		if stored()["PHONE100"].Price != 749.99 {
+			t.Error("Updated price was not persisted")
+		}
+	})
+
+	t.Run("Modificar campos", func(t *testing.T) {
+		w := send("PATCH", "/api/v1/products/PHONE100", `{"version": 2, "available": false}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Patch failed with status %d: %s", w.Code, w.Body.String())
+		}
+
+		patched := decodeProduct(w)
+		if patched.Available || patched.Price != 749.99 || len(patched.Specifications) != 1 {
+			t.Errorf("Patch should only change available, got %+v", patched)
+		}
+	})
+
+	t.Run("Producto inexistente", func(t *testing.T) {
+		if w := send("PATCH", "/api/v1/products/NOPE", `{"version": 1, "available": false}`); w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 on patch, got %d", w.Code)
+		}
+		if w := send("DELETE", "/api/v1/products/NOPE", ""); w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 on delete, got %d", w.Code)
+		}
+	})
+
+	t.Run("Eliminar producto", func(t *testing.T) {
+		w := send("DELETE", "/api/v1/products/PHONE100", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Delete failed with status %d", w.Code)
+		}
+
+		if deleted := decodeProduct(w); deleted.ID != "PHONE100" {
+			t.Errorf("Expected deleted product PHONE100, got %s", deleted.ID)
+		}
+		if _, ok := stored()["PHONE100"]; ok {
+			t.Error("Deleted product is still in the data file")
+		}
+		if w := send("GET", "/api/v1/products/PHONE100", ""); w.Code != http.StatusNotFound {
+			t.Errorf("Deleted product is still readable, status %d", w.Code)
+		}
+	})
+}
+
+func TestIntegration_ProductVersions(t *testing.T) {
+	router, _ := setupWritableTestAPI(t)
+
+	send := func(method, path, ifMatch, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("GET", "/api/v1/products/PHONE001", "", "")
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag != `"1"` {
+		t.Fatalf("Expected ETag \"1\" on a product loaded without version, got status %d and ETag %q", w.Code, etag)
+	}
+
+	t.Run("PATCH sin versión", func(t *testing.T) {
+		if w := send("PATCH", "/api/v1/products/PHONE001", "", `{"price": 849.99}`); w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Expected status 422, got %d", w.Code)
+		}
+	})
+
+	t.Run("If-Match inválido", func(t *testing.T) {
+		for _, ifMatch := range []string{"1", `W/"1"`, `"abc"`} {
+			if w := send("PATCH", "/api/v1/products/PHONE001", ifMatch, `{"price": 849.99}`); w.Code != http.StatusBadRequest {
+				t.Errorf("If-Match %s: expected status 400, got %d", ifMatch, w.Code)
+			}
+		}
+		if w := send("PATCH", "/api/v1/products/PHONE001", `"1"`, `{"version": 2, "price": 849.99}`); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 when If-Match and version differ, got %d", w.Code)
+		}
+	})
+
+	t.Run("Dos editores sobre la misma versión", func(t *testing.T) {
+		first := send("PATCH", "/api/v1/products/PHONE001", etag, `{"price": 849.99}`)
+		if first.Code != http.StatusOK || first.Header().Get("ETag") != `"2"` {
+			t.Fatalf("First patch: expected status 200 and ETag \"2\", got %d and %q", first.Code, first.Header().Get("ETag"))
+		}
+
+		second := send("PATCH", "/api/v1/products/PHONE001", etag, `{"available": false}`)
+		if second.Code != http.StatusConflict {
+			t.Fatalf("Second patch: expected status 409, got %d", second.Code)
+		}
+		if second.Header().Get("ETag") != `"2"` {
+			t.Errorf("Expected the current ETag on conflict, got %q", second.Header().Get("ETag"))
+		}
+
+		var response response.APIResponse
+		json.Unmarshal(second.Body.Bytes(), &response)
+		if response.Error == nil || response.Error.Code != "VERSION_CONFLICT" || !strings.Contains(response.Error.Details, "current version is 2") {
+			t.Errorf("Unexpected conflict error %+v", response.Error)
+		}
+
+		// El segundo editor reintenta sobre la versión actual
+		if w := send("PATCH", "/api/v1/products/PHONE001", "", `{"version": 2, "available": false}`); w.Code != http.StatusOK || w.Header().Get("ETag") != `"3"` {
+			t.Errorf("Retry: expected status 200 and ETag \"3\", got %d and %q", w.Code, w.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("PUT con versión vieja", func(t *testing.T) {
+		if w := send("PUT", "/api/v1/products/PHONE001", etag, `{"name": "Galaxy"}`); w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", w.Code)
+		}
+	})
+
+	product := `{
+		"name": "Pixel 9",
+		"image_url": "https://example.com/pixel-9.jpg",
+		"description": "Google Pixel 9",
+		"price": 799.99,
+		"rating": 4.4,
+		"category": "Smartphones",
+		"brand": "Google",
+		"available": true,
+		"specifications": [{"name": "RAM", "value": "12", "unit": "GB"}]
+	}`
+
+	t.Run("If-Match * en PUT acepta cualquier versión", func(t *testing.T) {
+		if w := send("PUT", "/api/v1/products/PHONE001", "*", product); w.Code != http.StatusOK || w.Header().Get("ETag") != `"4"` {
+			t.Errorf("Expected status 200 and ETag \"4\", got %d and %q: %s", w.Code, w.Header().Get("ETag"), w.Body.String())
+		}
+		if w := send("PUT", "/api/v1/products/NOPE", "*", product); w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a missing product, got %d", w.Code)
+		}
+	})
+
+	t.Run("If-Match * en PATCH", func(t *testing.T) {
+		w := send("PATCH", "/api/v1/products/PHONE001", "*", `{"price": 849.99}`)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+
+		var response response.APIResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if response.Error == nil || response.Error.Code != "INVALID_IF_MATCH" || !strings.Contains(response.Error.Details, "send the ETag of the version") {
+			t.Errorf("Unexpected error %+v", response.Error)
+		}
+	})
+
+	t.Run("Producto creado de nuevo después de eliminarlo", func(t *testing.T) {
+		if w := send("DELETE", "/api/v1/products/PHONE001", "", ""); w.Code != http.StatusOK {
+			t.Fatalf("Delete failed with status %d", w.Code)
+		}
+
+		w := send("POST", "/api/v1/products", "", strings.Replace(product, "{", `{"id": "PHONE001",`, 1))
+		if w.Code != http.StatusCreated || w.Header().Get("ETag") != `"5"` {
+			t.Fatalf("Expected status 201 and ETag \"5\", got %d and %q: %s", w.Code, w.Header().Get("ETag"), w.Body.String())
+		}
+
+		// Una ETag del producto eliminado no coincide con el nuevo
+		if w := send("PATCH", "/api/v1/products/PHONE001", etag, `{"price": 1}`); w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 with an ETag of the deleted product, got %d", w.Code)
+		}
+	})
 }
 
 func TestIntegration_GetBrands(t *testing.T) {
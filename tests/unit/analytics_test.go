@@ -0,0 +1,325 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/application/controllers/analytics"
+	"meli-products-api/internal/application/mediator"
+	"meli-products-api/internal/application/queries/product"
+	"meli-products-api/internal/repository/searchlog"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "  Audífonos   SONY ", want: "audifonos sony"},
+		{input: "samsung galaxy", want: "samsung galaxy"},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := domain.NormalizeQuery(tt.input); got != tt.want {
+			t.Errorf("NormalizeQuery(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseReportWindow(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "24h", want: 24 * time.Hour},
+		{input: "90m", want: 90 * time.Minute},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "0d", wantErr: true},
+		{input: "-1h", wantErr: true},
+		{input: "91d", wantErr: true},
+		{input: "semana", wantErr: true},
+		{input: "1.5d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := domain.ParseReportWindow(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseReportWindow(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseReportWindow(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func analyticsTestEvents(now time.Time) ([]domain.SearchEvent, []domain.SearchClick) {
+	events := []domain.SearchEvent{
+		{SearchID: "r1", Query: "Samsung Galaxy", ResultCount: 3, LatencyMs: 1, Timestamp: now},
+		{SearchID: "r2", Query: "samsung  galaxy", ResultCount: 3, LatencyMs: 3, Timestamp: now.Add(time.Minute)},
+		{SearchID: "r3", Query: "iphone 20", ResultCount: 0, Timestamp: now},
+		{SearchID: "r4", Query: "iPhone 20", ResultCount: 0, Timestamp: now},
+		{SearchID: "r5", Query: "iphone 20", ResultCount: 0, Timestamp: now},
+		{SearchID: "r6", Query: "monitor", ResultCount: 2, Timestamp: now},
+		{SearchID: "r7", Query: "televisor", ResultCount: 0, Timestamp: now},
+		{SearchID: "r8", Query: "televisor", ResultCount: 1, Timestamp: now},
+	}
+	clicks := []domain.SearchClick{
+		{SearchID: "r1", ProductID: "PHONE001", Timestamp: now},
+		{SearchID: "r1", ProductID: "PHONE002", Timestamp: now},
+	}
+
+	return events, clicks
+}
+
+func TestBuildSearchReport(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	events, clicks := analyticsTestEvents(now)
+
+	queries := func(report *domain.SearchReport) []string {
+		result := make([]string, len(report.Queries))
+		for i, stat := range report.Queries {
+			result[i] = stat.Query
+		}
+		return result
+	}
+
+	t.Run("Consultas más buscadas", func(t *testing.T) {
+		report, err := domain.BuildSearchReport(domain.ReportTopQueries, now, now, events, clicks, 10)
+		if err != nil {
+			t.Fatalf("BuildSearchReport() error = %v", err)
+		}
+
+		want := []string{"iphone 20", "samsung galaxy", "televisor", "monitor"}
+		if got := queries(report); !reflect.DeepEqual(got, want) {
+			t.Fatalf("queries = %v, want %v", got, want)
+		}
+		if report.TotalSearches != len(events) {
+			t.Errorf("TotalSearches = %d, want %d", report.TotalSearches, len(events))
+		}
+
+		galaxy := report.Queries[1]
+		if galaxy.Searches != 2 || galaxy.ClickedSearches != 1 || galaxy.ClickThroughRate != 0.5 || galaxy.AvgResults != 3 || galaxy.AvgLatencyMs != 2 {
+			t.Errorf("unexpected stats %+v", galaxy)
+		}
+		if !galaxy.LastSearchedAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("LastSearchedAt = %v, want %v", galaxy.LastSearchedAt, now.Add(time.Minute))
+		}
+	})
+
+	t.Run("Consultas sin resultados", func(t *testing.T) {
+		report, _ := domain.BuildSearchReport(domain.ReportZeroResultQueries, now, now, events, clicks, 10)
+		if got := queries(report); !reflect.DeepEqual(got, []string{"iphone 20", "televisor"}) {
+			t.Errorf("queries = %v, want [iphone 20 televisor]", got)
+		}
+	})
+
+	t.Run("Consultas sin clics", func(t *testing.T) {
+		report, _ := domain.BuildSearchReport(domain.ReportClicklessQueries, now, now, events, clicks, 10)
+		if got := queries(report); !reflect.DeepEqual(got, []string{"televisor", "monitor"}) {
+			t.Errorf("queries = %v, want [televisor monitor]", got)
+		}
+	})
+
+	t.Run("Límite", func(t *testing.T) {
+		report, _ := domain.BuildSearchReport(domain.ReportTopQueries, now, now, events, clicks, 1)
+		if got := queries(report); !reflect.DeepEqual(got, []string{"iphone 20"}) {
+			t.Errorf("queries = %v, want [iphone 20]", got)
+		}
+	})
+
+	t.Run("Reporte desconocido", func(t *testing.T) {
+		if _, err := domain.BuildSearchReport("slowest", now, now, events, clicks, 10); err == nil {
+			t.Error("expected error for unknown report")
+		}
+	})
+}
+
+func TestSearchFileLog(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Eventos dentro del período", func(t *testing.T) {
+		l, err := searchlog.Open(t.TempDir(), 0, 0)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer l.Close()
+
+		for i := 0; i < 3; i++ {
+			event := domain.SearchEvent{SearchID: "r", Query: "galaxy", Timestamp: now.Add(time.Duration(i) * time.Hour)}
+			if err := l.RecordSearch(event); err != nil {
+				t.Fatalf("RecordSearch() error = %v", err)
+			}
+		}
+		if err := l.RecordClick(domain.SearchClick{SearchID: "r", ProductID: "PHONE001", Timestamp: now}); err != nil {
+			t.Fatalf("RecordClick() error = %v", err)
+		}
+
+		events, clicks, err := l.Events(now, now.Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("Events() error = %v", err)
+		}
+		if len(events) != 2 || len(clicks) != 1 {
+			t.Errorf("Events() = %d searches and %d clicks, want 2 and 1", len(events), len(clicks))
+		}
+	})
+
+	t.Run("Rotación por tamaño", func(t *testing.T) {
+		dir := t.TempDir()
+		l, err := searchlog.Open(dir, 200, 2)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer l.Close()
+
+		for i := 0; i < 20; i++ {
+			if err := l.RecordSearch(domain.SearchEvent{Query: "galaxy", Timestamp: now.Add(time.Duration(i) * time.Second)}); err != nil {
+				t.Fatalf("RecordSearch() error = %v", err)
+			}
+		}
+
+		files, _ := filepath.Glob(filepath.Join(dir, "searches.log*"))
+		if len(files) != 3 {
+			t.Errorf("log files = %v, want the active file and 2 backups", files)
+		}
+		for _, file := range files {
+			if info, _ := os.Stat(file); info.Size() > 200 {
+				t.Errorf("%s has %d bytes, want at most 200", file, info.Size())
+			}
+		}
+
+		// Solo se conservan los eventos más recientes, en orden
+		events, _, err := l.Events(now, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Events() error = %v", err)
+		}
+		if len(events) == 0 || len(events) >= 20 || !events[len(events)-1].Timestamp.Equal(now.Add(19*time.Second)) {
+			t.Fatalf("Events() returned %d events, want the most recent ones", len(events))
+		}
+		for i := 1; i < len(events); i++ {
+			if events[i].Timestamp.Before(events[i-1].Timestamp) {
+				t.Errorf("events out of order at %d", i)
+			}
+		}
+	})
+
+	t.Run("Lectura concurrente con escrituras y rotaciones", func(t *testing.T) {
+		l, err := searchlog.Open(t.TempDir(), 200, 2)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer l.Close()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 200; i++ {
+				l.RecordSearch(domain.SearchEvent{Query: "galaxy", Timestamp: now.Add(time.Duration(i) * time.Second)})
+			}
+		}()
+
+		for reading := true; reading; {
+			select {
+			case <-done:
+				reading = false
+			default:
+			}
+
+			events, _, err := l.Events(now, now.Add(time.Hour))
+			if err != nil {
+				t.Fatalf("Events() error = %v", err)
+			}
+			for i := 1; i < len(events); i++ {
+				if events[i].Timestamp.Before(events[i-1].Timestamp) {
+					t.Fatalf("events out of order at %d", i)
+				}
+			}
+		}
+	})
+
+	t.Run("Tolera líneas incompletas y sobrevive a reinicios", func(t *testing.T) {
+		dir := t.TempDir()
+		l, _ := searchlog.Open(dir, 0, 0)
+		l.RecordSearch(domain.SearchEvent{Query: "antes", Timestamp: now})
+		l.Close()
+
+		// Simular una escritura interrumpida
+		file, _ := os.OpenFile(filepath.Join(dir, "searches.log"), os.O_APPEND|os.O_WRONLY, 0o644)
+		file.WriteString(`{"search":{"query":"cort`)
+		file.Close()
+
+		l, err := searchlog.Open(dir, 0, 0)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer l.Close()
+		l.RecordSearch(domain.SearchEvent{Query: "después", Timestamp: now})
+
+		events, _, err := l.Events(now, now.Add(time.Second))
+		if err != nil {
+			t.Fatalf("Events() error = %v", err)
+		}
+		if len(events) != 2 || events[0].Query != "antes" || events[1].Query != "después" {
+			t.Errorf("Events() = %+v, want [antes después]", events)
+		}
+	})
+}
+
+// memorySearchLog es un registro de búsquedas en memoria para tests
+type memorySearchLog struct {
+	events []domain.SearchEvent
+	clicks []domain.SearchClick
+}
+
+func (l *memorySearchLog) RecordSearch(event domain.SearchEvent) error {
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *memorySearchLog) RecordClick(click domain.SearchClick) error {
+	l.clicks = append(l.clicks, click)
+	return nil
+}
+
+func (l *memorySearchLog) Events(since, until time.Time) ([]domain.SearchEvent, []domain.SearchClick, error) {
+	return l.events, l.clicks, nil
+}
+
+func TestSearchLoggingHandler(t *testing.T) {
+	searchLog := &memorySearchLog{}
+	next := mediator.HandlerFunc(func(ctx context.Context, request interface{}) (interface{}, error) {
+		return &product.SearchProductsResult{Count: 4}, nil
+	})
+	handler := analytics.NewSearchLoggingHandler(next, searchLog)
+
+	query := &product.SearchProductsQuery{Query: "Audífonos  Sony"}
+	result, err := handler.Handle(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	again, err := handler.Handle(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if len(searchLog.events) != 2 {
+		t.Fatalf("recorded %d searches, want 2", len(searchLog.events))
+	}
+	event := searchLog.events[0]
+	if searchID := result.(*product.SearchProductsResult).SearchID; event.SearchID == "" || searchID != event.SearchID {
+		t.Errorf("result search ID = %q, want the recorded %q", searchID, event.SearchID)
+	}
+	if again.(*product.SearchProductsResult).SearchID == event.SearchID {
+		t.Errorf("two searches share the search ID %q", event.SearchID)
+	}
+	if event.Query != "Audífonos  Sony" || event.NormalizedQuery != "audifonos sony" || event.ResultCount != 4 || event.Timestamp.IsZero() {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
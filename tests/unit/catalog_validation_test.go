@@ -0,0 +1,161 @@
+package unit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"meli-products-api/domain"
+	jsonRepo "meli-products-api/internal/repository/json"
+)
+
+const catalogValidationTestProducts = `[
+	{"id": "OK", "name": "Galaxy", "image_url": "https://example.com/galaxy.jpg", "description": "Smartphone",
+	 "price": 900, "rating": 4.5, "category": "Smartphones", "brand": "Samsung",
+	 "specifications": [{"name": "RAM", "value": "8", "unit": "GB"}]},
+	{"id": "BAD", "name": "Roto", "image_url": "not a url", "description": "Precio negativo",
+	 "price": -10, "rating": 7, "category": "Smartphones", "brand": "Acme",
+	 "specifications": [{"name": "RAM", "value": ""}]},
+	{"id": "", "name": "", "image_url": "https://example.com/x.jpg", "description": "Sin ID",
+	 "price": 10, "rating": 3, "category": "Smartphones", "brand": "Acme"}
+]`
+
+func TestParseCatalogValidationMode(t *testing.T) {
+	for value, want := range map[string]domain.CatalogValidationMode{"strict": domain.ValidationStrict, " Lenient ": domain.ValidationLenient} {
+		mode, err := domain.ParseCatalogValidationMode(value)
+		if err != nil || mode != want {
+			t.Errorf("ParseCatalogValidationMode(%q) = %q, %v, want %q", value, mode, err, want)
+		}
+	}
+
+	if _, err := domain.ParseCatalogValidationMode("disabled"); err == nil {
+		t.Error("expected error for unsupported mode")
+	}
+}
+
+func TestRepositoryValidationMode(t *testing.T) {
+	path := createTestFile(t, catalogValidationTestProducts)
+
+	t.Run("Sin validación se cargan todos los productos", func(t *testing.T) {
+		repo, err := jsonRepo.NewProductRepository(path)
+		if err != nil {
+			t.Fatalf("NewProductRepository() error = %v", err)
+		}
+
+		report := repo.GetValidationReport()
+		if report.Mode != domain.ValidationDisabled || report.LoadedProducts != 3 || len(report.Rejected) != 0 {
+			t.Errorf("unexpected report %+v", report)
+		}
+	})
+
+	t.Run("Modo estricto", func(t *testing.T) {
+		repo, _ := jsonRepo.NewProductRepository(path)
+
+		err := repo.SetValidationMode(domain.ValidationStrict)
+		if err == nil {
+			t.Fatal("expected error in strict mode")
+		}
+		if repo.GetProductCount() != 3 {
+			t.Errorf("previous catalog should be kept, got %d products", repo.GetProductCount())
+		}
+	})
+
+	t.Run("Modo permisivo", func(t *testing.T) {
+		repo, _ := jsonRepo.NewProductRepository(path)
+
+		if err := repo.SetValidationMode(domain.ValidationLenient); err != nil {
+			t.Fatalf("SetValidationMode() error = %v", err)
+		}
+
+		if repo.GetProductCount() != 1 {
+			t.Errorf("expected only the valid product, got %d", repo.GetProductCount())
+		}
+		if _, err := repo.GetByID("BAD"); err == nil {
+			t.Error("invalid product should not be loaded")
+		}
+
+		report := repo.GetValidationReport()
+		if report.Mode != domain.ValidationLenient || report.TotalProducts != 3 || report.LoadedProducts != 1 {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if len(report.Rejected) != 2 {
+			t.Fatalf("expected 2 rejected products, got %+v", report.Rejected)
+		}
+
+		bad := report.Rejected[0]
+		fields := make([]string, len(bad.Errors))
+		for i, e := range bad.Errors {
+			fields[i] = e.Field
+		}
+		want := []string{
+			"products[1].image_url",
+			"products[1].price",
+			"products[1].rating",
+			"products[1].specifications[0].value",
+		}
+		if bad.Index != 1 || bad.ProductID != "BAD" || !reflect.DeepEqual(fields, want) {
+			t.Errorf("unexpected rejected product %+v, fields %v", bad, fields)
+		}
+		if bad.Errors[1].Message != "must be greater than 0" {
+			t.Errorf("unexpected price message %q", bad.Errors[1].Message)
+		}
+
+		if missing := report.Rejected[1]; missing.Index != 2 || len(missing.Errors) != 2 {
+			t.Errorf("expected missing id and name errors, got %+v", missing)
+		}
+	})
+}
+
+func TestRepositoryLenientModeCatalogRules(t *testing.T) {
+	path := createTestFile(t, `[
+	{"id": "OK", "name": "Galaxy", "image_url": "https://example.com/galaxy.jpg", "description": "Smartphone",
+	 "price": 900, "rating": 4.5, "category": "Smartphones", "brand": "Samsung",
+	 "specifications": [{"name": "RAM", "value": "8", "unit": "GB"}]},
+	{"id": "TABLET", "name": "iPad", "image_url": "https://example.com/ipad.jpg", "description": "Tablet",
+	 "price": 800, "rating": 4.6, "category": "Tablets", "brand": "Apple",
+	 "specifications": [{"name": "RAM", "value": "8", "unit": "GB"}]}
+]`)
+
+	repo, err := jsonRepo.NewProductRepository(path)
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+	if err := repo.SetValidationMode(domain.ValidationLenient); err != nil {
+		t.Fatalf("SetValidationMode() error = %v", err)
+	}
+	if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+		t.Fatalf("SetTaxonomy() in lenient mode error = %v", err)
+	}
+
+	if _, err := repo.GetByID("TABLET"); err == nil {
+		t.Error("a product outside the taxonomy should not be loaded")
+	}
+	if product, err := repo.GetByID("OK"); err != nil || len(product.Breadcrumbs) != 3 {
+		t.Errorf("GetByID(OK) = %+v, %v", product, err)
+	}
+
+	report := repo.GetValidationReport()
+	if report.TotalProducts != 2 || report.LoadedProducts != 1 || len(report.Rejected) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if rejected := report.Rejected[0]; rejected.Index != 1 || rejected.ProductID != "TABLET" ||
+		len(rejected.Errors) != 1 || rejected.Errors[0].Field != "products[1].category" {
+		t.Errorf("unexpected rejected product %+v", rejected)
+	}
+
+	t.Run("Una escritura fuera de la taxonomía se rechaza", func(t *testing.T) {
+		product := writeTestProduct("NEW")
+		product.Category = "Tablets"
+
+		var errs domain.ValidationErrors
+		if _, err := repo.Create(product); !errors.As(err, &errs) || errs[0].Field != "category" {
+			t.Fatalf("Create() error = %v, want a category validation error", err)
+		}
+		if _, err := repo.GetByID("NEW"); err == nil {
+			t.Error("the rejected product should not be created")
+		}
+		if stored := readStoredProducts(t, path); len(stored) != 2 {
+			t.Errorf("the rejected product should not be persisted, got %d products", len(stored))
+		}
+	})
+}
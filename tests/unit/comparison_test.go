@@ -0,0 +1,259 @@
+package unit
+
+import (
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func TestNewComparisonMatrix(t *testing.T) {
+	products := []*domain.Product{
+		{
+			ID: "PHONE001",
+			Specifications: []domain.Specification{
+				{Name: "RAM", Value: "12", Unit: "GB"},
+				{Name: "Almacenamiento", Value: "256", Unit: "GB"},
+				{Name: "S Pen", Value: "Sí"},
+			},
+		},
+		{
+			ID: "PHONE002",
+			Specifications: []domain.Specification{
+				{Name: "ram", Value: "8", Unit: "GB"},
+				{Name: "Almacenamiento", Value: "256", Unit: "GB"},
+			},
+		},
+	}
+
+	matrix := domain.NewComparisonMatrix(products, nil)
+
+	t.Run("Unión de especificaciones en orden de aparición", func(t *testing.T) {
+		if len(matrix.Rows) != 3 {
+			t.Fatalf("NewComparisonMatrix() rows = %v, want 3", len(matrix.Rows))
+		}
+
+		expected := []string{"RAM", "Almacenamiento", "S Pen"}
+		for i, name := range expected {
+			if matrix.Rows[i].Name != name {
+				t.Errorf("Rows[%d].Name = %v, want %v", i, matrix.Rows[i].Name, name)
+			}
+			if len(matrix.Rows[i].Cells) != len(products) {
+				t.Errorf("Rows[%d] cells = %v, want %v", i, len(matrix.Rows[i].Cells), len(products))
+			}
+		}
+	})
+
+	t.Run("Fila con valores distintos", func(t *testing.T) {
+		row := matrix.Rows[0]
+		if !row.Differs {
+			t.Error("RAM row should differ")
+		}
+		if row.Cells[1].ProductID != "PHONE002" || row.Cells[1].Value != "8" {
+			t.Errorf("RAM cell for PHONE002 = %+v, want value 8", row.Cells[1])
+		}
+	})
+
+	t.Run("Fila con valores iguales", func(t *testing.T) {
+		if matrix.Rows[1].Differs {
+			t.Error("Almacenamiento row should not differ")
+		}
+	})
+
+	t.Run("Celda faltante", func(t *testing.T) {
+		row := matrix.Rows[2]
+		if !row.Cells[1].Missing {
+			t.Error("S Pen cell for PHONE002 should be missing")
+		}
+		if row.Cells[0].Missing {
+			t.Error("S Pen cell for PHONE001 should not be missing")
+		}
+		if !row.Differs {
+			t.Error("Row with missing cells should differ")
+		}
+	})
+}
+
+func TestComparisonMatrixWinners(t *testing.T) {
+	rules := &domain.ComparisonRuleSet{
+		Fields: []domain.ComparisonRule{
+			{Name: "price", Direction: domain.LowerIsBetter, Type: domain.NumericRule},
+			{Name: "rating", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+		},
+		Specifications: []domain.ComparisonRule{
+			{Name: "RAM", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+			{Name: "Almacenamiento", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+			{Name: "Batería", Direction: domain.LowerIsBetter, Type: domain.NumericRule},
+			{Name: "Batería", Category: "Smartphones", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+		},
+	}
+
+	products := []*domain.Product{
+		{
+			ID: "PHONE001", Price: 1299.99, Rating: 4.6, Category: "Smartphones",
+			Specifications: []domain.Specification{
+				{Name: "RAM", Value: "12", Unit: "GB"},
+				{Name: "Almacenamiento", Value: "256", Unit: "GB"},
+				{Name: "Batería", Value: "5000", Unit: "mAh"},
+			},
+		},
+		{
+			ID: "PHONE002", Price: 1399.99, Rating: 4.8, Category: "Smartphones",
+			Specifications: []domain.Specification{
+				{Name: "RAM", Value: "8", Unit: "GB"},
+				{Name: "Almacenamiento", Value: "256", Unit: "GB"},
+				{Name: "Batería", Value: "4422", Unit: "mAh"},
+			},
+		},
+	}
+
+	matrix := domain.NewComparisonMatrix(products, rules)
+
+	tests := []struct {
+		name    string
+		row     domain.SpecComparisonRow
+		winners []string
+		tie     bool
+	}{
+		{name: "Menor precio gana", row: matrix.Fields[0], winners: []string{"PHONE001"}},
+		{name: "Mayor rating gana", row: matrix.Fields[1], winners: []string{"PHONE002"}},
+		{name: "Mayor RAM gana", row: matrix.Rows[0], winners: []string{"PHONE001"}},
+		{name: "Empate en almacenamiento", row: matrix.Rows[1], winners: []string{"PHONE001", "PHONE002"}, tie: true},
+		{name: "Regla por categoría tiene prioridad", row: matrix.Rows[2], winners: []string{"PHONE001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.row.Winners) != len(tt.winners) {
+				t.Fatalf("%s winners = %v, want %v", tt.row.Name, tt.row.Winners, tt.winners)
+			}
+			for i, id := range tt.winners {
+				if tt.row.Winners[i] != id {
+					t.Errorf("%s winners = %v, want %v", tt.row.Name, tt.row.Winners, tt.winners)
+				}
+			}
+			if tt.row.Tie != tt.tie {
+				t.Errorf("%s tie = %v, want %v", tt.row.Name, tt.row.Tie, tt.tie)
+			}
+		})
+	}
+}
+
+func TestComparisonMatrixMixedCategories(t *testing.T) {
+	rules := &domain.ComparisonRuleSet{
+		Specifications: []domain.ComparisonRule{
+			{Name: "Peso", Direction: domain.LowerIsBetter, Type: domain.NumericRule},
+			{Name: "Drivers", Category: "Audífonos", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+			{Name: "Tipo", Category: "Audífonos", Direction: domain.HigherIsBetter, Type: domain.OrdinalRule, Order: []string{"In-ear", "Over-ear"}},
+		},
+	}
+
+	products := []*domain.Product{
+		{
+			ID: "HEADPHONES001", Category: "Audífonos",
+			Specifications: []domain.Specification{
+				{Name: "Peso", Value: "250", Unit: "g"},
+				{Name: "Drivers", Value: "40", Unit: "mm"},
+				{Name: "Tipo", Value: "Over-ear"},
+			},
+		},
+		{
+			ID: "HEADPHONES002", Category: "Audífonos",
+			Specifications: []domain.Specification{
+				{Name: "Peso", Value: "5", Unit: "g"},
+				{Name: "Drivers", Value: "11", Unit: "mm"},
+			},
+		},
+		{
+			ID: "SPEAKER001", Category: "Parlantes",
+			Specifications: []domain.Specification{
+				{Name: "Peso", Value: "540", Unit: "g"},
+				{Name: "Tipo", Value: "Portátil"},
+			},
+		},
+	}
+
+	matrix := domain.NewComparisonMatrix(products, rules)
+
+	rows := make(map[string]domain.SpecComparisonRow)
+	for _, row := range matrix.Rows {
+		rows[row.Name] = row
+	}
+
+	t.Run("Regla general entre categorías", func(t *testing.T) {
+		row := rows["Peso"]
+		if len(row.Winners) != 1 || row.Winners[0] != "HEADPHONES002" || row.RuleConflict != "" {
+			t.Errorf("Peso winners = %v, conflict = %q, want [HEADPHONES002] without conflict", row.Winners, row.RuleConflict)
+		}
+	})
+
+	t.Run("Regla de la categoría de quienes declaran la especificación", func(t *testing.T) {
+		row := rows["Drivers"]
+		if len(row.Winners) != 1 || row.Winners[0] != "HEADPHONES001" || row.RuleConflict != "" {
+			t.Errorf("Drivers winners = %v, conflict = %q, want [HEADPHONES001] without conflict", row.Winners, row.RuleConflict)
+		}
+	})
+
+	t.Run("Reglas distintas por categoría se informan", func(t *testing.T) {
+		row := rows["Tipo"]
+		if len(row.Winners) != 0 {
+			t.Errorf("Tipo winners = %v, want none", row.Winners)
+		}
+		want := "categories 'Audífonos' and 'Parlantes' use different rules for 'Tipo'"
+		if row.RuleConflict != want {
+			t.Errorf("Tipo conflict = %q, want %q", row.RuleConflict, want)
+		}
+	})
+
+	t.Run("El puntaje rechaza un criterio con reglas distintas", func(t *testing.T) {
+		_, err := domain.ScoreProducts(products, map[string]float64{"Tipo": 1}, rules)
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("ScoreProducts() error = %v, want *domain.ValidationError", err)
+		}
+	})
+}
+
+func TestComparisonRuleSetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    domain.ComparisonRule
+		wantErr bool
+	}{
+		{name: "Regla numérica válida", rule: domain.ComparisonRule{Name: "RAM", Direction: domain.HigherIsBetter, Type: domain.NumericRule}},
+		{name: "Dirección inválida", rule: domain.ComparisonRule{Name: "RAM", Direction: "bigger", Type: domain.NumericRule}, wantErr: true},
+		{name: "Tipo inválido", rule: domain.ComparisonRule{Name: "RAM", Direction: domain.HigherIsBetter, Type: "fuzzy"}, wantErr: true},
+		{name: "Ordinal sin orden", rule: domain.ComparisonRule{Name: "Tipo", Direction: domain.HigherIsBetter, Type: domain.OrdinalRule}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := &domain.ComparisonRuleSet{Specifications: []domain.ComparisonRule{tt.rule}}
+			err := rules.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComparisonMatrixUnitConversion(t *testing.T) {
+	rules := &domain.ComparisonRuleSet{
+		Specifications: []domain.ComparisonRule{
+			{Name: "Almacenamiento", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+		},
+	}
+
+	products := []*domain.Product{
+		{ID: "LAPTOP001", Specifications: []domain.Specification{{Name: "Almacenamiento", Value: "512", Unit: "GB SSD"}}},
+		{ID: "LAPTOP002", Specifications: []domain.Specification{{Name: "Almacenamiento", Value: "1", Unit: "TB SSD"}}},
+		{ID: "LAPTOP003", Specifications: []domain.Specification{{Name: "Almacenamiento", Value: "1024", Unit: "GB SSD"}}},
+	}
+
+	row := domain.NewComparisonMatrix(products, rules).Rows[0]
+
+	if !row.Tie || len(row.Winners) != 2 || row.Winners[0] != "LAPTOP002" || row.Winners[1] != "LAPTOP003" {
+		t.Errorf("Almacenamiento winners = %v (tie %v), want [LAPTOP002 LAPTOP003] tie", row.Winners, row.Tie)
+	}
+	if row.Cells[1].Value != "1" || row.Cells[1].Unit != "TB SSD" {
+		t.Errorf("Original value must be preserved, got %+v", row.Cells[1])
+	}
+}
@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"reflect"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func TestParseFacetRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		facets  string
+		buckets string
+		want    *domain.FacetRequest
+		wantErr bool
+	}{
+		{
+			name:   "Campos y especificaciones",
+			facets: "Brand, price,spec.RAM,spec.Sistema Operativo,brand",
+			want:   &domain.FacetRequest{Fields: []string{"brand", "price"}, Specs: []string{"RAM", "Sistema Operativo"}},
+		},
+		{
+			name:    "Rangos de precio configurados",
+			facets:  "price",
+			buckets: "500, 1000,2000",
+			want:    &domain.FacetRequest{Fields: []string{"price"}, PriceBuckets: []float64{500, 1000, 2000}},
+		},
+		{name: "Faceta desconocida", facets: "color", wantErr: true},
+		{name: "Rangos desordenados", facets: "price", buckets: "1000,500", wantErr: true},
+		{name: "Rango inválido", facets: "price", buckets: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseFacetRequest(tt.facets, tt.buckets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFacetRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFacetRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeFacets(t *testing.T) {
+	products := []*domain.Product{
+		{ID: "P1", Brand: "Samsung", Category: "Smartphones", Price: 1199.99, Rating: 4.5,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "12", Unit: "GB"}, {Name: "Almacenamiento", Value: "1", Unit: "TB"}}},
+		{ID: "P2", Brand: "Apple", Category: "Smartphones", Price: 999, Rating: 4.8,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "8", Unit: "GB"}, {Name: "Almacenamiento", Value: "1024", Unit: "GB"}}},
+		{ID: "P3", Brand: "samsung", Category: "Tablets", Price: 499, Rating: 3.9,
+			Specifications: []domain.Specification{{Name: "ram", Value: "8", Unit: "GB"}}},
+		{ID: "P4", Brand: "Sony", Category: "Audífonos", Price: 2000, Rating: 4.2},
+	}
+	for _, product := range products {
+		product.ParseSpecifications()
+	}
+
+	request := &domain.FacetRequest{
+		Fields:       []string{"brand", "price", "rating"},
+		Specs:        []string{"RAM", "Almacenamiento"},
+		PriceBuckets: []float64{500, 1000, 2000},
+	}
+	facets := domain.ComputeFacets(products, request)
+
+	values := func(facet domain.Facet) map[string]int {
+		result := make(map[string]int)
+		for _, value := range facet.Values {
+			result[value.Value] = value.Count
+		}
+		return result
+	}
+
+	if len(facets) != 5 {
+		t.Fatalf("ComputeFacets() returned %d facets, want 5", len(facets))
+	}
+
+	t.Run("Marcas sin distinguir mayúsculas", func(t *testing.T) {
+		if facets[0].Values[0].Value != "Samsung" || facets[0].Values[0].Count != 2 {
+			t.Errorf("brand facet = %+v, want Samsung with 2 first", facets[0].Values)
+		}
+		if got := values(facets[0]); got["Apple"] != 1 || got["Sony"] != 1 {
+			t.Errorf("brand facet = %v", got)
+		}
+	})
+
+	t.Run("Rangos de precio", func(t *testing.T) {
+		want := map[string]int{"0-500": 1, "500-1000": 1, "1000-2000": 1, "2000+": 1}
+		if got := values(facets[1]); !reflect.DeepEqual(got, want) {
+			t.Errorf("price facet = %v, want %v", got, want)
+		}
+		if from, to := facets[1].Values[1].From, facets[1].Values[1].To; from == nil || to == nil || *from != 500 || *to != 1000 {
+			t.Errorf("price bucket bounds = %v-%v, want 500-1000", from, to)
+		}
+	})
+
+	t.Run("Bandas de calificación acumulativas", func(t *testing.T) {
+		want := map[string]int{"4.5+": 2, "4+": 3, "3+": 4}
+		if got := values(facets[2]); !reflect.DeepEqual(got, want) {
+			t.Errorf("rating facet = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Valores de especificación ordenados numéricamente", func(t *testing.T) {
+		if facets[3].Name != "spec.RAM" {
+			t.Errorf("facet name = %q, want spec.RAM", facets[3].Name)
+		}
+		want := []domain.FacetValue{{Value: "8 GB", Count: 2}, {Value: "12 GB", Count: 1}}
+		if !reflect.DeepEqual(facets[3].Values, want) {
+			t.Errorf("RAM facet = %+v, want %+v", facets[3].Values, want)
+		}
+	})
+
+	t.Run("Valores equivalentes en distintas unidades", func(t *testing.T) {
+		want := []domain.FacetValue{{Value: "1024 GB", Count: 2}}
+		if !reflect.DeepEqual(facets[4].Values, want) {
+			t.Errorf("Almacenamiento facet = %+v, want %+v", facets[4].Values, want)
+		}
+	})
+
+	t.Run("Sin facetas solicitadas", func(t *testing.T) {
+		if got := domain.ComputeFacets(products, nil); got != nil {
+			t.Errorf("ComputeFacets(nil) = %+v, want nil", got)
+		}
+	})
+}
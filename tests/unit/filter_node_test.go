@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func TestCompileFilter(t *testing.T) {
+	products := queryTestProducts()
+
+	tests := []struct {
+		name     string
+		document string
+		wantIDs  []string
+	}{
+		{name: "Campo de texto", document: `{"field": "brand", "op": "=", "value": "samsung"}`, wantIDs: []string{"S24", "A54"}},
+		{name: "Campo numérico", document: `{"field": "price", "op": "<", "value": 1000}`, wantIDs: []string{"A54", "IPHONE"}},
+		{name: "Campo booleano", document: `{"field": "available", "op": "=", "value": false}`, wantIDs: []string{"IPHONE"}},
+		{name: "Especificación", document: `{"spec": "RAM", "op": ">", "value": "8GB"}`, wantIDs: []string{"S24", "XPS"}},
+		{name: "Texto", document: `{"text": "S Pen"}`, wantIDs: []string{"S24"}},
+		{
+			name:     "Árbol anidado",
+			document: `{"and": [{"or": [{"field": "brand", "op": "=", "value": "Apple"}, {"field": "rating", "op": ">=", "value": 4.7}]}, {"not": {"field": "available", "op": "=", "value": false}}]}`,
+			wantIDs:  []string{"S24"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node domain.FilterNode
+			if err := json.Unmarshal([]byte(tt.document), &node); err != nil {
+				t.Fatalf("invalid test document: %v", err)
+			}
+
+			condition, err := domain.CompileFilter(&node)
+			if err != nil {
+				t.Fatalf("CompileFilter() error = %v", err)
+			}
+
+			var got []string
+			for _, product := range products {
+				if condition.Matches(product) {
+					got = append(got, product.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("matched %v, want %v (condition %v)", got, tt.wantIDs, condition)
+			}
+		})
+	}
+}
+
+func TestCompileFilterValidationErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		document   string
+		wantFields []string
+	}{
+		{name: "Nodo vacío", document: `{}`, wantFields: []string{"filter"}},
+		{name: "Nodo ambiguo", document: `{"field": "brand", "text": "galaxy"}`, wantFields: []string{"filter"}},
+		{name: "Lista vacía", document: `{"or": []}`, wantFields: []string{"filter.or"}},
+		{name: "Campo desconocido", document: `{"field": "color", "op": "=", "value": "rojo"}`, wantFields: []string{"filter.field"}},
+		{name: "Tipo de valor incorrecto", document: `{"field": "price", "op": ">", "value": "barato"}`, wantFields: []string{"filter.value"}},
+		{name: "Operador no soportado por el campo", document: `{"field": "brand", "op": ">", "value": "Apple"}`, wantFields: []string{"filter.op"}},
+		{
+			name:       "Errores en varios nodos",
+			document:   `{"and": [{"spec": "RAM", "op": "=>", "value": 8}, {"not": {"field": "name", "op": "~"}}]}`,
+			wantFields: []string{"filter.and[0].op", "filter.and[1].not.value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node domain.FilterNode
+			if err := json.Unmarshal([]byte(tt.document), &node); err != nil {
+				t.Fatalf("invalid test document: %v", err)
+			}
+
+			_, err := domain.CompileFilter(&node)
+
+			var errs domain.ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("CompileFilter() error = %v, want ValidationErrors", err)
+			}
+
+			var fields []string
+			for _, fieldErr := range errs {
+				fields = append(fields, fieldErr.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("error fields = %v, want %v (%v)", fields, tt.wantFields, err)
+			}
+		})
+	}
+}
+
+func TestProjectProduct(t *testing.T) {
+	product := queryTestProducts()[0]
+
+	got := domain.ProjectProduct(product, []string{"id", "Price", "available"})
+	want := map[string]interface{}{"id": "S24", "price": 1299.99, "available": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectProduct() = %v, want %v", got, want)
+	}
+
+	if err := domain.ValidateProjection([]string{"id", "weight"}); err == nil {
+		t.Error("ValidateProjection() expected error for unknown field")
+	}
+}
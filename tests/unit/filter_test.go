@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func TestParseSpecPredicate(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    domain.SpecPredicate
+		wantErr bool
+	}{
+		{name: "Mayor o igual", expr: "RAM>=12", want: domain.SpecPredicate{Name: "RAM", Operator: domain.OpGreaterOrEqual, Value: "12"}},
+		{name: "Igualdad", expr: "Almacenamiento=256", want: domain.SpecPredicate{Name: "Almacenamiento", Operator: domain.OpEqual, Value: "256"}},
+		{name: "Distinto", expr: "RAM!=8", want: domain.SpecPredicate{Name: "RAM", Operator: domain.OpNotEqual, Value: "8"}},
+		{name: "Contiene con espacios", expr: "Sistema Operativo~Android", want: domain.SpecPredicate{Name: "Sistema Operativo", Operator: domain.OpContains, Value: "Android"}},
+		{name: "Sin operador", expr: "RAM", wantErr: true},
+		{name: "Sin valor", expr: "RAM>=", wantErr: true},
+		{name: "Sin nombre", expr: ">=12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseSpecPredicate(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSpecPredicate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseSpecPredicate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductFilterMatches(t *testing.T) {
+	product := &domain.Product{
+		ID:        "LAPTOP002",
+		Price:     2299.99,
+		Rating:    4.3,
+		Category:  "Laptops",
+		Brand:     "Dell",
+		Available: true,
+		Specifications: []domain.Specification{
+			{Name: "RAM", Value: "16", Unit: "GB"},
+			{Name: "Almacenamiento", Value: "1", Unit: "TB SSD"},
+			{Name: "Pantalla", Value: "15.6", Unit: "pulgadas"},
+			{Name: "Sistema Operativo", Value: "Windows 11 Pro"},
+		},
+	}
+	product.ParseSpecifications()
+
+	unavailable := false
+
+	tests := []struct {
+		name   string
+		filter domain.ProductFilter
+		want   bool
+	}{
+		{name: "Filtro vacío", filter: domain.ProductFilter{}, want: true},
+		{name: "Categoría sin mayúsculas", filter: domain.ProductFilter{Category: "laptops"}, want: true},
+		{name: "Marca distinta", filter: domain.ProductFilter{Brand: "Apple"}, want: false},
+		{name: "Disponibilidad", filter: domain.ProductFilter{Available: &unavailable}, want: false},
+		{name: "Rating mínimo igual", filter: domain.ProductFilter{MinRating: 4.3}, want: true},
+		{name: "Rating mínimo superior", filter: domain.ProductFilter{MinRating: 4.5}, want: false},
+		{name: "RAM numérica", filter: specFilter("RAM", domain.OpGreaterOrEqual, "12"), want: true},
+		{name: "Almacenamiento con conversión", filter: specFilter("Almacenamiento", domain.OpGreater, "512GB"), want: true},
+		{name: "Almacenamiento sin unidad en la unidad declarada", filter: specFilter("Almacenamiento", domain.OpGreater, "512"), want: false},
+		{name: "Almacenamiento con unidad", filter: specFilter("Almacenamiento", domain.OpEqual, "1TB"), want: true},
+		{name: "Pantalla en centímetros", filter: specFilter("Pantalla", domain.OpLess, "40 cm"), want: true},
+		{name: "Unidad incompatible", filter: specFilter("RAM", domain.OpGreaterOrEqual, "12 mAh"), want: false},
+		{name: "Texto contiene", filter: specFilter("Sistema Operativo", domain.OpContains, "windows"), want: true},
+		{name: "Texto igual", filter: specFilter("sistema operativo", domain.OpEqual, "Windows 11 Pro"), want: true},
+		{name: "Orden sobre texto", filter: specFilter("Sistema Operativo", domain.OpGreater, "10"), want: false},
+		{name: "Especificación inexistente", filter: specFilter("Batería", domain.OpGreater, "1"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(product); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecPredicateDeclaredUnit(t *testing.T) {
+	// Los valores se guardan en la unidad canónica (pulgadas para longitudes); un valor sin
+	// unidad en el filtro se lee en la unidad declarada por el producto
+	product := &domain.Product{
+		ID: "HEADPHONES001",
+		Specifications: []domain.Specification{
+			{Name: "Drivers", Value: "30", Unit: "mm"},
+			{Name: "Peso", Value: "250 g"},
+		},
+	}
+	product.ParseSpecifications()
+
+	tests := []struct {
+		name   string
+		filter domain.ProductFilter
+		want   bool
+	}{
+		{name: "Mayor o igual sin unidad", filter: specFilter("Drivers", domain.OpGreaterOrEqual, "30"), want: true},
+		{name: "Igual sin unidad", filter: specFilter("Drivers", domain.OpEqual, "30"), want: true},
+		{name: "Mayor sin unidad", filter: specFilter("Drivers", domain.OpGreater, "30"), want: false},
+		{name: "Con otra unidad", filter: specFilter("Drivers", domain.OpGreaterOrEqual, "3cm"), want: true},
+		{name: "Unidad en el valor", filter: specFilter("Peso", domain.OpLess, "300"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(product); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// specFilter crea un filtro con un único predicado de especificación
+func specFilter(name string, operator domain.FilterOperator, value string) domain.ProductFilter {
+	return domain.ProductFilter{
+		Specs: []domain.SpecPredicate{{Name: name, Operator: operator, Value: value}},
+	}
+}
@@ -0,0 +1,687 @@
+package unit
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/repository/logstore"
+)
+
+// faultyFile simula fallas del disco: escribe solo los primeros writeLimit bytes de la
+// escritura que falla y puede fallar también al sincronizar o al truncar
+type faultyFile struct {
+	logstore.File
+
+	mu           sync.Mutex
+	failWrite    bool
+	writeLimit   int
+	failSync     bool
+	failTruncate bool
+}
+
+func (f *faultyFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.failWrite {
+		return f.File.Write(p)
+	}
+
+	n, _ := f.File.Write(p[:f.writeLimit])
+	return n, errors.New("injected write failure")
+}
+
+func (f *faultyFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failSync {
+		return errors.New("injected sync failure")
+	}
+	return f.File.Sync()
+}
+
+func (f *faultyFile) Truncate(size int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failTruncate {
+		return errors.New("injected truncate failure")
+	}
+	return f.File.Truncate(size)
+}
+
+// set cambia las fallas inyectadas
+func (f *faultyFile) set(change func(f *faultyFile)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	change(f)
+}
+
+// openFaultyLog abre el registro con un archivo que permite inyectar fallas
+func openFaultyLog(t *testing.T, path string) (*logstore.ProductRepository, *faultyFile) {
+	t.Helper()
+
+	var faulty *faultyFile
+	repo, err := logstore.OpenWithOptions(path, logstore.Options{WrapFile: func(file logstore.File) logstore.File {
+		faulty = &faultyFile{File: file}
+		return faulty
+	}})
+	if err != nil {
+		t.Fatalf("OpenWithOptions() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	return repo, faulty
+}
+
+// reopenLog cierra el registro y lo vuelve a abrir desde el archivo
+func reopenLog(t *testing.T, repo *logstore.ProductRepository, path string) *logstore.ProductRepository {
+	t.Helper()
+
+	repo.Close()
+	reopened, err := logstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { reopened.Close() })
+
+	return reopened
+}
+
+// fileSize devuelve el tamaño del archivo
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat %s: %v", path, err)
+	}
+	return info.Size()
+}
+
+// productIDs devuelve los IDs de los productos en orden
+func productIDs(t *testing.T, repo domain.ProductRepository) []string {
+	t.Helper()
+
+	products, err := repo.GetAll(domain.ProductFilter{})
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	return ids
+}
+
+func TestLogStoreReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.log")
+
+	repo, err := logstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	for _, id := range []string{"A", "B", "C"} {
+		if _, err := repo.Create(writeTestProduct(id)); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+
+	product := writeTestProduct("B")
+	product.Version = 1
+	product.Price = 599
+	if _, err := repo.Update(product); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := repo.Delete("A"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Create(writeTestProduct("A")); err != nil {
+		t.Fatalf("Create(A) again error = %v", err)
+	}
+
+	repo = reopenLog(t, repo, path)
+
+	if ids := fmt.Sprint(productIDs(t, repo)); ids != "[B C A]" {
+		t.Errorf("expected products [B C A] after replay, got %s", ids)
+	}
+	updated, err := repo.GetByID("B")
+	if err != nil || updated.Price != 599 || updated.Version != 2 {
+		t.Errorf("GetByID(B) = %+v, %v", updated, err)
+	}
+	if updated.Specifications[0].Typed.Kind != domain.SpecKindNumber || len(updated.Breadcrumbs) != 1 {
+		t.Errorf("expected derived fields after replay, got %+v", updated)
+	}
+
+	if stats := repo.Stats(); stats.Records != 6 || stats.LiveProducts != 3 || stats.StaleRecords != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestLogStoreRecoversTornWrites(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "products.log")
+
+	repo, err := logstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := repo.Create(writeTestProduct("A")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	committed := fileSize(t, path)
+	if _, err := repo.Create(writeTestProduct("B")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	repo.Close()
+
+	full, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+
+	// Cortar el último registro en cada byte posible: cabecera o contenido incompletos
+	for cut := committed; cut < int64(len(full)); cut++ {
+		torn := filepath.Join(dir, fmt.Sprintf("torn-%d.log", cut))
+		if err := os.WriteFile(torn, full[:cut], 0o644); err != nil {
+			t.Fatalf("Failed to write torn log: %v", err)
+		}
+
+		recovered, err := logstore.Open(torn)
+		if err != nil {
+			t.Fatalf("Open() with log cut at %d error = %v", cut, err)
+		}
+		if ids := fmt.Sprint(productIDs(t, recovered)); ids != "[A]" {
+			t.Errorf("cut at %d: expected only A, got %s", cut, ids)
+		}
+		if got := recovered.RecoveredBytes(); got != cut-committed {
+			t.Errorf("cut at %d: expected %d recovered bytes, got %d", cut, cut-committed, got)
+		}
+		if size := fileSize(t, torn); size != committed {
+			t.Errorf("cut at %d: expected log truncated to %d, got %d", cut, committed, size)
+		}
+
+		// Las escrituras siguientes se agregan después del último registro válido
+		if _, err := recovered.Create(writeTestProduct("C")); err != nil {
+			t.Fatalf("cut at %d: Create() after recovery error = %v", cut, err)
+		}
+		recovered = reopenLog(t, recovered, torn)
+		if ids := fmt.Sprint(productIDs(t, recovered)); ids != "[A C]" {
+			t.Errorf("cut at %d: expected [A C] after reopening, got %s", cut, ids)
+		}
+		recovered.Close()
+	}
+
+	t.Run("Último registro con checksum inválido", func(t *testing.T) {
+		damaged := append([]byte(nil), full...)
+		damaged[len(damaged)-2] ^= 0xff
+		torn := filepath.Join(dir, "damaged-tail.log")
+		if err := os.WriteFile(torn, damaged, 0o644); err != nil {
+			t.Fatalf("Failed to write damaged log: %v", err)
+		}
+
+		recovered, err := logstore.Open(torn)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer recovered.Close()
+		if ids := fmt.Sprint(productIDs(t, recovered)); ids != "[A]" {
+			t.Errorf("expected only A, got %s", ids)
+		}
+	})
+
+	t.Run("Final del archivo con ceros", func(t *testing.T) {
+		// Una caída puede dejar el archivo extendido con ceros en lugar de los datos: una
+		// cabecera de largo y checksum cero o un registro a medio escribir seguido de ceros
+		tests := []struct {
+			name      string
+			data      []byte
+			want      string
+			validSize int64
+		}{
+			{"Después del último registro", append(append([]byte(nil), full...), make([]byte, 64)...), "[A B]", int64(len(full))},
+			{"En lugar del último registro", append(append([]byte(nil), full[:committed]...), make([]byte, int64(len(full))-committed)...), "[A]", committed},
+			{"Después de un registro parcial", append(append([]byte(nil), full[:committed+12]...), make([]byte, 64)...), "[A]", committed},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				zeroed := filepath.Join(t.TempDir(), "products.log")
+				if err := os.WriteFile(zeroed, tt.data, 0o644); err != nil {
+					t.Fatalf("Failed to write log: %v", err)
+				}
+
+				recovered, err := logstore.Open(zeroed)
+				if err != nil {
+					t.Fatalf("Open() error = %v", err)
+				}
+				defer recovered.Close()
+
+				if ids := fmt.Sprint(productIDs(t, recovered)); ids != tt.want {
+					t.Errorf("expected %s, got %s", tt.want, ids)
+				}
+				if size := fileSize(t, zeroed); size != tt.validSize {
+					t.Errorf("expected log truncated to %d, got %d", tt.validSize, size)
+				}
+				if _, err := recovered.Create(writeTestProduct("C")); err != nil {
+					t.Errorf("Create() after recovery error = %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Ceros seguidos de un registro válido", func(t *testing.T) {
+		damaged := append(append(append([]byte(nil), full[:committed]...), make([]byte, 16)...), full[committed:]...)
+		corrupted := filepath.Join(dir, "zero-middle.log")
+		if err := os.WriteFile(corrupted, damaged, 0o644); err != nil {
+			t.Fatalf("Failed to write damaged log: %v", err)
+		}
+
+		if _, err := logstore.Open(corrupted); !errors.Is(err, logstore.ErrCorrupted) {
+			t.Errorf("expected ErrCorrupted, got %v", err)
+		}
+	})
+
+	t.Run("Registro dañado antes del final", func(t *testing.T) {
+		damaged := append([]byte(nil), full...)
+		damaged[committed-2] ^= 0xff
+		corrupted := filepath.Join(dir, "damaged-middle.log")
+		if err := os.WriteFile(corrupted, damaged, 0o644); err != nil {
+			t.Fatalf("Failed to write damaged log: %v", err)
+		}
+
+		if _, err := logstore.Open(corrupted); !errors.Is(err, logstore.ErrCorrupted) {
+			t.Errorf("expected ErrCorrupted, got %v", err)
+		}
+		if size := fileSize(t, corrupted); size != int64(len(full)) {
+			t.Error("a corrupted log should not be truncated")
+		}
+	})
+
+	t.Run("Largo dañado antes del final", func(t *testing.T) {
+		// Un largo que excede el archivo en el primer registro no es una escritura
+		// interrumpida: truncar ahí perdería el registro de B
+		damaged := append([]byte(nil), full...)
+		header := damaged[len("PRODLOG\x01"):]
+		binary.BigEndian.PutUint32(header, binary.BigEndian.Uint32(header)+1<<16)
+		corrupted := filepath.Join(dir, "damaged-length.log")
+		if err := os.WriteFile(corrupted, damaged, 0o644); err != nil {
+			t.Fatalf("Failed to write damaged log: %v", err)
+		}
+
+		if _, err := logstore.Open(corrupted); !errors.Is(err, logstore.ErrCorrupted) {
+			t.Errorf("expected ErrCorrupted, got %v", err)
+		}
+		if size := fileSize(t, corrupted); size != int64(len(full)) {
+			t.Error("a corrupted log should not be truncated")
+		}
+	})
+
+	t.Run("Cabecera del archivo incompleta", func(t *testing.T) {
+		partial := filepath.Join(dir, "partial-header.log")
+		if err := os.WriteFile(partial, full[:3], 0o644); err != nil {
+			t.Fatalf("Failed to write log: %v", err)
+		}
+
+		recovered, err := logstore.Open(partial)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer recovered.Close()
+		if count := recovered.GetProductCount(); count != 0 {
+			t.Errorf("expected an empty store, got %d products", count)
+		}
+	})
+
+	t.Run("Archivo que no es un registro", func(t *testing.T) {
+		other := createTestFile(t, `[]`)
+		if _, err := logstore.Open(other); err == nil {
+			t.Error("expected an error opening a file that is not a product log")
+		}
+	})
+}
+
+func TestLogStoreInterruptedImport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "products.log")
+
+	repo, err := logstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := repo.Create(writeTestProduct("A")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	committed := fileSize(t, path)
+
+	imported := []*domain.Product{writeTestProduct("B"), writeTestProduct("C"), writeTestProduct("D")}
+	if err := repo.Import(imported, domain.ValidationStrict); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	repo.Close()
+
+	full, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+
+	// Una caída en cualquier punto de la importación, incluso entre dos productos completos,
+	// descarta la importación entera para que se repita al iniciar
+	for cut := committed + 1; cut < int64(len(full)); cut++ {
+		torn := filepath.Join(dir, fmt.Sprintf("import-%d.log", cut))
+		if err := os.WriteFile(torn, full[:cut], 0o644); err != nil {
+			t.Fatalf("Failed to write torn log: %v", err)
+		}
+
+		recovered, err := logstore.Open(torn)
+		if err != nil {
+			t.Fatalf("Open() with log cut at %d error = %v", cut, err)
+		}
+		if ids := fmt.Sprint(productIDs(t, recovered)); ids != "[A]" {
+			t.Errorf("cut at %d: expected only A, got %s", cut, ids)
+		}
+		if size := fileSize(t, torn); size != committed {
+			t.Errorf("cut at %d: expected log truncated to %d, got %d", cut, committed, size)
+		}
+
+		if err := recovered.Import(imported, domain.ValidationStrict); err != nil {
+			t.Fatalf("cut at %d: Import() after recovery error = %v", cut, err)
+		}
+		recovered = reopenLog(t, recovered, torn)
+		if ids := fmt.Sprint(productIDs(t, recovered)); ids != "[A B C D]" {
+			t.Errorf("cut at %d: expected [A B C D] after importing again, got %s", cut, ids)
+		}
+		recovered.Close()
+	}
+
+	t.Run("Importación completa", func(t *testing.T) {
+		complete, err := logstore.Open(path)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer complete.Close()
+
+		if ids := fmt.Sprint(productIDs(t, complete)); ids != "[A B C D]" {
+			t.Errorf("expected [A B C D], got %s", ids)
+		}
+		if complete.RecoveredBytes() != 0 {
+			t.Errorf("expected no recovered bytes, got %d", complete.RecoveredBytes())
+		}
+	})
+}
+
+func TestLogStoreFailedWrites(t *testing.T) {
+	t.Run("Escritura parcial se deshace", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "products.log")
+		repo, faulty := openFaultyLog(t, path)
+
+		if _, err := repo.Create(writeTestProduct("A")); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		committed := fileSize(t, path)
+
+		faulty.set(func(f *faultyFile) { f.failWrite, f.writeLimit = true, 10 })
+		if _, err := repo.Create(writeTestProduct("B")); err == nil {
+			t.Fatal("expected the injected write failure")
+		}
+		if _, err := repo.GetByID("B"); err == nil {
+			t.Error("a failed write should not change the products")
+		}
+		if size := fileSize(t, path); size != committed {
+			t.Errorf("expected the partial record removed, log size %d want %d", size, committed)
+		}
+
+		faulty.set(func(f *faultyFile) { f.failWrite = false })
+		if _, err := repo.Create(writeTestProduct("C")); err != nil {
+			t.Fatalf("Create() after failure error = %v", err)
+		}
+
+		repo = reopenLog(t, repo, path)
+		if ids := fmt.Sprint(productIDs(t, repo)); ids != "[A C]" {
+			t.Errorf("expected [A C], got %s", ids)
+		}
+	})
+
+	t.Run("Falla al sincronizar", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "products.log")
+		repo, faulty := openFaultyLog(t, path)
+
+		faulty.set(func(f *faultyFile) { f.failSync = true })
+		if _, err := repo.Create(writeTestProduct("A")); err == nil {
+			t.Fatal("expected the injected sync failure")
+		}
+		if count := repo.GetProductCount(); count != 0 {
+			t.Errorf("expected no products, got %d", count)
+		}
+
+		repo = reopenLog(t, repo, path)
+		if count := repo.GetProductCount(); count != 0 {
+			t.Errorf("expected no products after reopening, got %d", count)
+		}
+	})
+
+	t.Run("Escritura parcial que no puede deshacerse", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "products.log")
+		repo, faulty := openFaultyLog(t, path)
+
+		if _, err := repo.Create(writeTestProduct("A")); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		faulty.set(func(f *faultyFile) { f.failWrite, f.writeLimit, f.failTruncate = true, 20, true })
+		if _, err := repo.Delete("A"); err == nil {
+			t.Fatal("expected the injected write failure")
+		}
+
+		// Con un registro incompleto al final no se aceptan más escrituras
+		faulty.set(func(f *faultyFile) { f.failWrite, f.failTruncate = false, false })
+		if _, err := repo.Create(writeTestProduct("B")); err == nil {
+			t.Error("expected writes to be rejected after an unrecoverable failure")
+		}
+		if _, err := repo.GetByID("A"); err != nil {
+			t.Error("reads should keep working after a failed write")
+		}
+
+		// Al volver a abrir se descarta el registro incompleto
+		repo = reopenLog(t, repo, path)
+		if repo.RecoveredBytes() != 20 {
+			t.Errorf("expected 20 recovered bytes, got %d", repo.RecoveredBytes())
+		}
+		if ids := fmt.Sprint(productIDs(t, repo)); ids != "[A]" {
+			t.Errorf("expected [A], got %s", ids)
+		}
+		if _, err := repo.Create(writeTestProduct("B")); err != nil {
+			t.Errorf("Create() after reopening error = %v", err)
+		}
+	})
+}
+
+func TestLogStoreCatalogRules(t *testing.T) {
+	t.Run("Importación validada con la taxonomía", func(t *testing.T) {
+		repo, err := logstore.Open(filepath.Join(t.TempDir(), "products.log"))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer repo.Close()
+		if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+			t.Fatalf("SetTaxonomy() error = %v", err)
+		}
+
+		outside := writeTestProduct("B")
+		outside.Category = "Tablets"
+		if err := repo.Import([]*domain.Product{writeTestProduct("A"), outside}, domain.ValidationLenient); err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+
+		report := repo.GetValidationReport()
+		if report.LoadedProducts != 1 || len(report.Rejected) != 1 || report.Rejected[0].Errors[0].Field != "products[1].category" {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if product, err := repo.GetByID("A"); err != nil || len(product.Breadcrumbs) != 3 {
+			t.Errorf("GetByID() = %+v, %v; want the taxonomy path", product, err)
+		}
+	})
+
+	t.Run("Esquemas de especificaciones", func(t *testing.T) {
+		repo := openLogTestRepository(t, `[]`)
+		if err := repo.SetSpecSchemas(testSpecSchemas()); err != nil {
+			t.Fatalf("SetSpecSchemas() error = %v", err)
+		}
+
+		var errs domain.ValidationErrors
+		if _, err := repo.Create(writeTestProduct("NEW")); !errors.As(err, &errs) {
+			t.Fatalf("Create() error = %v, want validation errors", err)
+		}
+
+		product := writeTestProduct("NEW")
+		product.Specifications = append(product.Specifications,
+			domain.Specification{Name: "Almacenamiento", Value: "256", Unit: "GB"},
+			domain.Specification{Name: "Garantía", Value: "1 año"},
+		)
+		created, err := repo.Create(product)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if created.Completeness == nil || created.Completeness.Present != 3 {
+			t.Errorf("Completeness = %+v, want 3 specifications present", created.Completeness)
+		}
+	})
+
+	t.Run("Reglas que no cumplen los productos guardados", func(t *testing.T) {
+		repo := openLogTestRepository(t, sqliteTestProducts)
+		if err := repo.SetTaxonomy(testTaxonomy()); err == nil {
+			t.Fatal("expected an error for products outside the taxonomy")
+		}
+
+		// Las reglas anteriores se conservan: las categorías siguen siendo planas
+		if tree := repo.GetCategoryTree(); len(tree) == 0 || len(tree[0].Children) != 0 {
+			t.Errorf("GetCategoryTree() = %+v, want flat categories", tree)
+		}
+		if _, err := repo.Create(writeTestProduct("NEW")); err != nil {
+			t.Errorf("Create() error = %v", err)
+		}
+	})
+}
+
+func TestLogStoreCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.log")
+
+	repo, err := logstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	for _, id := range []string{"A", "B", "C"} {
+		if _, err := repo.Create(writeTestProduct(id)); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		product := writeTestProduct("A")
+		product.Price = float64(100 + i)
+		if _, err := repo.Update(product); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+	if _, err := repo.Delete("B"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	before := fileSize(t, path)
+	if err := repo.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+
+	// Se conserva la eliminación de B, con su versión, y no cuenta como obsoleta
+	if stats := repo.Stats(); stats.Records != 3 || stats.LiveProducts != 2 || stats.StaleRecords != 0 || stats.SizeBytes != fileSize(t, path) {
+		t.Errorf("unexpected stats after compaction %+v", stats)
+	}
+	if after := fileSize(t, path); after >= before {
+		t.Errorf("expected the log to shrink, %d -> %d", before, after)
+	}
+
+	// Las escrituras siguientes van al registro compactado
+	if _, err := repo.Create(writeTestProduct("D")); err != nil {
+		t.Fatalf("Create() after compaction error = %v", err)
+	}
+
+	// Una compactación interrumpida deja un temporal que se descarta al abrir
+	if err := os.WriteFile(path+".compact", []byte("partial"), 0o644); err != nil {
+		t.Fatalf("Failed to write leftover compaction: %v", err)
+	}
+
+	repo = reopenLog(t, repo, path)
+	if ids := fmt.Sprint(productIDs(t, repo)); ids != "[A C D]" {
+		t.Errorf("expected [A C D] after compaction, got %s", ids)
+	}
+	if product, _ := repo.GetByID("A"); product.Price != 119 || product.Version != 21 {
+		t.Errorf("expected the last version of A, got %+v", product)
+	}
+	if _, err := os.Stat(path + ".compact"); !os.IsNotExist(err) {
+		t.Error("leftover compaction file should be removed")
+	}
+
+	// B se eliminó en la versión inicial antes de compactar: uno nuevo continúa su numeración
+	if created, err := repo.Create(writeTestProduct("B")); err != nil || created.Version != 2 {
+		t.Errorf("Create(B) after compaction = %+v, %v; want version 2", created, err)
+	}
+}
+
+func TestLogStoreConcurrentAccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.log")
+
+	repo, err := logstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer repo.Close()
+
+	if _, err := repo.Create(writeTestProduct("A")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Escrituras, lecturas, búsquedas y compactaciones en paralelo
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if _, err := repo.Create(writeTestProduct(fmt.Sprintf("P%d", i))); err != nil {
+				t.Errorf("Create() error = %v", err)
+			}
+			product := writeTestProduct("A")
+			product.Name = fmt.Sprintf("Pixel %d", i)
+			if _, err := repo.Update(product); err != nil {
+				t.Errorf("Update() error = %v", err)
+			}
+			repo.Search("pixel")
+			repo.GetAll(domain.ProductFilter{Brand: "google"})
+			if i%3 == 0 {
+				if err := repo.Compact(); err != nil {
+					t.Errorf("Compact() error = %v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if product, _ := repo.GetByID("A"); product.Version != 9 {
+		t.Errorf("expected 8 updates applied to A, got version %d", product.Version)
+	}
+
+	reopened := reopenLog(t, repo, path)
+	if count := reopened.GetProductCount(); count != 9 {
+		t.Errorf("expected 9 products after reopening, got %d", count)
+	}
+}
@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"fmt"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+// paginationProducts crea productos de prueba con precios repetidos para ejercitar desempates
+func paginationProducts() []*domain.Product {
+	var products []*domain.Product
+	for i := 1; i <= 7; i++ {
+		products = append(products, &domain.Product{
+			ID:     fmt.Sprintf("P%03d", i),
+			Name:   fmt.Sprintf("Product %d", 8-i),
+			Price:  float64(100 * (i % 3)),
+			Rating: float32(i) / 2,
+		})
+	}
+	return products
+}
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []domain.SortKey
+		wantErr bool
+	}{
+		{name: "Vacío", value: "", want: nil},
+		{name: "Prefijo descendente", value: "-rating,price", want: []domain.SortKey{{Field: "rating", Descending: true}, {Field: "price"}}},
+		{name: "Sufijo de dirección", value: "price:desc,name:asc", want: []domain.SortKey{{Field: "price", Descending: true}, {Field: "name"}}},
+		{name: "Campo inválido", value: "stock", wantErr: true},
+		{name: "Dirección inválida", value: "price:up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseSortKeys(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSortKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseSortKeys() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseSortKeys()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	keys := []domain.SortKey{{Field: "price", Descending: true}, {Field: "name"}}
+
+	t.Run("Paginación por número de página", func(t *testing.T) {
+		page, err := domain.Paginate(paginationProducts(), keys, domain.PageRequest{Page: 2, PageSize: 3})
+		if err != nil {
+			t.Fatalf("Paginate() error = %v, wantErr nil", err)
+		}
+
+		if page.TotalCount != 7 || page.TotalPages != 3 || page.Page != 2 || len(page.Items) != 3 {
+			t.Errorf("Paginate() info = %+v, items = %d", page.PageInfo, len(page.Items))
+		}
+		if page.NextCursor == "" {
+			t.Error("Paginate() expected next cursor for intermediate page")
+		}
+	})
+
+	t.Run("Página fuera de rango", func(t *testing.T) {
+		page, err := domain.Paginate(paginationProducts(), keys, domain.PageRequest{Page: 10, PageSize: 3})
+		if err != nil {
+			t.Fatalf("Paginate() error = %v, wantErr nil", err)
+		}
+
+		if len(page.Items) != 0 || page.NextCursor != "" {
+			t.Errorf("Paginate() out of range items = %d, cursor = %q", len(page.Items), page.NextCursor)
+		}
+	})
+
+	t.Run("Iteración completa por cursor", func(t *testing.T) {
+		sorted, _ := domain.Paginate(paginationProducts(), keys, domain.PageRequest{PageSize: domain.MaxPageSize})
+
+		var seen []string
+		request := domain.PageRequest{PageSize: 2}
+		for {
+			page, err := domain.Paginate(paginationProducts(), keys, request)
+			if err != nil {
+				t.Fatalf("Paginate() error = %v, wantErr nil", err)
+			}
+			for _, product := range page.Items {
+				seen = append(seen, product.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			request.Cursor = page.NextCursor
+		}
+
+		if len(seen) != len(sorted.Items) {
+			t.Fatalf("cursor iteration returned %d products, want %d", len(seen), len(sorted.Items))
+		}
+		for i, product := range sorted.Items {
+			if seen[i] != product.ID {
+				t.Errorf("cursor iteration[%d] = %s, want %s", i, seen[i], product.ID)
+			}
+		}
+	})
+
+	t.Run("Cursor con otro orden", func(t *testing.T) {
+		page, _ := domain.Paginate(paginationProducts(), keys, domain.PageRequest{PageSize: 2})
+
+		_, err := domain.Paginate(paginationProducts(), nil, domain.PageRequest{PageSize: 2, Cursor: page.NextCursor})
+		if _, ok := err.(*domain.ValidationError); !ok {
+			t.Errorf("Paginate() error type = %T, want *domain.ValidationError", err)
+		}
+	})
+
+	t.Run("Cursor malformado", func(t *testing.T) {
+		if err := domain.ValidateCursor("not-a-cursor"); err == nil {
+			t.Error("ValidateCursor() expected error for malformed cursor, got nil")
+		}
+	})
+}
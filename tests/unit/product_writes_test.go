@@ -0,0 +1,296 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"meli-products-api/domain"
+	productCommands "meli-products-api/internal/application/commands/product"
+	jsonRepo "meli-products-api/internal/repository/json"
+)
+
+// writeTestProduct devuelve un producto válido para los tests de escritura
+func writeTestProduct(id string) *domain.Product {
+	return &domain.Product{
+		ID:          id,
+		Name:        "Pixel 9",
+		ImageURL:    "https://example.com/pixel.jpg",
+		Description: "Smartphone Google",
+		Price:       799,
+		Rating:      4.4,
+		Category:    "Smartphones",
+		Brand:       "Google",
+		Specifications: []domain.Specification{
+			{Name: "RAM", Value: "12", Unit: "GB"},
+		},
+	}
+}
+
+// readStoredProducts lee el archivo de datos tal como quedó escrito
+func readStoredProducts(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read data file: %v", err)
+	}
+
+	var products []map[string]interface{}
+	if err := json.Unmarshal(data, &products); err != nil {
+		t.Fatalf("Data file is not valid JSON: %v", err)
+	}
+
+	return products
+}
+
+func TestRepositoryWrites(t *testing.T) {
+	path := createTestFile(t, reloadTestProducts)
+
+	repo, err := jsonRepo.NewProductRepository(path)
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+	if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+		t.Fatalf("SetTaxonomy() error = %v", err)
+	}
+
+	t.Run("Create agrega y persiste el producto", func(t *testing.T) {
+		created, err := repo.Create(writeTestProduct("C"))
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if len(created.Breadcrumbs) != 3 || created.Specifications[0].Typed.Kind != domain.SpecKindNumber {
+			t.Errorf("expected derived fields on the created product, got %+v", created)
+		}
+
+		if product, err := repo.GetByID("C"); err != nil || product.Name != "Pixel 9" {
+			t.Errorf("GetByID() = %+v, %v", product, err)
+		}
+
+		stored := readStoredProducts(t, path)
+		if len(stored) != 3 || stored[2]["id"] != "C" {
+			t.Fatalf("expected C appended to the data file, got %v", stored)
+		}
+		if _, ok := stored[2]["breadcrumbs"]; ok {
+			t.Error("derived breadcrumbs should not be persisted")
+		}
+		spec := stored[2]["specifications"].([]interface{})[0].(map[string]interface{})
+		if _, ok := spec["typed"]; ok {
+			t.Error("typed specification values should not be persisted")
+		}
+	})
+
+	t.Run("Create rechaza IDs duplicados", func(t *testing.T) {
+		_, err := repo.Create(writeTestProduct("A"))
+
+		var exists *domain.ProductAlreadyExistsError
+		if !errors.As(err, &exists) {
+			t.Errorf("expected ProductAlreadyExistsError, got %v", err)
+		}
+	})
+
+	t.Run("Create valida etiquetas y taxonomía", func(t *testing.T) {
+		product := writeTestProduct("D")
+		product.ImageURL = "not-a-url"
+		product.Price = 0
+
+		_, err := repo.Create(product)
+		var errs domain.ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 2 {
+			t.Fatalf("expected two validation errors, got %v", err)
+		}
+		if errs[0].Field != "image_url" || errs[1].Field != "price" {
+			t.Errorf("unexpected fields %s, %s", errs[0].Field, errs[1].Field)
+		}
+
+		product = writeTestProduct("D")
+		product.Category = "Tablets"
+		_, err = repo.Create(product)
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "category" {
+			t.Fatalf("expected a category error relative to the product, got %v", err)
+		}
+
+		if _, err := repo.GetByID("D"); err == nil {
+			t.Error("rejected product should not be in the catalog")
+		}
+		if len(readStoredProducts(t, path)) != 3 {
+			t.Error("rejected product should not be persisted")
+		}
+	})
+
+	t.Run("Update reemplaza el producto", func(t *testing.T) {
+		product := writeTestProduct("C")
+		product.Price = 699
+
+		updated, err := repo.Update(product)
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if updated.Price != 699 {
+			t.Errorf("expected price 699, got %v", updated.Price)
+		}
+		if stored := readStoredProducts(t, path); stored[2]["price"] != 699.0 {
+			t.Errorf("expected persisted price 699, got %v", stored[2]["price"])
+		}
+
+		var notFound *domain.ProductNotFoundError
+		if _, err := repo.Update(writeTestProduct("Z")); !errors.As(err, &notFound) {
+			t.Errorf("expected ProductNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("Delete elimina el producto", func(t *testing.T) {
+		deleted, err := repo.Delete("C")
+		if err != nil || deleted.ID != "C" {
+			t.Fatalf("Delete() = %+v, %v", deleted, err)
+		}
+		if _, err := repo.GetByID("C"); err == nil {
+			t.Error("deleted product should not be found")
+		}
+		if len(readStoredProducts(t, path)) != 2 {
+			t.Error("deleted product should be removed from the data file")
+		}
+
+		var notFound *domain.ProductNotFoundError
+		if _, err := repo.Delete("C"); !errors.As(err, &notFound) {
+			t.Errorf("expected ProductNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("La recarga lee lo escrito", func(t *testing.T) {
+		if _, err := repo.Create(writeTestProduct("E")); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := repo.Reload(); err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		if product, err := repo.GetByID("E"); err != nil || len(product.Breadcrumbs) != 3 {
+			t.Errorf("GetByID() after reload = %+v, %v", product, err)
+		}
+	})
+}
+
+func TestRepositoryWriteFailureKeepsCatalog(t *testing.T) {
+	path := createTestFile(t, reloadTestProducts)
+
+	repo, err := jsonRepo.NewProductRepository(path)
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	// Sin el directorio no se puede crear el archivo temporal
+	if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+		t.Fatalf("Failed to remove data directory: %v", err)
+	}
+
+	if _, err := repo.Create(writeTestProduct("C")); err == nil || !strings.Contains(err.Error(), "temporary") {
+		t.Fatalf("expected a persistence error, got %v", err)
+	}
+	if _, err := repo.GetByID("C"); err == nil {
+		t.Error("catalog should not change when the write fails")
+	}
+	if _, err := repo.Delete("A"); err == nil {
+		t.Error("expected a persistence error on delete")
+	}
+	if _, err := repo.GetByID("A"); err != nil {
+		t.Error("catalog should keep A when the delete fails")
+	}
+}
+
+func TestPatchProductCommandApply(t *testing.T) {
+	price := 599.0
+	specs := []productCommands.SpecificationInput{{Name: "RAM", Value: "8", Unit: "GB"}, {Name: "NFC", Value: "Sí"}}
+	command := &productCommands.PatchProductCommand{ID: "C", Price: &price, Specifications: &specs}
+
+	current := writeTestProduct("C")
+	patched := command.Apply(current)
+
+	if patched.Price != 599 || patched.Name != current.Name || len(patched.Specifications) != 2 {
+		t.Errorf("unexpected patched product %+v", patched)
+	}
+	if current.Price != 799 {
+		t.Error("Apply should not modify the current product")
+	}
+}
+
+func TestRepositoryUpdateVersions(t *testing.T) {
+	path := createTestFile(t, reloadTestProducts)
+
+	repo, err := jsonRepo.NewProductRepository(path)
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	if product, _ := repo.GetByID("A"); product.Version != domain.InitialProductVersion {
+		t.Fatalf("expected products loaded without version at version 1, got %d", product.Version)
+	}
+
+	created, err := repo.Create(writeTestProduct("C"))
+	if err != nil || created.Version != 1 {
+		t.Fatalf("Create() = %+v, %v", created, err)
+	}
+
+	// Una actualización sobre la versión actual la incrementa
+	product := writeTestProduct("C")
+	product.Version = 1
+	updated, err := repo.Update(product)
+	if err != nil || updated.Version != 2 {
+		t.Fatalf("Update() = %+v, %v", updated, err)
+	}
+
+	// Otra actualización sobre la versión 1 ya no es válida
+	product.Price = 1
+	_, err = repo.Update(product)
+	var conflict *domain.ProductVersionConflictError
+	if !errors.As(err, &conflict) || conflict.Expected != 1 || conflict.Current != 2 {
+		t.Fatalf("expected a version conflict, got %v", err)
+	}
+	if current, _ := repo.GetByID("C"); current.Price != 799 {
+		t.Error("a conflicting update should not change the product")
+	}
+
+	// Sin versión la actualización es incondicional
+	product.Version = 0
+	if updated, err := repo.Update(product); err != nil || updated.Version != 3 {
+		t.Fatalf("Update() = %+v, %v", updated, err)
+	}
+
+	// La versión se persiste y sobrevive a la recarga
+	if err := repo.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if current, _ := repo.GetByID("C"); current.Version != 3 {
+		t.Errorf("expected version 3 after reload, got %d", current.Version)
+	}
+}
+
+func TestParseProductETag(t *testing.T) {
+	if etag := domain.ProductETag(12); etag != `"12"` {
+		t.Errorf(`ProductETag(12) = %s, want "12"`, etag)
+	}
+
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{`"3"`, 3, false},
+		{` "3" `, 3, false},
+		{`3`, 0, true},
+		{`W/"3"`, 0, true},
+		{`"0"`, 0, true},
+		{`"x"`, 0, true},
+		{`"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := domain.ParseProductETag(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseProductETag(%q) = %d, %v; want %d, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
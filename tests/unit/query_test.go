@@ -0,0 +1,165 @@
+package unit
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func queryTestProducts() []*domain.Product {
+	return []*domain.Product{
+		{
+			ID: "S24", Name: "Samsung Galaxy S24 Ultra", Brand: "Samsung", Category: "Smartphones",
+			Description: "Incluye S Pen integrado", Price: 1299.99, Rating: 4.7, Available: true,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "12", Unit: "GB"}},
+		},
+		{
+			ID: "A54", Name: "Samsung Galaxy A54", Brand: "Samsung", Category: "Smartphones",
+			Description: "Gama media", Price: 449.99, Rating: 4.3, Available: true,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "8", Unit: "GB"}},
+		},
+		{
+			ID: "IPHONE", Name: "iPhone 15 Pro", Brand: "Apple", Category: "Smartphones",
+			Description: "Titanio", Price: 999.99, Rating: 4.8, Available: false,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "8", Unit: "GB"}},
+		},
+		{
+			ID: "XPS", Name: "Dell XPS 15", Brand: "Dell", Category: "Laptops",
+			Description: "Laptop de alto rendimiento", Price: 2299.99, Rating: 4.3, Available: true,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "16", Unit: "GB"}},
+		},
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	products := queryTestProducts()
+
+	tests := []struct {
+		name     string
+		query    string
+		wantText string
+		wantIDs  []string
+	}{
+		{name: "Solo texto libre", query: "Samsung Galaxy", wantText: "Samsung Galaxy", wantIDs: []string{"S24", "A54", "IPHONE", "XPS"}},
+		{name: "Términos de campo y especificación", query: `brand:Samsung category:smartphones price<1000 ram>=8`, wantIDs: []string{"A54"}},
+		{name: "Frase entre comillas", query: `galaxy "S Pen"`, wantText: "galaxy S Pen", wantIDs: []string{"S24"}},
+		{name: "Negación con guion", query: "category:Smartphones -brand:Samsung", wantIDs: []string{"IPHONE"}},
+		{name: "Negación con NOT", query: "NOT available:true", wantIDs: []string{"IPHONE"}},
+		{name: "Grupo OR", query: "(brand:Apple OR brand:Dell) rating>4.5", wantIDs: []string{"IPHONE"}},
+		{name: "OR en el nivel superior", query: "iphone OR xps", wantIDs: []string{"IPHONE", "XPS"}},
+		{name: "Campo y valor entre comillas", query: `name:"galaxy s24" "RAM"=12GB`, wantIDs: []string{"S24"}},
+		{name: "Dos puntos contiene en el nombre", query: "name:galaxy", wantIDs: []string{"S24", "A54"}},
+		{name: "AND explícito", query: "brand:Samsung AND rating>=4.5", wantIDs: []string{"S24"}},
+		{name: "Signo que no forma un operador", query: "galaxy!", wantText: "galaxy!", wantIDs: []string{"S24", "A54", "IPHONE", "XPS"}},
+		{name: "Especificación con prefijo", query: "spec.RAM>=12 SPEC.ram<16", wantIDs: []string{"S24"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := domain.ParseSearchQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseSearchQuery(%q) error = %v", tt.query, err)
+			}
+			if plan.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", plan.Text, tt.wantText)
+			}
+
+			filter := plan.Filter()
+			var got []string
+			for _, product := range products {
+				if filter.Matches(product) {
+					got = append(got, product.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("matched %v, want %v (condition %v)", got, tt.wantIDs, plan.Condition)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuerySyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantPosition int
+	}{
+		{name: "Comillas sin cerrar", query: `brand:Samsung "S Pen`, wantPosition: 15},
+		{name: "Paréntesis sin cerrar", query: "ram>=8 (brand:Apple OR brand:Dell", wantPosition: 8},
+		{name: "Paréntesis de cierre de más", query: "brand:Apple)", wantPosition: 12},
+		{name: "Grupo vacío", query: "galaxy ()", wantPosition: 8},
+		{name: "OR sin término", query: "brand:Apple OR", wantPosition: 13},
+		{name: "Negación sin término", query: "galaxy NOT", wantPosition: 8},
+		{name: "Campo sin valor", query: "price<", wantPosition: 7},
+		{name: "Operador sin campo", query: ">=8", wantPosition: 1},
+		{name: "Número inválido", query: "galaxy price<barato", wantPosition: 8},
+		{name: "Operador no soportado por el campo", query: "available>true", wantPosition: 1},
+		{name: "Prefijo de especificación sin nombre", query: "galaxy spec.=8", wantPosition: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.ParseSearchQuery(tt.query)
+
+			var syntaxErr *domain.QuerySyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseSearchQuery(%q) error = %v, want QuerySyntaxError", tt.query, err)
+			}
+			if syntaxErr.Position != tt.wantPosition {
+				t.Errorf("Position = %d, want %d (%v)", syntaxErr.Position, tt.wantPosition, err)
+			}
+		})
+	}
+}
+
+// testSpecCatalog es un catálogo con las especificaciones indicadas, en minúsculas
+type testSpecCatalog map[string]bool
+
+func (c testSpecCatalog) HasSpecification(name string) (bool, error) {
+	return c[strings.ToLower(name)], nil
+}
+
+func TestSearchPlanCheckSpecNames(t *testing.T) {
+	catalog := testSpecCatalog{"ram": true, "sistema operativo": true}
+
+	tests := []struct {
+		name         string
+		query        string
+		wantPosition int
+	}{
+		{name: "Campos del producto", query: "brand:Samsung price<1000", wantPosition: 0},
+		{name: "Especificación conocida", query: `RAM>=8 "Sistema Operativo"~Android`, wantPosition: 0},
+		{name: "Especificación desconocida con prefijo", query: "spec.Color:rojo", wantPosition: 0},
+		{name: "Campo mal escrito", query: "galaxy brnad:Samsung", wantPosition: 8},
+		{name: "Campo mal escrito negado en un grupo", query: "(brand:Apple OR -categroy:Laptops)", wantPosition: 18},
+		{name: "Especificación desconocida sin prefijo", query: "ram>=8 color:rojo", wantPosition: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := domain.ParseSearchQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseSearchQuery(%q) error = %v", tt.query, err)
+			}
+
+			err = plan.CheckSpecNames(catalog)
+			if tt.wantPosition == 0 {
+				if err != nil {
+					t.Errorf("CheckSpecNames() error = %v", err)
+				}
+				return
+			}
+
+			var syntaxErr *domain.QuerySyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("CheckSpecNames() error = %v, want QuerySyntaxError", err)
+			}
+			if syntaxErr.Position != tt.wantPosition {
+				t.Errorf("Position = %d, want %d (%v)", syntaxErr.Position, tt.wantPosition, err)
+			}
+		})
+	}
+}
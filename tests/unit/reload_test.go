@@ -0,0 +1,134 @@
+package unit
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"meli-products-api/domain"
+	jsonRepo "meli-products-api/internal/repository/json"
+)
+
+// writeTestFile reemplaza el contenido de un archivo de pruebas
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+}
+
+const reloadTestProducts = `[
+	{"id": "A", "name": "Galaxy", "image_url": "https://example.com/a.jpg", "description": "Smartphone Samsung",
+	 "price": 900, "rating": 4.5, "category": "Smartphones", "brand": "Samsung"},
+	{"id": "B", "name": "WH-1000XM5", "image_url": "https://example.com/b.jpg", "description": "Audífonos Sony",
+	 "price": 350, "rating": 4.7, "category": "Audífonos", "brand": "Sony"}
+]`
+
+func TestRepositoryReload(t *testing.T) {
+	path := createTestFile(t, reloadTestProducts)
+
+	repo, err := jsonRepo.NewProductRepository(path)
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+	if err := repo.SetValidationMode(domain.ValidationStrict); err != nil {
+		t.Fatalf("SetValidationMode() error = %v", err)
+	}
+	if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+		t.Fatalf("SetTaxonomy() error = %v", err)
+	}
+	repo.SetSynonyms(&domain.SynonymSet{Rules: []domain.SynonymRule{{Equivalent: []string{"celular", "smartphone"}}}})
+
+	t.Run("Recarga un archivo válido conservando la configuración", func(t *testing.T) {
+		writeTestFile(t, path, `[
+			{"id": "A", "name": "Galaxy", "image_url": "https://example.com/a.jpg", "description": "Smartphone Samsung",
+			 "price": 799, "rating": 4.5, "category": "Smartphones", "brand": "Samsung"},
+			{"id": "C", "name": "Pixel", "image_url": "https://example.com/c.jpg", "description": "Smartphone Google",
+			 "price": 699, "rating": 4.4, "category": "smartphones", "brand": "Google"}
+		]`)
+
+		if err := repo.Reload(); err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+
+		if product, _ := repo.GetByID("A"); product.Price != 799 || len(product.Breadcrumbs) != 3 {
+			t.Errorf("expected reloaded product with breadcrumbs, got %+v", product)
+		}
+		if _, err := repo.GetByID("B"); err == nil {
+			t.Error("removed product should not be found after reload")
+		}
+
+		products, _ := repo.GetAll(domain.ProductFilter{Category: "telefonia"})
+		if len(products) != 2 {
+			t.Errorf("expected 2 products in the taxonomy subtree, got %d", len(products))
+		}
+
+		hits, _ := repo.Search("celular")
+		if len(hits) != 2 {
+			t.Errorf("expected synonyms to apply after reload, got %d hits", len(hits))
+		}
+	})
+
+	t.Run("Conserva el catálogo si el archivo es inválido", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"JSON inválido":         `[{"id": "A",`,
+			"producto inválido":     `[{"id": "A", "name": "Galaxy", "image_url": "https://example.com/a.jpg", "description": "x", "price": -1, "rating": 4, "category": "Smartphones"}]`,
+			"categoría desconocida": `[{"id": "A", "name": "Galaxy", "image_url": "https://example.com/a.jpg", "description": "x", "price": 1, "rating": 4, "category": "Consolas"}]`,
+		} {
+			writeTestFile(t, path, content)
+
+			if err := repo.Reload(); err == nil {
+				t.Errorf("%s: expected Reload() error", name)
+			}
+			if repo.GetProductCount() != 2 {
+				t.Errorf("%s: previous catalog should be kept, got %d products", name, repo.GetProductCount())
+			}
+		}
+	})
+}
+
+func TestRepositoryReloadConcurrentReads(t *testing.T) {
+	path := createTestFile(t, reloadTestProducts)
+
+	repo, err := jsonRepo.NewProductRepository(path)
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// Las lecturas concurrentes siempre ven un catálogo completo: los dos productos
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				products, _ := repo.GetAll(domain.ProductFilter{})
+				if len(products) != 2 {
+					t.Errorf("expected a complete catalog, got %d products", len(products))
+					return
+				}
+				if hits, _ := repo.Search("sony"); len(hits) != 1 {
+					t.Errorf("expected 1 search hit, got %d", len(hits))
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		if err := repo.Reload(); err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"meli-products-api/domain"
+	jsonRepo "meli-products-api/internal/repository/json"
+)
+
+// catalogSizes son los tamaños de catálogo utilizados en los benchmarks. Con índices, el
+// costo por operación debe mantenerse prácticamente constante al crecer el catálogo.
+var catalogSizes = []int{1000, 10000, 50000}
+
+var (
+	benchCategories = []string{"Smartphones", "Laptops", "Audífonos", "Tablets", "Monitores", "Cámaras", "Consolas", "Relojes"}
+	benchBrands     = []string{"Samsung", "Apple", "Google", "Sony", "Dell", "Lenovo", "Xiaomi", "Motorola", "LG", "HP"}
+	benchWords      = []string{"pro", "max", "ultra", "lite", "plus", "mini", "air", "neo", "edge", "prime"}
+)
+
+// generateProducts genera un catálogo sintético y determinista de n productos
+func generateProducts(n int) []*domain.Product {
+	products := make([]*domain.Product, n)
+
+	for i := 0; i < n; i++ {
+		brand := benchBrands[i%len(benchBrands)]
+		products[i] = &domain.Product{
+			ID:          fmt.Sprintf("GEN%06d", i),
+			Name:        fmt.Sprintf("%s Modelo%d %s", brand, i, benchWords[(i/7)%len(benchWords)]),
+			ImageURL:    "https://example.com/product.jpg",
+			Description: fmt.Sprintf("Producto %s de la línea %s", benchWords[i%len(benchWords)], benchWords[(i/3)%len(benchWords)]),
+			Price:       float64(100 + (i*7919)%5000),
+			Rating:      float32(1 + (i%40)/10.0),
+			Category:    benchCategories[(i/len(benchBrands))%len(benchCategories)],
+			Brand:       brand,
+			Available:   i%5 != 0,
+			Specifications: []domain.Specification{
+				{Name: "RAM", Value: fmt.Sprintf("%d", 4*(1+i%4)), Unit: "GB"},
+			},
+		}
+	}
+
+	return products
+}
+
+// writeProductsFile serializa los productos en un archivo temporal
+func writeProductsFile(tb testing.TB, products []*domain.Product) string {
+	tb.Helper()
+
+	bytes, err := json.Marshal(products)
+	if err != nil {
+		tb.Fatalf("Failed to marshal products: %v", err)
+	}
+
+	filePath := filepath.Join(tb.TempDir(), "products.json")
+	if err := os.WriteFile(filePath, bytes, 0644); err != nil {
+		tb.Fatalf("Failed to write products file: %v", err)
+	}
+
+	return filePath
+}
+
+// newBenchRepository crea un repositorio con un catálogo sintético de n productos
+func newBenchRepository(b *testing.B, n int) *jsonRepo.ProductRepository {
+	b.Helper()
+
+	repo, err := jsonRepo.NewProductRepository(writeProductsFile(b, generateProducts(n)))
+	if err != nil {
+		b.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	return repo
+}
+
+func BenchmarkRepositoryGetByID(b *testing.B) {
+	for _, size := range catalogSizes {
+		b.Run(fmt.Sprintf("products=%d", size), func(b *testing.B) {
+			repo := newBenchRepository(b, size)
+			id := fmt.Sprintf("GEN%06d", size-1)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetByID(id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRepositoryGetByIDs(b *testing.B) {
+	for _, size := range catalogSizes {
+		b.Run(fmt.Sprintf("products=%d", size), func(b *testing.B) {
+			repo := newBenchRepository(b, size)
+			ids := make([]string, 10)
+			for i := range ids {
+				ids[i] = fmt.Sprintf("GEN%06d", size-1-i*(size/10))
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetByIDs(ids); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRepositoryGetAllByCategoryAndBrand(b *testing.B) {
+	for _, size := range catalogSizes {
+		b.Run(fmt.Sprintf("products=%d", size), func(b *testing.B) {
+			repo := newBenchRepository(b, size)
+			filter := domain.ProductFilter{Category: "smartphones", Brand: "samsung"}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetAll(filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRepositoryGetAllByPriceRange(b *testing.B) {
+	for _, size := range catalogSizes {
+		b.Run(fmt.Sprintf("products=%d", size), func(b *testing.B) {
+			repo := newBenchRepository(b, size)
+			// Rango angosto: la cantidad de resultados crece con el catálogo, no el costo de encontrarlos
+			filter := domain.ProductFilter{MinPrice: 1000, MaxPrice: 1000}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetAll(filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRepositorySearch(b *testing.B) {
+	for _, size := range catalogSizes {
+		b.Run(fmt.Sprintf("products=%d", size), func(b *testing.B) {
+			repo := newBenchRepository(b, size)
+			query := fmt.Sprintf("modelo%d", size/2)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.Search(query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestRepositoryIndexesMatchLinearScan verifica que las consultas apoyadas en índices
+// devuelven exactamente lo mismo que evaluar el filtro sobre todo el catálogo
+func TestRepositoryIndexesMatchLinearScan(t *testing.T) {
+	products := generateProducts(500)
+	repo, err := jsonRepo.NewProductRepository(writeProductsFile(t, products))
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	filters := []domain.ProductFilter{
+		{Category: "SMARTPHONES"},
+		{Brand: "apple"},
+		{Category: "Laptops", Brand: "Dell"},
+		{MinPrice: 1000, MaxPrice: 2000},
+		{MinPrice: 4000},
+		{MaxPrice: 500, Brand: "Sony"},
+		{Category: "Tablets", MinPrice: 2500, MaxPrice: 2600},
+		{Category: "Inexistente"},
+		{MinPrice: 3000, MaxPrice: 1000},
+	}
+
+	for _, filter := range filters {
+		t.Run(fmt.Sprintf("%+v", filter), func(t *testing.T) {
+			var want []string
+			for _, product := range products {
+				if filter.Matches(product) {
+					want = append(want, product.ID)
+				}
+			}
+
+			got, err := repo.GetAll(filter)
+			if err != nil {
+				t.Fatalf("GetAll() error = %v", err)
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("GetAll() returned %d products, want %d", len(got), len(want))
+			}
+			for i := range got {
+				if got[i].ID != want[i] {
+					t.Fatalf("GetAll()[%d] = %s, want %s", i, got[i].ID, want[i])
+				}
+			}
+		})
+	}
+}
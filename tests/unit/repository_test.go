@@ -1,12 +1,16 @@
 package unit
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"meli-products-api/domain"
 	jsonRepo "meli-products-api/internal/repository/json"
+	"meli-products-api/internal/repository/logstore"
+	sqliteRepo "meli-products-api/internal/repository/sqlite"
+	"meli-products-api/pkg/repotest"
 )
 
 // createTestFile crea un archivo JSON temporal para las pruebas
@@ -24,6 +28,133 @@ func createTestFile(t *testing.T, content string) string {
 	return filePath
 }
 
+// repositoryBackend crea un repositorio con los productos del JSON indicado
+type repositoryBackend struct {
+	name string
+	open func(t *testing.T, content string) domain.ProductRepository
+}
+
+// repositoryBackends son las implementaciones del repositorio sobre las que corren los tests
+// de lectura compartidos
+var repositoryBackends = []repositoryBackend{
+	{name: "json", open: func(t *testing.T, content string) domain.ProductRepository {
+		t.Helper()
+
+		repo, err := jsonRepo.NewProductRepository(createTestFile(t, content))
+		if err != nil {
+			t.Fatalf("Failed to create repository: %v", err)
+		}
+		return repo
+	}},
+	{name: "sqlite", open: func(t *testing.T, content string) domain.ProductRepository {
+		t.Helper()
+
+		return openSQLiteTestRepository(t, content)
+	}},
+	{name: "log", open: func(t *testing.T, content string) domain.ProductRepository {
+		t.Helper()
+
+		return openLogTestRepository(t, content)
+	}},
+}
+
+// openSQLiteTestRepository crea una base SQLite temporal con los productos del JSON indicado
+func openSQLiteTestRepository(t *testing.T, content string) *sqliteRepo.ProductRepository {
+	t.Helper()
+
+	products, err := jsonRepo.LoadProducts(createTestFile(t, content))
+	if err != nil {
+		t.Fatalf("Failed to load products: %v", err)
+	}
+
+	repo, err := sqliteRepo.Open(filepath.Join(t.TempDir(), "products.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	if err := repo.Import(products, domain.ValidationDisabled); err != nil {
+		t.Fatalf("Failed to import products: %v", err)
+	}
+
+	return repo
+}
+
+// openLogTestRepository crea un registro de productos temporal con los productos del JSON indicado
+func openLogTestRepository(t *testing.T, content string) *logstore.ProductRepository {
+	t.Helper()
+
+	products, err := jsonRepo.LoadProducts(createTestFile(t, content))
+	if err != nil {
+		t.Fatalf("Failed to load products: %v", err)
+	}
+
+	repo, err := logstore.Open(filepath.Join(t.TempDir(), "products.log"))
+	if err != nil {
+		t.Fatalf("Failed to open product log: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	if err := repo.Import(products, domain.ValidationDisabled); err != nil {
+		t.Fatalf("Failed to import products: %v", err)
+	}
+
+	return repo
+}
+
+// forEachBackend ejecuta el test sobre un repositorio de cada implementación
+func forEachBackend(t *testing.T, content string, test func(t *testing.T, repo domain.ProductRepository)) {
+	for _, backend := range repositoryBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, backend.open(t, content))
+		})
+	}
+}
+
+func TestRepositoryConformance(t *testing.T) {
+	for _, backend := range repositoryBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			repotest.Run(t, func(t *testing.T, products []*domain.Product) domain.ProductRepository {
+				t.Helper()
+
+				content, err := json.Marshal(products)
+				if err != nil {
+					t.Fatalf("Failed to encode products: %v", err)
+				}
+				return backend.open(t, string(content))
+			})
+		})
+	}
+}
+
+// TestRepositoryConformanceWithTaxonomy ejecuta la suite sobre repositorios con la taxonomía
+// de la suite cargada: las migas de pan y la validación de categorías no deben cambiar el
+// resto del contrato
+func TestRepositoryConformanceWithTaxonomy(t *testing.T) {
+	for _, backend := range repositoryBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			repotest.Run(t, func(t *testing.T, products []*domain.Product) domain.ProductRepository {
+				t.Helper()
+
+				content, err := json.Marshal(products)
+				if err != nil {
+					t.Fatalf("Failed to encode products: %v", err)
+				}
+				repo := backend.open(t, string(content))
+
+				organized, ok := repo.(interface{ SetTaxonomy(*domain.Taxonomy) error })
+				if !ok {
+					t.Fatalf("%s repository does not support a taxonomy", backend.name)
+				}
+				if err := organized.SetTaxonomy(repotest.Taxonomy()); err != nil {
+					t.Fatalf("SetTaxonomy() error = %v", err)
+				}
+				return repo
+			})
+		})
+	}
+}
+
 func TestNewProductRepository(t *testing.T) {
 	testData := `[
 		{
@@ -100,47 +231,43 @@ func TestRepositoryGetByID(t *testing.T) {
 		}
 	]`
 
-	filePath := createTestFile(t, testData)
-	repo, err := jsonRepo.NewProductRepository(filePath)
-	if err != nil {
-		t.Fatalf("Failed to create repository: %v", err)
-	}
-
-	t.Run("Obtener producto existente", func(t *testing.T) {
-		product, err := repo.GetByID("TEST001")
-		if err != nil {
-			t.Errorf("GetByID() error = %v, wantErr nil", err)
-			return
-		}
+	forEachBackend(t, testData, func(t *testing.T, repo domain.ProductRepository) {
+		t.Run("Obtener producto existente", func(t *testing.T) {
+			product, err := repo.GetByID("TEST001")
+			if err != nil {
+				t.Errorf("GetByID() error = %v, wantErr nil", err)
+				return
+			}
 		
-		if product.ID != "TEST001" {
-			t.Errorf("GetByID() ID = %v, want TEST001", product.ID)
-		}
-		if product.Name != "Test Product 1" {
-			t.Errorf("GetByID() Name = %v, want Test Product 1", product.Name)
-		}
-	})
+			if product.ID != "TEST001" {
+				t.Errorf("GetByID() ID = %v, want TEST001", product.ID)
+			}
+			if product.Name != "Test Product 1" {
+				t.Errorf("GetByID() Name = %v, want Test Product 1", product.Name)
+			}
+		})
 
-	t.Run("Producto no encontrado", func(t *testing.T) {
-		_, err := repo.GetByID("NONEXISTENT")
-		if err == nil {
-			t.Error("GetByID() expected error for nonexistent product, got nil")
-		}
+		t.Run("Producto no encontrado", func(t *testing.T) {
+			_, err := repo.GetByID("NONEXISTENT")
+			if err == nil {
+				t.Error("GetByID() expected error for nonexistent product, got nil")
+			}
 		
-		if _, ok := err.(*domain.ProductNotFoundError); !ok {
-			t.Errorf("GetByID() error type = %T, want *domain.ProductNotFoundError", err)
-		}
-	})
+			if _, ok := err.(*domain.ProductNotFoundError); !ok {
+				t.Errorf("GetByID() error type = %T, want *domain.ProductNotFoundError", err)
+			}
+		})
 
-	t.Run("ID vacío", func(t *testing.T) {
-		_, err := repo.GetByID("")
-		if err == nil {
-			t.Error("GetByID() expected error for empty ID, got nil")
-		}
+		t.Run("ID vacío", func(t *testing.T) {
+			_, err := repo.GetByID("")
+			if err == nil {
+				t.Error("GetByID() expected error for empty ID, got nil")
+			}
 		
-		if _, ok := err.(*domain.InvalidProductIDError); !ok {
-			t.Errorf("GetByID() error type = %T, want *domain.InvalidProductIDError", err)
-		}
+			if _, ok := err.(*domain.InvalidProductIDError); !ok {
+				t.Errorf("GetByID() error type = %T, want *domain.InvalidProductIDError", err)
+			}
+		})
 	})
 }
 
@@ -172,62 +299,139 @@ func TestRepositorySearch(t *testing.T) {
 		}
 	]`
 
-	filePath := createTestFile(t, testData)
-	repo, err := jsonRepo.NewProductRepository(filePath)
-	if err != nil {
-		t.Fatalf("Failed to create repository: %v", err)
-	}
+	forEachBackend(t, testData, func(t *testing.T, repo domain.ProductRepository) {
+		t.Run("Buscar por nombre", func(t *testing.T) {
+			products, err := repo.Search("Samsung")
+			if err != nil {
+				t.Errorf("Search() error = %v, wantErr nil", err)
+				return
+			}
+		
+			if len(products) != 1 {
+				t.Errorf("Search() count = %v, want 1", len(products))
+			}
+		
+			if products[0].Brand != "Samsung" {
+				t.Errorf("Search() result brand = %v, want Samsung", products[0].Brand)
+			}
+		})
 
-	t.Run("Buscar por nombre", func(t *testing.T) {
-		products, err := repo.Search("Samsung")
-		if err != nil {
-			t.Errorf("Search() error = %v, wantErr nil", err)
-			return
-		}
+		t.Run("Buscar por descripción", func(t *testing.T) {
+			products, err := repo.Search("camera")
+			if err != nil {
+				t.Errorf("Search() error = %v, wantErr nil", err)
+				return
+			}
 		
-		if len(products) != 1 {
-			t.Errorf("Search() count = %v, want 1", len(products))
-		}
+			if len(products) != 1 {
+				t.Errorf("Search() by description count = %v, want 1", len(products))
+			}
+		})
+
+		t.Run("Búsqueda sin resultados", func(t *testing.T) {
+			products, err := repo.Search("NonExistent")
+			if err != nil {
+				t.Errorf("Search() error = %v, wantErr nil", err)
+				return
+			}
 		
-		if products[0].Brand != "Samsung" {
-			t.Errorf("Search() result brand = %v, want Samsung", products[0].Brand)
-		}
-	})
+			if len(products) != 0 {
+				t.Errorf("Search() no results count = %v, want 0", len(products))
+			}
+		})
 
-	t.Run("Buscar por descripción", func(t *testing.T) {
-		products, err := repo.Search("camera")
-		if err != nil {
-			t.Errorf("Search() error = %v, wantErr nil", err)
-			return
-		}
+		t.Run("Búsqueda vacía", func(t *testing.T) {
+			products, err := repo.Search("")
+			if err != nil {
+				t.Errorf("Search() error = %v, wantErr nil", err)
+				return
+			}
 		
-		if len(products) != 1 {
-			t.Errorf("Search() by description count = %v, want 1", len(products))
-		}
+			// Búsqueda vacía debe devolver todos los productos
+			if len(products) != 2 {
+				t.Errorf("Search() empty query count = %v, want 2", len(products))
+			}
+		})
 	})
+}
+func TestLoadComparisonRules(t *testing.T) {
+	t.Run("Cargar reglas válidas", func(t *testing.T) {
+		filePath := createTestFile(t, `{
+			"fields": [{"name": "price", "direction": "lower", "type": "numeric"}],
+			"specifications": [{"name": "RAM", "direction": "higher", "type": "numeric"}]
+		}`)
 
-	t.Run("Búsqueda sin resultados", func(t *testing.T) {
-		products, err := repo.Search("NonExistent")
+		rules, err := jsonRepo.LoadComparisonRules(filePath)
 		if err != nil {
-			t.Errorf("Search() error = %v, wantErr nil", err)
-			return
+			t.Fatalf("LoadComparisonRules() error = %v, wantErr nil", err)
 		}
-		
-		if len(products) != 0 {
-			t.Errorf("Search() no results count = %v, want 0", len(products))
+
+		if rules.SpecRule("Smartphones", "ram") == nil {
+			t.Error("LoadComparisonRules() missing RAM rule")
 		}
 	})
 
-	t.Run("Búsqueda vacía", func(t *testing.T) {
-		products, err := repo.Search("")
-		if err != nil {
-			t.Errorf("Search() error = %v, wantErr nil", err)
-			return
+	t.Run("Error con regla inválida", func(t *testing.T) {
+		filePath := createTestFile(t, `{"specifications": [{"name": "RAM", "direction": "up", "type": "numeric"}]}`)
+
+		if _, err := jsonRepo.LoadComparisonRules(filePath); err == nil {
+			t.Error("LoadComparisonRules() expected error for invalid rule, got nil")
 		}
-		
-		// Búsqueda vacía debe devolver todos los productos
-		if len(products) != 2 {
-			t.Errorf("Search() empty query count = %v, want 2", len(products))
+	})
+}
+
+func TestRepositoryGetAll(t *testing.T) {
+	testData := `[
+		{
+			"id": "PHONE001",
+			"name": "Samsung Galaxy S24 Ultra",
+			"image_url": "https://example.com/s24.jpg",
+			"description": "Samsung flagship",
+			"price": 1299.99,
+			"rating": 4.6,
+			"category": "Smartphones",
+			"brand": "Samsung",
+			"available": true,
+			"specifications": [{"name": "RAM", "value": "12", "unit": "GB"}]
+		},
+		{
+			"id": "PHONE002",
+			"name": "iPhone 15 Pro Max",
+			"image_url": "https://example.com/iphone.jpg",
+			"description": "Apple flagship",
+			"price": 1399.99,
+			"rating": 4.8,
+			"category": "Smartphones",
+			"brand": "Apple",
+			"available": false,
+			"specifications": [{"name": "RAM", "value": "8", "unit": "GB"}]
 		}
+	]`
+
+	forEachBackend(t, testData, func(t *testing.T, repo domain.ProductRepository) {
+		t.Run("Filtro por especificación", func(t *testing.T) {
+			products, err := repo.GetAll(domain.ProductFilter{
+				Specs: []domain.SpecPredicate{{Name: "RAM", Operator: domain.OpGreaterOrEqual, Value: "12"}},
+			})
+			if err != nil {
+				t.Fatalf("GetAll() error = %v, wantErr nil", err)
+			}
+
+			if len(products) != 1 || products[0].ID != "PHONE001" {
+				t.Errorf("GetAll() = %v products, want only PHONE001", len(products))
+			}
+		})
+
+		t.Run("Filtro por marca y disponibilidad", func(t *testing.T) {
+			available := true
+			products, err := repo.GetAll(domain.ProductFilter{Brand: "apple", Available: &available})
+			if err != nil {
+				t.Fatalf("GetAll() error = %v, wantErr nil", err)
+			}
+
+			if len(products) != 0 {
+				t.Errorf("GetAll() count = %v, want 0", len(products))
+			}
+		})
 	})
-}
\ No newline at end of file
+}
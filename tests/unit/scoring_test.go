@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func TestScoreProducts(t *testing.T) {
+	rules := &domain.ComparisonRuleSet{
+		Fields: []domain.ComparisonRule{
+			{Name: "price", Direction: domain.LowerIsBetter, Type: domain.NumericRule},
+			{Name: "rating", Direction: domain.HigherIsBetter, Type: domain.NumericRule},
+		},
+	}
+
+	products := []*domain.Product{
+		{
+			ID: "PHONE001", Price: 1000, Rating: 4.0,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "12", Unit: "GB"}},
+		},
+		{
+			ID: "PHONE002", Price: 1500, Rating: 5.0,
+			Specifications: []domain.Specification{{Name: "RAM", Value: "8", Unit: "GB"}},
+		},
+		{
+			ID: "PHONE003", Price: 1250, Rating: 4.5,
+		},
+	}
+
+	t.Run("Ranking ponderado", func(t *testing.T) {
+		ranking, err := domain.ScoreProducts(products, map[string]float64{"price": 3, "rating": 1}, rules)
+		if err != nil {
+			t.Fatalf("ScoreProducts() error = %v, wantErr nil", err)
+		}
+
+		if ranking[0].ProductID != "PHONE001" || ranking[0].Rank != 1 {
+			t.Errorf("ScoreProducts() first = %+v, want PHONE001 rank 1", ranking[0])
+		}
+		if math.Abs(ranking[0].Score-0.75) > 1e-9 {
+			t.Errorf("ScoreProducts() PHONE001 score = %v, want 0.75", ranking[0].Score)
+		}
+		if len(ranking[0].Contributions) != 2 {
+			t.Errorf("ScoreProducts() contributions = %v, want 2", len(ranking[0].Contributions))
+		}
+	})
+
+	t.Run("Especificación faltante aporta cero", func(t *testing.T) {
+		ranking, err := domain.ScoreProducts(products, map[string]float64{"ram": 1}, rules)
+		if err != nil {
+			t.Fatalf("ScoreProducts() error = %v, wantErr nil", err)
+		}
+
+		last := ranking[len(ranking)-1]
+		if last.ProductID != "PHONE003" || !last.Contributions[0].Missing || last.Score != 0 {
+			t.Errorf("ScoreProducts() last = %+v, want PHONE003 with missing RAM", last)
+		}
+	})
+
+	t.Run("Errores de validación", func(t *testing.T) {
+		cases := map[string]map[string]float64{
+			"sin pesos":         {},
+			"peso negativo":     {"price": -1},
+			"criterio inválido": {"Peso": 1},
+			"pesos en cero":     {"price": 0},
+		}
+
+		for name, weights := range cases {
+			_, err := domain.ScoreProducts(products, weights, rules)
+			if _, ok := err.(*domain.ValidationError); !ok {
+				t.Errorf("%s: ScoreProducts() error type = %T, want *domain.ValidationError", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,381 @@
+package unit
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"meli-products-api/domain"
+	"meli-products-api/internal/search"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "Audífonos", want: "audifonos"},
+		{input: "Batería", want: "bateria"},
+		{input: "CANCELACIÓN", want: "cancelacion"},
+		{input: "Añadir", want: "anadir"},
+		{input: "Pingüino", want: "pinguino"},
+		{input: "Galaxy", want: "galaxy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := search.Fold(tt.input); got != tt.want {
+				t.Errorf("Fold(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "Palabras vacías en español", input: "Audífonos con cancelación de ruido", want: []string{"audifono", "cancelacion", "ruido"}},
+		{name: "Palabras vacías en inglés", input: "The best phone for the money", want: []string{"best", "phone", "money"}},
+		{name: "Separadores y números", input: "Galaxy S24-Ultra, 256GB", want: []string{"galaxy", "s24", "ultra", "256gb"}},
+		{name: "Solo palabras vacías", input: "de la con", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := search.Terms(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Terms(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func searchTestProducts() []*domain.Product {
+	return []*domain.Product{
+		{ID: "DESC", Name: "Parlante Portátil", Brand: "JBL", Category: "Audio", Description: "Compatible con cualquier Samsung"},
+		{ID: "NAME", Name: "Samsung Galaxy S24", Brand: "Samsung", Category: "Smartphones", Description: "Teléfono insignia"},
+		{ID: "HEAD", Name: "Sony WH-1000XM5", Brand: "Sony", Category: "Audífonos", Description: "Audífonos inalámbricos con cancelación de ruido"},
+		{ID: "BRAND", Name: "Smart TV 55", Brand: "Samsung", Category: "Televisores", Description: "Pantalla 4K"},
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	index := search.NewIndex(searchTestProducts())
+
+	ids := func(hits []domain.SearchHit) []string {
+		result := make([]string, len(hits))
+		for i, hit := range hits {
+			result[i] = hit.ID
+		}
+		return result
+	}
+
+	t.Run("Coincidencia sin acentos", func(t *testing.T) {
+		hits := index.Search("audifonos")
+		if got := ids(hits); !reflect.DeepEqual(got, []string{"HEAD"}) {
+			t.Errorf("Search(audifonos) = %v, want [HEAD]", got)
+		}
+	})
+
+	t.Run("Nombre pesa más que marca y descripción", func(t *testing.T) {
+		hits := index.Search("samsung")
+		if got := ids(hits); !reflect.DeepEqual(got, []string{"NAME", "BRAND", "DESC"}) {
+			t.Fatalf("Search(samsung) = %v, want [NAME BRAND DESC]", got)
+		}
+		for i := 1; i < len(hits); i++ {
+			if hits[i].Score > hits[i-1].Score {
+				t.Errorf("hits not sorted by score: %v", hits)
+			}
+		}
+	})
+
+	t.Run("Todos los términos deben coincidir", func(t *testing.T) {
+		if got := ids(index.Search("samsung galaxy")); !reflect.DeepEqual(got, []string{"NAME"}) {
+			t.Errorf("Search(samsung galaxy) = %v, want [NAME]", got)
+		}
+	})
+
+	t.Run("Coincidencia por prefijo", func(t *testing.T) {
+		if got := ids(index.Search("galax")); !reflect.DeepEqual(got, []string{"NAME"}) {
+			t.Errorf("Search(galax) = %v, want [NAME]", got)
+		}
+	})
+
+	t.Run("Sin resultados", func(t *testing.T) {
+		if hits := index.Search("iphone"); len(hits) != 0 {
+			t.Errorf("Search(iphone) = %v, want no hits", ids(hits))
+		}
+	})
+
+	t.Run("Consulta vacía devuelve todo en orden original", func(t *testing.T) {
+		if got := ids(index.Search("")); !reflect.DeepEqual(got, []string{"DESC", "NAME", "HEAD", "BRAND"}) {
+			t.Errorf("Search('') = %v", got)
+		}
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{a: "samsung", b: "samsung", max: 1, want: 0},
+		{a: "samsumg", b: "samsung", max: 1, want: 1},
+		{a: "galxy", b: "galaxy", max: 1, want: 1},
+		{a: "galayx", b: "galaxy", max: 1, want: 1},
+		{a: "sasmumg", b: "samsung", max: 2, want: 2},
+		{a: "iphone", b: "pixel", max: 1, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := search.EditDistance(tt.a, tt.b, tt.max); got != tt.want {
+				t.Errorf("EditDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxEdits(t *testing.T) {
+	for term, want := range map[string]int{"ram": 0, "galxy": 1, "samsumg": 1, "televisores": 2} {
+		if got := search.MaxEdits(term); got != want {
+			t.Errorf("MaxEdits(%q) = %d, want %d", term, got, want)
+		}
+	}
+}
+
+func TestIndexFuzzySearch(t *testing.T) {
+	index := search.NewIndex(searchTestProducts())
+
+	t.Run("Términos mal escritos", func(t *testing.T) {
+		hits := index.Search("samsumg galxy")
+		if len(hits) != 1 || hits[0].ID != "NAME" {
+			t.Fatalf("Search(samsumg galxy) = %v, want [NAME]", hits)
+		}
+		if exact := index.Search("samsung galaxy"); hits[0].Score >= exact[0].Score {
+			t.Errorf("fuzzy score %v should be lower than exact score %v", hits[0].Score, exact[0].Score)
+		}
+	})
+
+	t.Run("Términos cortos no se corrigen", func(t *testing.T) {
+		if hits := index.Search("tc"); len(hits) != 0 {
+			t.Errorf("Search(tc) returned %d hits, want 0", len(hits))
+		}
+	})
+
+	t.Run("Sugerencias", func(t *testing.T) {
+		got := index.Suggest("samsumg galxy", 3)
+		if len(got) == 0 || got[0] != "samsung galaxy" {
+			t.Errorf("Suggest(samsumg galxy) = %v, want first suggestion 'samsung galaxy'", got)
+		}
+	})
+
+	t.Run("Sin sugerencias para consultas correctas", func(t *testing.T) {
+		if got := index.Suggest("samsung galaxy", 3); len(got) != 0 {
+			t.Errorf("Suggest(samsung galaxy) = %v, want none", got)
+		}
+	})
+
+	t.Run("Conserva acentos del catálogo", func(t *testing.T) {
+		got := index.Suggest("audifonoz sony", 3)
+		if len(got) == 0 || got[0] != "audífonos sony" {
+			t.Errorf("Suggest(audifonoz sony) = %v, want 'audífonos sony'", got)
+		}
+	})
+}
+
+func TestIndexExplain(t *testing.T) {
+	products := searchTestProducts()
+	index := search.NewIndex(products)
+	index.SetSynonyms(search.NewSynonyms(&domain.SynonymSet{Rules: []domain.SynonymRule{
+		{Equivalent: []string{"televisor", "smart tv"}},
+	}}))
+
+	t.Run("La suma de los aportes es la puntuación", func(t *testing.T) {
+		for _, query := range []string{"samsung", "samsumg galax", "televisor samsung"} {
+			hits := index.Search(query)
+			if len(hits) == 0 {
+				t.Fatalf("Search(%q) returned no hits", query)
+			}
+
+			for _, hit := range hits {
+				explanation := index.Explain(query, hit.Product)
+				if explanation == nil {
+					t.Fatalf("Explain(%q, %s) = nil", query, hit.ID)
+				}
+				if explanation.Score != hit.Score {
+					t.Errorf("Explain(%q, %s).Score = %v, want %v", query, hit.ID, explanation.Score, hit.Score)
+				}
+
+				var sum float64
+				for _, match := range explanation.Matches {
+					sum += match.Score
+				}
+				if math.Abs(sum-hit.Score) > 0.001 {
+					t.Errorf("Explain(%q, %s) components sum %v, want %v", query, hit.ID, sum, hit.Score)
+				}
+			}
+		}
+	})
+
+	t.Run("Tipos de coincidencia", func(t *testing.T) {
+		tests := []struct {
+			query string
+			id    string
+			want  map[string]string
+		}{
+			{query: "samsung", id: "NAME", want: map[string]string{"samsung": domain.MatchExact}},
+			{query: "galax", id: "NAME", want: map[string]string{"galaxy": domain.MatchPrefix}},
+			{query: "samsumg", id: "NAME", want: map[string]string{"samsung": domain.MatchFuzzy}},
+			{query: "televisor", id: "BRAND", want: map[string]string{"smart": domain.MatchSynonym, "tv": domain.MatchSynonym}},
+		}
+
+		for _, tt := range tests {
+			product := products[0]
+			for _, p := range products {
+				if p.ID == tt.id {
+					product = p
+				}
+			}
+
+			explanation := index.Explain(tt.query, product)
+			if explanation == nil {
+				t.Fatalf("Explain(%q, %s) = nil", tt.query, tt.id)
+			}
+
+			got := make(map[string]string)
+			for _, match := range explanation.Matches {
+				got[match.Term] = match.MatchType
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Explain(%q, %s) match types = %v, want %v", tt.query, tt.id, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("Detalle por campo", func(t *testing.T) {
+		explanation := index.Explain("samsung", products[1])
+		fields := make(map[string]bool)
+		for _, match := range explanation.Matches {
+			fields[match.Field] = true
+			if match.TermFrequency != 1 || match.IDF <= 0 || match.Weight != 1 {
+				t.Errorf("unexpected components %+v", match)
+			}
+		}
+		if !reflect.DeepEqual(fields, map[string]bool{"name": true, "brand": true}) {
+			t.Errorf("matched fields = %v, want name and brand", fields)
+		}
+	})
+
+	t.Run("Producto sin coincidencias", func(t *testing.T) {
+		if explanation := index.Explain("samsung", products[2]); explanation != nil {
+			t.Errorf("Explain(samsung, HEAD) = %+v, want nil", explanation)
+		}
+	})
+}
+
+func TestIndexHighlight(t *testing.T) {
+	products := searchTestProducts()
+	index := search.NewIndex(products)
+
+	t.Run("Marca los términos en nombre y descripción", func(t *testing.T) {
+		got := index.Highlight("audifonos sony", products[2])
+		want := map[string]string{
+			"name":        "<em>Sony</em> WH-1000XM5",
+			"description": "<em>Audífonos</em> inalámbricos con cancelación de ruido",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Highlight = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Omite campos sin coincidencias", func(t *testing.T) {
+		got := index.Highlight("galax", products[1])
+		if !reflect.DeepEqual(got, map[string]string{"name": "Samsung <em>Galaxy</em> S24"}) {
+			t.Errorf("Highlight(galax) = %v", got)
+		}
+	})
+
+	t.Run("Recorta descripciones largas y escapa HTML", func(t *testing.T) {
+		product := &domain.Product{
+			ID:          "LONG",
+			Name:        "Monitor",
+			Description: strings.Repeat("Panel de alta calidad para trabajo diario. ", 5) + "Incluye <cable> HDMI y soporte ajustable. " + strings.Repeat("Garantía oficial de un año. ", 5),
+		}
+		index := search.NewIndex([]*domain.Product{product})
+
+		got := index.Highlight("hdmi", product)["description"]
+		if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") {
+			t.Errorf("snippet %q should be trimmed on both ends", got)
+		}
+		if !strings.Contains(got, "&lt;cable&gt; <em>HDMI</em>") {
+			t.Errorf("snippet %q should escape HTML and mark HDMI", got)
+		}
+		if len(got) >= len(product.Description)/2 {
+			t.Errorf("snippet length %d should be well below the description length %d", len(got), len(product.Description))
+		}
+	})
+}
+
+func TestCompleter(t *testing.T) {
+	products := []*domain.Product{
+		{ID: "S24", Name: "Samsung Galaxy S24", Brand: "Samsung", Category: "Smartphones", Rating: 4.6, Available: true},
+		{ID: "TAB", Name: "Samsung Galaxy Tab S9", Brand: "Samsung", Category: "Tablets", Rating: 4.2, Available: true},
+		{ID: "BUDS", Name: "Galaxy Buds", Brand: "Samsung", Category: "Audífonos", Rating: 4.9, Available: false},
+		{ID: "SONY", Name: "Sony WH-1000XM5", Brand: "Sony", Category: "Audífonos", Rating: 4.7, Available: true},
+	}
+	completer := search.NewCompleter(products)
+
+	texts := func(suggestions []domain.Suggestion) []string {
+		result := make([]string, len(suggestions))
+		for i, s := range suggestions {
+			result[i] = s.Text
+		}
+		return result
+	}
+
+	t.Run("Prefijo en medio del nombre ordenado por popularidad", func(t *testing.T) {
+		// Los productos no disponibles pesan la mitad de su calificación
+		got := texts(completer.Complete("gal", 5))
+		want := []string{"Samsung Galaxy S24", "Samsung Galaxy Tab S9", "Galaxy Buds"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Complete(gal) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Marcas y categorías con cantidad de productos", func(t *testing.T) {
+		got := completer.Complete("s", 2)
+		if len(got) != 2 || got[0].Type != domain.SuggestionBrand || got[0].Text != "Samsung" || got[0].ProductCount != 3 {
+			t.Fatalf("Complete(s) = %+v, want Samsung brand first", got)
+		}
+		// Ante igual popularidad, la marca Sony precede al producto Sony
+		if got[1].Type != domain.SuggestionBrand || got[1].Text != "Sony" {
+			t.Errorf("Complete(s)[1] = %+v, want Sony brand", got[1])
+		}
+	})
+
+	t.Run("Sin acentos y con separadores", func(t *testing.T) {
+		got := completer.Complete("audif", 5)
+		if len(got) != 1 || got[0].Text != "Audífonos" || got[0].Type != domain.SuggestionCategory {
+			t.Errorf("Complete(audif) = %+v, want Audífonos category", got)
+		}
+
+		got = completer.Complete("wh 1000", 5)
+		if len(got) != 1 || got[0].ProductID != "SONY" {
+			t.Errorf("Complete(wh 1000) = %+v, want SONY product", got)
+		}
+	})
+
+	t.Run("Límite y prefijo desconocido", func(t *testing.T) {
+		if got := completer.Complete("galaxy", 1); len(got) != 1 {
+			t.Errorf("Complete(galaxy, 1) returned %d suggestions, want 1", len(got))
+		}
+		if got := completer.Complete("xyz", 5); len(got) != 0 {
+			t.Errorf("Complete(xyz) = %+v, want none", got)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package unit
+
+import (
+	"reflect"
+	"testing"
+
+	"meli-products-api/domain"
+	jsonRepo "meli-products-api/internal/repository/json"
+)
+
+func testSpecSchemas() *domain.SpecSchemaSet {
+	return &domain.SpecSchemaSet{Schemas: []domain.CategorySpecSchema{
+		{Category: "electronica", Specifications: []domain.SpecAttribute{
+			{Name: "Peso", Type: domain.SpecKindNumber, Units: []string{"g", "kg"}},
+			{Name: "Garantía", Type: domain.SpecKindText},
+		}},
+		{Category: "Smartphones", Specifications: []domain.SpecAttribute{
+			{Name: "RAM", Type: domain.SpecKindNumber, Required: true, Units: []string{"GB"}},
+			{Name: "Almacenamiento", Type: domain.SpecKindNumber, Required: true, Units: []string{"GB", "TB"}},
+			{Name: "NFC", Type: domain.SpecKindBoolean},
+			{Name: "Garantía", Type: domain.SpecKindText, Required: true},
+		}},
+	}}
+}
+
+func TestSpecSchemaSetValidate(t *testing.T) {
+	if err := testSpecSchemas().Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	invalid := &domain.SpecSchemaSet{Schemas: []domain.CategorySpecSchema{
+		{Category: "Audio", Specifications: []domain.SpecAttribute{
+			{Name: "Drivers", Type: domain.SpecKindNumber, Units: []string{"mm", "parsecs"}},
+			{Name: "drivers", Type: domain.SpecKindText},
+			{Name: "Tipo", Type: "enum"},
+			{Name: "Color", Type: domain.SpecKindText, Units: []string{"mm"}},
+		}},
+		{Category: "AUDIO"},
+	}}
+
+	err := invalid.Validate()
+	errs, ok := err.(domain.ValidationErrors)
+	if !ok {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	want := []string{
+		"schemas[0].specifications[0].units[1]",
+		"schemas[0].specifications[1].name",
+		"schemas[0].specifications[2].type",
+		"schemas[0].specifications[3].units",
+		"schemas[1].category",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("error fields = %v, want %v", fields, want)
+	}
+}
+
+func TestSpecSchemaAttributesFor(t *testing.T) {
+	schemas := testSpecSchemas()
+
+	phone := &domain.Product{
+		Category: "Smartphones",
+		Breadcrumbs: []domain.CategoryRef{
+			{ID: "electronica", Name: "Electrónica"},
+			{ID: "smartphones", Name: "Smartphones"},
+		},
+	}
+
+	attributes := schemas.AttributesFor(phone)
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		names[i] = attribute.Name
+	}
+	if want := []string{"Peso", "Garantía", "RAM", "Almacenamiento", "NFC"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("AttributesFor() names = %v, want %v", names, want)
+	}
+
+	// El esquema de la categoría más específica prevalece sobre el del ancestro
+	if !attributes[1].Required {
+		t.Error("expected Garantía to be required by the Smartphones schema")
+	}
+
+	if got := schemas.AttributesFor(&domain.Product{Category: "Hogar"}); got != nil {
+		t.Errorf("AttributesFor() = %v, want nil for a category without schema", got)
+	}
+}
+
+func TestValidateSpecificationsAndCompleteness(t *testing.T) {
+	attributes := testSpecSchemas().Schemas[1].Specifications
+
+	product := &domain.Product{
+		ID: "P1",
+		Specifications: []domain.Specification{
+			{Name: "RAM", Value: "ocho"},
+			{Name: "Almacenamiento", Value: "1", Unit: "TB SSD"},
+			{Name: "NFC", Value: "sí"},
+		},
+	}
+	product.ParseSpecifications()
+
+	errs := domain.ValidateSpecifications(product, attributes, "products[0]")
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	want := []string{"products[0].specifications.RAM", "products[0].specifications.Garantía"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("error fields = %v, want %v", fields, want)
+	}
+
+	product.Specifications[1].Unit = "MB"
+	product.ParseSpecifications()
+	if errs := domain.ValidateSpecifications(product, attributes[1:2], "products[0]"); len(errs) != 1 {
+		t.Errorf("expected a unit error for MB, got %v", errs)
+	}
+
+	completeness := domain.Completeness(product, attributes)
+	want2 := &domain.SpecCompleteness{Score: 0.75, Present: 3, Total: 4, Missing: []string{"Garantía"}}
+	if !reflect.DeepEqual(completeness, want2) {
+		t.Errorf("Completeness() = %+v, want %+v", completeness, want2)
+	}
+
+	if got := domain.Completeness(product, nil); got != nil {
+		t.Errorf("Completeness() = %+v, want nil without schema", got)
+	}
+	if score := (&domain.Product{}).CompletenessScore(); score != 1 {
+		t.Errorf("CompletenessScore() = %v, want 1 without schema", score)
+	}
+}
+
+func TestRepositorySpecSchemas(t *testing.T) {
+	repo, err := jsonRepo.NewProductRepository(createTestFile(t, `[
+		{"id": "A", "category": "Smartphones", "specifications": [
+			{"name": "RAM", "value": "8", "unit": "GB"},
+			{"name": "Almacenamiento", "value": "128", "unit": "GB"},
+			{"name": "Garantía", "value": "12 meses"}
+		]},
+		{"id": "B", "category": "Smartphones", "specifications": [
+			{"name": "RAM", "value": "12", "unit": "GB"},
+			{"name": "Almacenamiento", "value": "1", "unit": "TB"},
+			{"name": "Garantía", "value": "24 meses"},
+			{"name": "NFC", "value": "sí"},
+			{"name": "Peso", "value": "0.2", "unit": "kg"}
+		]},
+		{"id": "C", "category": "Accesorios"}
+	]`))
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	// Sin taxonomía no aplica el esquema de Electrónica: la completitud se mide sobre el de Smartphones
+	if err := repo.SetSpecSchemas(testSpecSchemas()); err != nil {
+		t.Fatalf("SetSpecSchemas() error = %v", err)
+	}
+
+	a, _ := repo.GetByID("A")
+	if a.Completeness == nil || a.Completeness.Score != 0.75 {
+		t.Errorf("A completeness = %+v, want score 0.75", a.Completeness)
+	}
+	b, _ := repo.GetByID("B")
+	if b.Completeness == nil || b.Completeness.Score != 1 {
+		t.Errorf("B completeness = %+v, want score 1", b.Completeness)
+	}
+	if c, _ := repo.GetByID("C"); c.Completeness != nil {
+		t.Errorf("C completeness = %+v, want nil", c.Completeness)
+	}
+
+	t.Run("Orden por completitud con cursor", func(t *testing.T) {
+		products, _ := repo.GetAll(domain.ProductFilter{})
+		keys, err := domain.ParseSortKeys("completeness")
+		if err != nil {
+			t.Fatalf("ParseSortKeys() error = %v", err)
+		}
+
+		first, err := domain.Paginate(products, keys, domain.PageRequest{PageSize: 1})
+		if err != nil || first.Items[0].ID != "A" {
+			t.Fatalf("first page = %+v, %v", first, err)
+		}
+
+		second, err := domain.Paginate(products, keys, domain.PageRequest{PageSize: 2, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatalf("Paginate() error = %v", err)
+		}
+		if ids := []string{second.Items[0].ID, second.Items[1].ID}; !reflect.DeepEqual(ids, []string{"B", "C"}) {
+			t.Errorf("second page = %v, want [B C]", ids)
+		}
+	})
+
+	t.Run("Productos que no cumplen el esquema", func(t *testing.T) {
+		repo, _ := jsonRepo.NewProductRepository(createTestFile(t, `[
+			{"id": "A", "category": "Smartphones", "specifications": [{"name": "RAM", "value": "8", "unit": "GB"}]}
+		]`))
+
+		err := repo.SetSpecSchemas(testSpecSchemas())
+		if err == nil {
+			t.Fatal("expected error for missing required specifications")
+		}
+		if product, _ := repo.GetByID("A"); product.Completeness != nil {
+			t.Error("previous catalog should be kept")
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"math"
+	"testing"
+
+	"meli-products-api/domain"
+)
+
+func TestParseSpecValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		unit      string
+		kind      domain.SpecValueKind
+		number    float64
+		wantUnit  string
+		qualifier string
+	}{
+		{name: "Pulgadas a unidad canónica", value: "6.8", unit: "pulgadas", kind: domain.SpecKindNumber, number: 6.8, wantUnit: "in"},
+		{name: "Gigabytes", value: "12", unit: "GB", kind: domain.SpecKindNumber, number: 12, wantUnit: "GB"},
+		{name: "Terabytes con calificador", value: "1", unit: "TB SSD", kind: domain.SpecKindNumber, number: 1024, wantUnit: "GB", qualifier: "SSD"},
+		{name: "Unidad embebida en el valor", value: "6.8 inches", kind: domain.SpecKindNumber, number: 6.8, wantUnit: "in"},
+		{name: "Coma decimal", value: "6,1", unit: "pulgadas", kind: domain.SpecKindNumber, number: 6.1, wantUnit: "in"},
+		{name: "Coma decimal con dos dígitos", value: "1,25 kg", kind: domain.SpecKindNumber, number: 1250, wantUnit: "g"},
+		{name: "Coma de miles", value: "1,000 mAh", kind: domain.SpecKindNumber, number: 1000, wantUnit: "mAh"},
+		{name: "Comas de miles con decimales", value: "1,234,567.5", unit: "Hz", kind: domain.SpecKindNumber, number: 1234567.5, wantUnit: "Hz"},
+		{name: "Coma de miles con grupo incompleto", value: "1,0000 mAh", kind: domain.SpecKindText},
+		{name: "Coma después del punto decimal", value: "1.000,5", kind: domain.SpecKindText},
+		{name: "Horas", value: "30", unit: "horas", kind: domain.SpecKindNumber, number: 30, wantUnit: "h"},
+		{name: "Unidad desconocida se conserva", value: "120", unit: "fps", kind: domain.SpecKindNumber, number: 120, wantUnit: "fps"},
+		{name: "Número sin unidad", value: "8", kind: domain.SpecKindNumber, number: 8},
+		{name: "Símbolo de varias letras sin distinguir mayúsculas", value: "12 gb", kind: domain.SpecKindNumber, number: 12, wantUnit: "GB"},
+		{name: "Símbolo de una letra distingue mayúsculas", value: "5G", kind: domain.SpecKindText},
+		{name: "Texto con número", value: "Snapdragon 8 Gen 3", kind: domain.SpecKindText},
+		{name: "Texto que empieza con número", value: "14-core GPU", kind: domain.SpecKindText},
+		{name: "Texto con unidad parcial", value: "3 min = 3h", kind: domain.SpecKindText},
+		{name: "Booleano", value: "Sí", kind: domain.SpecKindBoolean},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := domain.ParseSpecValue(tt.value, tt.unit)
+
+			if got.Kind != tt.kind {
+				t.Fatalf("ParseSpecValue() kind = %v, want %v", got.Kind, tt.kind)
+			}
+			if tt.kind != domain.SpecKindNumber {
+				return
+			}
+			if math.Abs(got.Number-tt.number) > 1e-9 {
+				t.Errorf("ParseSpecValue() number = %v, want %v", got.Number, tt.number)
+			}
+			if got.Unit != tt.wantUnit {
+				t.Errorf("ParseSpecValue() unit = %v, want %v", got.Unit, tt.wantUnit)
+			}
+			if got.Qualifier != tt.qualifier {
+				t.Errorf("ParseSpecValue() qualifier = %v, want %v", got.Qualifier, tt.qualifier)
+			}
+		})
+	}
+}
+
+func TestConvertUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		from    string
+		to      string
+		want    float64
+		wantErr bool
+	}{
+		{name: "TB a GB", value: 1, from: "TB", to: "GB", want: 1024},
+		{name: "Pulgadas a centímetros", value: 1, from: "pulgadas", to: "cm", want: 2.54},
+		{name: "Minutos a horas", value: 90, from: "min", to: "h", want: 1.5},
+		{name: "Unidades incompatibles", value: 1, from: "GB", to: "mAh", wantErr: true},
+		{name: "Unidad desconocida", value: 1, from: "parsecs", to: "cm", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ConvertUnit(tt.value, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ConvertUnit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"meli-products-api/domain"
+	sqliteRepo "meli-products-api/internal/repository/sqlite"
+)
+
+// sqliteTestProducts son productos con acentos, marcas repetidas y un producto no disponible
+const sqliteTestProducts = `[
+	{"id": "A", "name": "Galaxy S24", "image_url": "https://example.com/a.jpg", "description": "Cámara de 200 MP", "price": 1200, "rating": 4.5, "category": "Smartphones", "brand": "Samsung", "available": true, "specifications": [{"name": "RAM", "value": "12", "unit": "GB"}, {"name": "NFC", "value": "Sí", "unit": ""}]},
+	{"id": "B", "name": "Galaxy Tab S9", "image_url": "https://example.com/b.jpg", "description": "Tablet con lápiz", "price": 900, "rating": 4.2, "category": "Tablets", "brand": "Samsung", "available": false, "specifications": []},
+	{"id": "C", "name": "Cafetera Express", "image_url": "https://example.com/c.jpg", "description": "Café espresso en casa", "price": 150, "rating": 4.0, "category": "Electrodomésticos", "brand": "Oster", "available": true, "specifications": []}
+]`
+
+func TestSQLiteRepositoryReads(t *testing.T) {
+	repo := openSQLiteTestRepository(t, sqliteTestProducts)
+
+	t.Run("Especificaciones y campos derivados", func(t *testing.T) {
+		product, err := repo.GetByID("A")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if len(product.Specifications) != 2 || product.Specifications[0].Name != "RAM" || product.Specifications[1].Value != "Sí" {
+			t.Errorf("unexpected specifications %+v", product.Specifications)
+		}
+		if product.Specifications[0].Typed.Kind != domain.SpecKindNumber {
+			t.Errorf("expected typed RAM, got %+v", product.Specifications[0].Typed)
+		}
+		if len(product.Breadcrumbs) != 1 || product.Breadcrumbs[0].ID != "smartphones" || product.Version != 1 {
+			t.Errorf("unexpected breadcrumbs or version %+v, %d", product.Breadcrumbs, product.Version)
+		}
+	})
+
+	t.Run("Filtro por categoría sin acentos ni mayúsculas", func(t *testing.T) {
+		for _, category := range []string{"electrodomesticos", " ELECTRODOMÉSTICOS "} {
+			products, err := repo.GetAll(domain.ProductFilter{Category: category})
+			if err != nil || len(products) != 1 || products[0].ID != "C" {
+				t.Errorf("GetAll(%q) = %v, %v", category, products, err)
+			}
+		}
+	})
+
+	t.Run("Filtro por rango de precios", func(t *testing.T) {
+		products, err := repo.GetAll(domain.ProductFilter{MinPrice: 900, MaxPrice: 1200})
+		if err != nil || len(products) != 2 || products[0].ID != "A" || products[1].ID != "B" {
+			t.Errorf("GetAll() = %v, %v", products, err)
+		}
+	})
+
+	t.Run("GetByIDs devuelve los encontrados en orden", func(t *testing.T) {
+		products, err := repo.GetByIDs([]string{"C", "X", "A"})
+		if err == nil {
+			t.Error("expected an error for the missing ID")
+		}
+		if len(products) != 2 || products[0].ID != "C" || products[1].ID != "A" {
+			t.Errorf("GetByIDs() = %v", products)
+		}
+	})
+
+	t.Run("Búsqueda por prefijo y sin acentos", func(t *testing.T) {
+		hits, err := repo.SearchRanked(domain.SearchRequest{Query: "cafe"})
+		if err != nil || len(hits) != 1 || hits[0].ID != "C" || hits[0].Score <= 0 {
+			t.Fatalf("SearchRanked(cafe) = %v, %v", hits, err)
+		}
+
+		hits, err = repo.SearchRanked(domain.SearchRequest{Query: "galax"})
+		if err != nil || len(hits) != 2 {
+			t.Fatalf("SearchRanked(galax) = %v, %v", hits, err)
+		}
+
+		// Todos los términos deben coincidir
+		if hits, _ := repo.SearchRanked(domain.SearchRequest{Query: "galaxy oster"}); len(hits) != 0 {
+			t.Errorf("expected no hits, got %v", hits)
+		}
+
+		// Las comillas y operadores de FTS5 se tratan como texto
+		if _, err := repo.SearchRanked(domain.SearchRequest{Query: `"galaxy" OR -`}); err != nil {
+			t.Errorf("SearchRanked() error = %v", err)
+		}
+	})
+
+	t.Run("Búsqueda con filtro", func(t *testing.T) {
+		available := true
+		hits, err := repo.SearchRanked(domain.SearchRequest{Query: "galaxy", Filter: domain.ProductFilter{Available: &available}})
+		if err != nil || len(hits) != 1 || hits[0].ID != "A" {
+			t.Errorf("SearchRanked() = %v, %v", hits, err)
+		}
+	})
+
+	t.Run("Autocompletado", func(t *testing.T) {
+		suggestions, err := repo.Autocomplete("sam", 5)
+		if err != nil || len(suggestions) != 1 {
+			t.Fatalf("Autocomplete(sam) = %v, %v", suggestions, err)
+		}
+		if s := suggestions[0]; s.Type != domain.SuggestionBrand || s.Text != "Samsung" || s.ProductCount != 2 || s.Score != 6.6 {
+			t.Errorf("unexpected suggestion %+v", s)
+		}
+
+		suggestions, _ = repo.Autocomplete("s9", 5)
+		if len(suggestions) != 1 || suggestions[0].ProductID != "B" {
+			t.Errorf("Autocomplete(s9) = %v", suggestions)
+		}
+	})
+
+	t.Run("Marcas y categorías", func(t *testing.T) {
+		if brands := repo.GetBrands(); len(brands) != 2 || brands[0] != "Samsung" {
+			t.Errorf("GetBrands() = %v", brands)
+		}
+
+		tree := repo.GetCategoryTree()
+		if len(tree) != 3 || tree[0].ID != "smartphones" || tree[2].ID != "electrodomesticos" || tree[2].ProductCount != 1 {
+			t.Errorf("GetCategoryTree() = %+v", tree)
+		}
+	})
+}
+
+func TestSQLiteRepositoryWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.db")
+
+	repo, err := sqliteRepo.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	created, err := repo.Create(writeTestProduct("C"))
+	if err != nil || created.Version != 1 || created.Specifications[0].Typed.Kind != domain.SpecKindNumber {
+		t.Fatalf("Create() = %+v, %v", created, err)
+	}
+
+	var exists *domain.ProductAlreadyExistsError
+	if _, err := repo.Create(writeTestProduct("C")); !errors.As(err, &exists) {
+		t.Errorf("expected ProductAlreadyExistsError, got %v", err)
+	}
+
+	invalid := writeTestProduct("D")
+	invalid.Price = 0
+	var errs domain.ValidationErrors
+	if _, err := repo.Create(invalid); !errors.As(err, &errs) || errs[0].Field != "price" {
+		t.Errorf("expected a price validation error, got %v", err)
+	}
+
+	// Actualización con control de versión
+	product := writeTestProduct("C")
+	product.Version = 1
+	product.Name = "Pixel 9 Pro"
+	product.Specifications = nil
+	updated, err := repo.Update(product)
+	if err != nil || updated.Version != 2 || len(updated.Specifications) != 0 {
+		t.Fatalf("Update() = %+v, %v", updated, err)
+	}
+
+	var conflict *domain.ProductVersionConflictError
+	if _, err := repo.Update(product); !errors.As(err, &conflict) || conflict.Current != 2 {
+		t.Errorf("expected a version conflict, got %v", err)
+	}
+
+	// El índice de texto completo sigue a las escrituras
+	if products, _ := repo.Search("pro"); len(products) != 1 {
+		t.Errorf("expected the updated name to be searchable, got %v", products)
+	}
+
+	// Los datos y el esquema sobreviven al reabrir la base
+	repo.Close()
+	repo, err = sqliteRepo.Open(path)
+	if err != nil {
+		t.Fatalf("Open() on existing database error = %v", err)
+	}
+	defer repo.Close()
+
+	if product, err := repo.GetByID("C"); err != nil || product.Name != "Pixel 9 Pro" || product.Version != 2 {
+		t.Errorf("GetByID() after reopen = %+v, %v", product, err)
+	}
+
+	deleted, err := repo.Delete("C")
+	if err != nil || deleted.Name != "Pixel 9 Pro" {
+		t.Fatalf("Delete() = %+v, %v", deleted, err)
+	}
+	var notFound *domain.ProductNotFoundError
+	if _, err := repo.Delete("C"); !errors.As(err, &notFound) {
+		t.Errorf("expected ProductNotFoundError, got %v", err)
+	}
+	if products, _ := repo.Search("pixel"); len(products) != 0 {
+		t.Errorf("deleted product should not be searchable, got %v", products)
+	}
+
+	// La versión del producto eliminado se conserva al reabrir: uno nuevo continúa su numeración
+	repo.Close()
+	repo, err = sqliteRepo.Open(path)
+	if err != nil {
+		t.Fatalf("Open() on existing database error = %v", err)
+	}
+	defer repo.Close()
+
+	if created, err := repo.Create(writeTestProduct("C")); err != nil || created.Version != 3 {
+		t.Errorf("Create() after Delete = %+v, %v; want version 3", created, err)
+	}
+}
+
+func TestSQLiteRepositoryImport(t *testing.T) {
+	invalid := writeTestProduct("B")
+	invalid.ImageURL = "not-a-url"
+
+	t.Run("Modo estricto no importa nada", func(t *testing.T) {
+		repo, err := sqliteRepo.Open(filepath.Join(t.TempDir(), "products.db"))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer repo.Close()
+
+		if err := repo.Import([]*domain.Product{writeTestProduct("A"), invalid}, domain.ValidationStrict); err == nil {
+			t.Fatal("expected a validation error")
+		}
+		if count := repo.GetProductCount(); count != 0 {
+			t.Errorf("expected no products, got %d", count)
+		}
+	})
+
+	t.Run("Modo permisivo descarta los inválidos", func(t *testing.T) {
+		repo, err := sqliteRepo.Open(filepath.Join(t.TempDir(), "products.db"))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer repo.Close()
+
+		if err := repo.Import([]*domain.Product{writeTestProduct("A"), invalid}, domain.ValidationLenient); err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+
+		report := repo.GetValidationReport()
+		if report.LoadedProducts != 1 || len(report.Rejected) != 1 || report.Rejected[0].ProductID != "B" {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if _, err := repo.GetByID("B"); err == nil {
+			t.Error("rejected product should not be imported")
+		}
+	})
+}
+
+func TestSQLiteRepositoryConcurrentWrites(t *testing.T) {
+	repo, err := sqliteRepo.Open(filepath.Join(t.TempDir(), "products.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer repo.Close()
+
+	if _, err := repo.Create(writeTestProduct("C")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Varias actualizaciones sobre la misma versión: exactamente una debe aplicarse
+	const writers = 8
+	var wg sync.WaitGroup
+	results := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			product := writeTestProduct("C")
+			product.Version = 1
+			product.Name = fmt.Sprintf("Pixel %d", i)
+			_, err := repo.Update(product)
+			results <- err
+
+			repo.GetAll(domain.ProductFilter{})
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	applied := 0
+	for err := range results {
+		var conflict *domain.ProductVersionConflictError
+		switch {
+		case err == nil:
+			applied++
+		case !errors.As(err, &conflict):
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	if applied != 1 {
+		t.Errorf("expected exactly one update applied, got %d", applied)
+	}
+}
+
+func TestSQLiteRepositoryCatalogRules(t *testing.T) {
+	repo := openSQLiteTestRepository(t, taxonomyTestProducts)
+
+	if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+		t.Fatalf("SetTaxonomy() error = %v", err)
+	}
+
+	t.Run("Árbol con cantidades por subárbol", func(t *testing.T) {
+		tree := repo.GetCategoryTree()
+		if len(tree) != 2 || tree[0].ProductCount != 3 || tree[1].ProductCount != 0 {
+			t.Fatalf("unexpected roots %+v", tree)
+		}
+
+		audio := tree[0].Children[1]
+		want := []domain.CategoryTreeNode{
+			{ID: "audifonos", Name: "Audífonos", ProductCount: 2},
+			{ID: "parlantes", Name: "Parlantes", ProductCount: 0},
+		}
+		if audio.ProductCount != 2 || !reflect.DeepEqual(audio.Children, want) {
+			t.Errorf("unexpected audio subtree %+v", audio)
+		}
+	})
+
+	t.Run("Migas de pan", func(t *testing.T) {
+		product, err := repo.GetByID("HEAD")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		want := []domain.CategoryRef{
+			{ID: "electronica", Name: "Electrónica"},
+			{ID: "audio", Name: "Audio"},
+			{ID: "audifonos", Name: "Audífonos"},
+		}
+		if !reflect.DeepEqual(product.Breadcrumbs, want) {
+			t.Errorf("Breadcrumbs = %+v, want %+v", product.Breadcrumbs, want)
+		}
+	})
+
+	t.Run("Filtro por subárbol", func(t *testing.T) {
+		tests := []struct {
+			category string
+			want     int
+		}{
+			{category: "Electrónica", want: 3},
+			{category: "audio", want: 2},
+			{category: "Audífonos", want: 2},
+			{category: "hogar", want: 0},
+		}
+
+		for _, tt := range tests {
+			products, err := repo.GetAll(domain.ProductFilter{Category: tt.category})
+			if err != nil || len(products) != tt.want {
+				t.Errorf("GetAll(%q) = %d products, %v; want %d", tt.category, len(products), err, tt.want)
+			}
+		}
+	})
+
+	if err := repo.SetSpecSchemas(&domain.SpecSchemaSet{Schemas: []domain.CategorySpecSchema{
+		{Category: "audio", Specifications: []domain.SpecAttribute{
+			{Name: "Batería", Type: domain.SpecKindNumber, Units: []string{"h"}},
+		}},
+	}}); err != nil {
+		t.Fatalf("SetSpecSchemas() error = %v", err)
+	}
+
+	t.Run("Completitud según el esquema", func(t *testing.T) {
+		product, err := repo.GetByID("HEAD")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if product.Completeness == nil || product.Completeness.Score != 0 {
+			t.Errorf("Completeness = %+v, want score 0", product.Completeness)
+		}
+	})
+
+	t.Run("Escrituras que no cumplen las reglas", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			category string
+			field    string
+		}{
+			{name: "Categoría desconocida", category: "Tablets", field: "category"},
+			{name: "Categoría con subcategorías", category: "Audio", field: "category"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				product := writeTestProduct("NEW")
+				product.Category = tt.category
+
+				_, err := repo.Create(product)
+				var errs domain.ValidationErrors
+				if !errors.As(err, &errs) || errs[0].Field != tt.field {
+					t.Fatalf("Create() error = %v, want a validation error on %s", err, tt.field)
+				}
+				if _, err := repo.GetByID("NEW"); err == nil {
+					t.Error("rejected product should not be created")
+				}
+			})
+		}
+
+		product, _ := repo.GetByID("PHONE")
+		product.Category = "Audio"
+		if _, err := repo.Update(product); err == nil {
+			t.Error("expected Update() to reject a category with subcategories")
+		}
+	})
+
+	t.Run("Especificación obligatoria faltante", func(t *testing.T) {
+		repo := openSQLiteTestRepository(t, `[]`)
+		if err := repo.SetSpecSchemas(testSpecSchemas()); err != nil {
+			t.Fatalf("SetSpecSchemas() error = %v", err)
+		}
+
+		_, err := repo.Create(writeTestProduct("NEW"))
+		var errs domain.ValidationErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("Create() error = %v, want validation errors", err)
+		}
+	})
+
+	t.Run("Reglas que no cumplen los productos guardados", func(t *testing.T) {
+		flat := openSQLiteTestRepository(t, sqliteTestProducts)
+		if err := flat.SetTaxonomy(testTaxonomy()); err == nil {
+			t.Fatal("expected an error for products outside the taxonomy")
+		}
+
+		// Las reglas anteriores se conservan: las categorías siguen siendo planas
+		products, err := flat.GetAll(domain.ProductFilter{Category: "Tablets"})
+		if err != nil || len(products) != 1 {
+			t.Errorf("GetAll() = %v, %v", products, err)
+		}
+	})
+}
+
+func TestSQLiteRepositoryImportCatalogRules(t *testing.T) {
+	products := func() []*domain.Product {
+		outside := writeTestProduct("B")
+		outside.Category = "Tablets"
+		return []*domain.Product{writeTestProduct("A"), outside}
+	}
+	open := func(t *testing.T) *sqliteRepo.ProductRepository {
+		repo, err := sqliteRepo.Open(filepath.Join(t.TempDir(), "products.db"))
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		t.Cleanup(func() { repo.Close() })
+
+		if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+			t.Fatalf("SetTaxonomy() error = %v", err)
+		}
+		return repo
+	}
+
+	t.Run("Modo estricto no importa nada", func(t *testing.T) {
+		repo := open(t)
+		if err := repo.Import(products(), domain.ValidationStrict); err == nil {
+			t.Fatal("expected a validation error")
+		}
+		if count := repo.GetProductCount(); count != 0 {
+			t.Errorf("expected no products, got %d", count)
+		}
+	})
+
+	t.Run("Modo permisivo descarta los de categorías desconocidas", func(t *testing.T) {
+		repo := open(t)
+		if err := repo.Import(products(), domain.ValidationLenient); err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+
+		report := repo.GetValidationReport()
+		if report.LoadedProducts != 1 || len(report.Rejected) != 1 || report.Rejected[0].ProductID != "B" || report.Rejected[0].Errors[0].Field != "products[1].category" {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if _, err := repo.GetByID("B"); err == nil {
+			t.Error("rejected product should not be imported")
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"meli-products-api/domain"
+	jsonRepo "meli-products-api/internal/repository/json"
+	"meli-products-api/internal/search"
+)
+
+func synonymTestProducts() []*domain.Product {
+	return []*domain.Product{
+		{ID: "PHONE", Name: "Galaxy S24", Brand: "Samsung", Category: "Smartphones", Description: "Teléfono insignia"},
+		{ID: "LAPTOP", Name: "XPS 15", Brand: "Dell", Category: "Laptops", Description: "Laptop con GPU dedicada"},
+		{ID: "HEAD", Name: "WH-1000XM5", Brand: "Sony", Category: "Audífonos", Description: "Audífonos inalámbricos"},
+	}
+}
+
+func TestIndexSearchWithSynonyms(t *testing.T) {
+	index := search.NewIndex(synonymTestProducts())
+	index.SetSynonyms(search.NewSynonyms(&domain.SynonymSet{Rules: []domain.SynonymRule{
+		{Equivalent: []string{"smartphone", "celular"}},
+		{Equivalent: []string{"laptop", "notebook"}},
+		{From: []string{"computadora"}, To: []string{"laptop"}},
+		{From: []string{"tarjeta gráfica"}, To: []string{"gpu"}},
+	}}))
+
+	ids := func(hits []domain.SearchHit) []string {
+		result := make([]string, len(hits))
+		for i, hit := range hits {
+			result[i] = hit.ID
+		}
+		return result
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "Bidireccional en un sentido", query: "celular samsung", want: []string{"PHONE"}},
+		{name: "Bidireccional en el otro sentido", query: "notebook dell", want: []string{"LAPTOP"}},
+		{name: "Un solo sentido", query: "computadora", want: []string{"LAPTOP"}},
+		{name: "Un solo sentido no se invierte", query: "laptop computadora", want: []string{"LAPTOP"}},
+		{name: "Frase de varias palabras", query: "laptop con tarjeta grafica", want: []string{"LAPTOP"}},
+		{name: "Sin sinónimos", query: "audifonos", want: []string{"HEAD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(index.Search(tt.query)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("El término original puntúa más que el sinónimo", func(t *testing.T) {
+		exact, synonym := index.Search("laptop"), index.Search("notebook")
+		if len(exact) != 1 || len(synonym) != 1 || exact[0].Score <= synonym[0].Score {
+			t.Errorf("Search(laptop) = %v, Search(notebook) = %v", exact, synonym)
+		}
+	})
+
+	t.Run("Reemplazo del diccionario", func(t *testing.T) {
+		index.SetSynonyms(nil)
+		if got := index.Search("celular"); len(got) != 0 {
+			t.Errorf("Search(celular) without synonyms = %v, want no results", ids(got))
+		}
+	})
+}
+
+func TestSynonymSetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    domain.SynonymRule
+		wantErr bool
+	}{
+		{name: "Bidireccional", rule: domain.SynonymRule{Equivalent: []string{"celular", "smartphone"}}},
+		{name: "Un solo sentido", rule: domain.SynonymRule{From: []string{"ordenador"}, To: []string{"laptop"}}},
+		{name: "Un solo término equivalente", rule: domain.SynonymRule{Equivalent: []string{"celular"}}, wantErr: true},
+		{name: "Sin destino", rule: domain.SynonymRule{From: []string{"ordenador"}}, wantErr: true},
+		{name: "Ambas formas", rule: domain.SynonymRule{Equivalent: []string{"a", "b"}, From: []string{"c"}, To: []string{"d"}}, wantErr: true},
+		{name: "Término vacío", rule: domain.SynonymRule{Equivalent: []string{"celular", " "}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := &domain.SynonymSet{Rules: []domain.SynonymRule{tt.rule}}
+			if err := set.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "synonyms.json")
+	if err := os.WriteFile(filePath, []byte(`{"rules": []}`), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changed := make(chan struct{}, 1)
+	go jsonRepo.WatchFile(ctx, filePath, 10*time.Millisecond, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(filePath, []byte(`{"rules": [{"equivalent": ["a", "b"]}]}`), 0644); err != nil {
+		t.Fatalf("Failed to update test file: %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("WatchFile() did not report the change")
+	}
+
+	synonyms, err := jsonRepo.LoadSynonyms(filePath)
+	if err != nil || len(synonyms.Rules) != 1 {
+		t.Errorf("LoadSynonyms() = %+v, %v", synonyms, err)
+	}
+}
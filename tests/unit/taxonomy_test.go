@@ -0,0 +1,171 @@
+package unit
+
+import (
+	"reflect"
+	"testing"
+
+	"meli-products-api/domain"
+	jsonRepo "meli-products-api/internal/repository/json"
+)
+
+func testTaxonomy() *domain.Taxonomy {
+	return &domain.Taxonomy{Categories: []domain.CategoryNode{
+		{ID: "electronica", Name: "Electrónica", Children: []domain.CategoryNode{
+			{ID: "telefonia", Name: "Telefonía", Children: []domain.CategoryNode{
+				{ID: "smartphones", Name: "Smartphones"},
+			}},
+			{ID: "audio", Name: "Audio", Children: []domain.CategoryNode{
+				{ID: "audifonos", Name: "Audífonos"},
+				{ID: "parlantes", Name: "Parlantes"},
+			}},
+		}},
+		{ID: "hogar", Name: "Hogar"},
+	}}
+}
+
+const taxonomyTestProducts = `[
+	{"id": "PHONE", "name": "Galaxy", "category": "Smartphones", "brand": "Samsung", "price": 900},
+	{"id": "HEAD", "name": "WH-1000XM5", "category": "audifonos", "brand": "Sony", "price": 350},
+	{"id": "HEAD2", "name": "AirPods", "category": "Audífonos", "brand": "Apple", "price": 250}
+]`
+
+func TestTaxonomyValidate(t *testing.T) {
+	if err := testTaxonomy().Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	invalid := &domain.Taxonomy{Categories: []domain.CategoryNode{
+		{ID: "audio", Name: "Audio", Children: []domain.CategoryNode{
+			{ID: "", Name: "Parlantes"},
+			{ID: "auriculares", Name: "AUDIO"},
+		}},
+	}}
+
+	err := invalid.Validate()
+	errs, ok := err.(domain.ValidationErrors)
+	if !ok {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	want := []string{"categories[0].children[0].id", "categories[0].children[1].name"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("error fields = %v, want %v", fields, want)
+	}
+
+	if err := (&domain.Taxonomy{}).Validate(); err == nil {
+		t.Error("expected error for empty taxonomy")
+	}
+}
+
+func TestProductInCategory(t *testing.T) {
+	product := &domain.Product{
+		Category: "Smartphones",
+		Breadcrumbs: []domain.CategoryRef{
+			{ID: "electronica", Name: "Electrónica"},
+			{ID: "telefonia", Name: "Telefonía"},
+			{ID: "smartphones", Name: "Smartphones"},
+		},
+	}
+
+	for _, category := range []string{"smartphones", "Telefonia", "telefonía", "ELECTRONICA"} {
+		if !product.InCategory(category) {
+			t.Errorf("InCategory(%q) = false, want true", category)
+		}
+	}
+	for _, category := range []string{"audio", "electro", ""} {
+		if product.InCategory(category) {
+			t.Errorf("InCategory(%q) = true, want false", category)
+		}
+	}
+}
+
+func TestRepositoryTaxonomy(t *testing.T) {
+	repo, err := jsonRepo.NewProductRepository(createTestFile(t, taxonomyTestProducts))
+	if err != nil {
+		t.Fatalf("NewProductRepository() error = %v", err)
+	}
+
+	t.Run("Categorías planas sin taxonomía", func(t *testing.T) {
+		want := []domain.CategoryTreeNode{
+			{ID: "smartphones", Name: "Smartphones", ProductCount: 1},
+			{ID: "audifonos", Name: "audifonos", ProductCount: 2},
+		}
+		if got := repo.GetCategoryTree(); !reflect.DeepEqual(got, want) {
+			t.Errorf("GetCategoryTree() = %+v, want %+v", got, want)
+		}
+	})
+
+	if err := repo.SetTaxonomy(testTaxonomy()); err != nil {
+		t.Fatalf("SetTaxonomy() error = %v", err)
+	}
+
+	t.Run("Árbol con cantidades por subárbol", func(t *testing.T) {
+		tree := repo.GetCategoryTree()
+		if len(tree) != 2 || tree[0].ProductCount != 3 || tree[1].ProductCount != 0 {
+			t.Fatalf("unexpected roots %+v", tree)
+		}
+
+		audio := tree[0].Children[1]
+		want := []domain.CategoryTreeNode{
+			{ID: "audifonos", Name: "Audífonos", ProductCount: 2},
+			{ID: "parlantes", Name: "Parlantes", ProductCount: 0},
+		}
+		if audio.ProductCount != 2 || !reflect.DeepEqual(audio.Children, want) {
+			t.Errorf("unexpected audio subtree %+v", audio)
+		}
+	})
+
+	t.Run("Migas de pan", func(t *testing.T) {
+		product, _ := repo.GetByID("HEAD")
+		want := []domain.CategoryRef{
+			{ID: "electronica", Name: "Electrónica"},
+			{ID: "audio", Name: "Audio"},
+			{ID: "audifonos", Name: "Audífonos"},
+		}
+		if !reflect.DeepEqual(product.Breadcrumbs, want) {
+			t.Errorf("Breadcrumbs = %+v, want %+v", product.Breadcrumbs, want)
+		}
+	})
+
+	t.Run("Filtro por subárbol", func(t *testing.T) {
+		tests := []struct {
+			category string
+			want     int
+		}{
+			{category: "Electrónica", want: 3},
+			{category: "audio", want: 2},
+			{category: "Telefonía", want: 1},
+			{category: "hogar", want: 0},
+			{category: "desconocida", want: 0},
+		}
+
+		for _, tt := range tests {
+			products, err := repo.GetAll(domain.ProductFilter{Category: tt.category})
+			if err != nil {
+				t.Fatalf("GetAll(%s) error = %v", tt.category, err)
+			}
+			if len(products) != tt.want {
+				t.Errorf("GetAll(%s) returned %d products, want %d", tt.category, len(products), tt.want)
+			}
+		}
+	})
+
+	t.Run("Productos fuera de las hojas", func(t *testing.T) {
+		repo, _ := jsonRepo.NewProductRepository(createTestFile(t, `[
+			{"id": "A", "category": "Audio"},
+			{"id": "B", "category": "Consolas"}
+		]`))
+
+		err := repo.SetTaxonomy(testTaxonomy())
+		if err == nil {
+			t.Fatal("expected error for products outside leaf categories")
+		}
+		if tree := repo.GetCategoryTree(); len(tree) != 2 || tree[0].Name != "Audio" {
+			t.Errorf("previous categories should be kept, got %+v", tree)
+		}
+	})
+}